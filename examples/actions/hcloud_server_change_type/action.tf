@@ -0,0 +1,25 @@
+resource "hcloud_server" "worker" {
+  name        = "worker"
+  server_type = "cpx22"
+  image       = "ubuntu-24.04"
+
+  lifecycle {
+    # Keep the Server Type changed by the action.
+    ignore_changes = [server_type]
+  }
+}
+
+# Scale up the server before a batch job, and back down afterwards.
+action "hcloud_server_change_type" "scale_up" {
+  config {
+    server_id   = hcloud_server.worker.id
+    server_type = "cpx42"
+  }
+}
+
+action "hcloud_server_change_type" "scale_down" {
+  config {
+    server_id   = hcloud_server.worker.id
+    server_type = "cpx22"
+  }
+}
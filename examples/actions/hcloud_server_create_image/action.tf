@@ -0,0 +1,23 @@
+resource "hcloud_server" "main" {
+  name        = "main"
+  server_type = "cpx22"
+  image       = "ubuntu-24.04"
+
+  lifecycle {
+    action_trigger {
+      events  = [before_update]
+      actions = [action.hcloud_server_create_image.pre_change]
+    }
+  }
+}
+
+action "hcloud_server_create_image" "pre_change" {
+  config {
+    server_id   = hcloud_server.main.id
+    type        = "snapshot"
+    description = "Before change"
+    labels = {
+      "purpose" : "pre-change"
+    }
+  }
+}
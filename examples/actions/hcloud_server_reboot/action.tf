@@ -0,0 +1,8 @@
+# Reboot all web servers, two at a time, with a pause between the batches.
+action "hcloud_server_reboot" "web" {
+  config {
+    label_selector = "role=web"
+    max_parallel   = 2
+    batch_pause    = "1m"
+  }
+}
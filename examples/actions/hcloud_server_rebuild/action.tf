@@ -0,0 +1,13 @@
+data "hcloud_image" "golden" {
+  with_selector = "role=golden"
+  most_recent   = true
+}
+
+action "hcloud_server_rebuild" "main" {
+  config {
+    server_id                = 123
+    image                    = data.hcloud_image.golden.id
+    wait_for_running         = true
+    wait_for_running_timeout = "5m"
+  }
+}
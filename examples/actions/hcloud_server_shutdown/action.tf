@@ -0,0 +1,7 @@
+action "hcloud_server_shutdown" "main" {
+  config {
+    server_id = 123
+    timeout   = "5m"
+    fallback  = "poweroff"
+  }
+}
@@ -0,0 +1,13 @@
+data "hcloud_load_balancer_metrics" "open_connections" {
+  load_balancer_id = 123
+  type             = "open_connections"
+  duration         = "168h"
+  step             = 3600
+}
+
+check "open_connections" {
+  assert {
+    condition     = data.hcloud_load_balancer_metrics.open_connections.series[0].max < 8000
+    error_message = "The Load Balancer is close to the maximum number of connections of its type, consider upgrading to lb21."
+  }
+}
@@ -0,0 +1,13 @@
+data "hcloud_server_metrics" "cpu" {
+  server_id = 123
+  type      = "cpu"
+  duration  = "24h"
+  step      = 300
+}
+
+check "cpu_usage" {
+  assert {
+    condition     = data.hcloud_server_metrics.cpu.series[0].p95 < 50
+    error_message = "The Server used more than 50% CPU, it should not be downscaled."
+  }
+}
@@ -0,0 +1,6 @@
+import {
+  to = hcloud_server.example
+  identity = {
+    id = 123
+  }
+}
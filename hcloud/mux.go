@@ -7,6 +7,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
 	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/traceutil"
 )
 
 func GetMuxedProvider(ctx context.Context) (func() tfprotov6.ProviderServer, error) {
@@ -30,5 +32,9 @@ func GetMuxedProvider(ctx context.Context) (func() tfprotov6.ProviderServer, err
 		return nil, err
 	}
 
-	return muxServer.ProviderServer, nil
+	server := traceutil.NewProviderServer(muxServer.ProviderServer())
+
+	return func() tfprotov6.ProviderServer {
+		return server
+	}, nil
 }
@@ -21,3 +21,14 @@ func TestMuxedProviderSchema(t *testing.T) {
 
 	assert.Len(t, resp.Diagnostics, 0)
 }
+
+func TestMuxedProviderActions(t *testing.T) {
+	providerFactory, err := GetMuxedProvider(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The traced provider server must still serve the optional RPCs.
+	assert.Implements(t, (*tfprotov6.ProviderServerWithActions)(nil), providerFactory())
+	assert.Implements(t, (*tfprotov6.ProviderServerWithListResource)(nil), providerFactory())
+}
@@ -2,11 +2,17 @@ package hcloud
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -34,7 +40,10 @@ import (
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/storageboxsnapshot"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/storageboxsubaccount"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/storageboxtype"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/resourceutil"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/tflogutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/transportutil"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/zone"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/zonerecord"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/zonerrset"
@@ -70,6 +79,18 @@ func (p *PluginProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"token_file": schema.StringAttribute{
+				Description: "Path to a file containing the Hetzner Cloud API token. Used when neither the token attribute nor the HCLOUD_TOKEN environment variable are set.",
+				Optional:    true,
+			},
+			"token_command": schema.ListAttribute{
+				Description: "Command printing the Hetzner Cloud API token on its standard output, as a list of the executable and its arguments. The command runs once per Terraform run. Used when neither the token attribute, the HCLOUD_TOKEN environment variable nor the token_file attribute are set.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
 			"endpoint": schema.StringAttribute{
 				Description: "The Hetzner Cloud API endpoint, can be used to override the default API Endpoint https://api.hetzner.cloud/v1.",
 				Optional:    true,
@@ -78,6 +99,33 @@ func (p *PluginProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				Description: "The Hetzner API endpoint, can be used to override the default API Endpoint https://api.hetzner.com/v1.",
 				Optional:    true,
 			},
+			"ca_bundle": schema.StringAttribute{
+				Description: "PEM encoded CA certificates, trusted in addition to the system certificates to verify the certificate presented by the API endpoints.",
+				Optional:    true,
+			},
+			"client_certificate": schema.StringAttribute{
+				Description: "PEM encoded client certificate presented to the API endpoints. Requires client_key.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM encoded private key of the client certificate. Requires client_certificate.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_certificate")),
+				},
+			},
+			"http_proxy": schema.StringAttribute{
+				Description: "URL of the proxy the API requests are sent through, the http, https and socks5 schemes are supported. By default, the proxy is read from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.",
+				Optional:    true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Skip the verification of the certificate presented by the API endpoints. This is insecure, only use it to reach test endpoints.",
+				Optional:    true,
+			},
 			"poll_interval": schema.StringAttribute{
 				Description: "The interval at which actions are polled by the client. Default `500ms`. Increase this interval if you run into rate limiting errors.",
 				Optional:    true,
@@ -89,6 +137,88 @@ func (p *PluginProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 					stringvalidator.OneOf([]string{"constant", "exponential"}...),
 				},
 			},
+			"read_only": schema.BoolAttribute{
+				Description: "Reject every request that could mutate a resource, only reading resources is allowed. Can also be specified with the HCLOUD_READ_ONLY environment variable.",
+				Optional:    true,
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				Description: "The maximum number of requests per second sent to the API, shared by all the resources. By default, requests are only slowed down when the API rate limit budget is running low.",
+				Optional:    true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "The maximum number of concurrent requests sent to the API, shared by all the resources. By default, the number of concurrent requests is not limited.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"default_location": schema.StringAttribute{
+				Description: "Default location of the resources that do not configure any location. Applied when creating a resource.",
+				Optional:    true,
+			},
+			"default_datacenter": schema.StringAttribute{
+				Description: "Default datacenter of the resources that accept a datacenter and do not configure any location or datacenter, when no default_location is configured. Applied when creating a resource.",
+				Optional:    true,
+			},
+			"default_labels": schema.MapAttribute{
+				Description: "Default labels merged into the labels of every resource. Labels set on a resource take precedence over the default labels.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Map{
+					resourceutil.LabelsValidator(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"retry": schema.ListNestedBlock{
+				Description: "Retry policy of the requests failing with a transient error.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"max_attempts": schema.Int64Attribute{
+							Description: "The maximum number of attempts of a request. Default `5`.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"max_backoff": schema.StringAttribute{
+							Description: "The maximum duration to wait between two attempts. Default `30s`.",
+							Optional:    true,
+						},
+						"retryable_error_codes": schema.ListAttribute{
+							Description: "The API error codes to retry. Requests failing with a 5xx status code are always retried. Default `[\"conflict\", \"locked\", \"rate_limit_exceeded\", \"timeout\"]`.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+			},
+			"ignore_labels": schema.ListNestedBlock{
+				Description: "Labels managed outside of Terraform, ignored when reading and preserved when updating the labels of every resource.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"keys": schema.SetAttribute{
+							Description: "Label keys to ignore.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"key_prefixes": schema.SetAttribute{
+							Description: "Label key prefixes to ignore.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+			},
 		},
 		// TODO: Uncomment once we get rid of the SDK v2 Provider
 		// MarkdownDescription: `The Hetzner Cloud (hcloud) provider is used to interact with the resources supported by
@@ -99,11 +229,44 @@ func (p *PluginProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 
 // PluginProviderModel describes the provider data model.
 type PluginProviderModel struct {
-	Token           types.String `tfsdk:"token"`
-	Endpoint        types.String `tfsdk:"endpoint"`
-	EndpointHetzner types.String `tfsdk:"endpoint_hetzner"`
-	PollInterval    types.String `tfsdk:"poll_interval"`
-	PollFunction    types.String `tfsdk:"poll_function"`
+	Token                 types.String                      `tfsdk:"token"`
+	TokenFile             types.String                      `tfsdk:"token_file"`
+	TokenCommand          types.List                        `tfsdk:"token_command"`
+	Endpoint              types.String                      `tfsdk:"endpoint"`
+	EndpointHetzner       types.String                      `tfsdk:"endpoint_hetzner"`
+	CABundle              types.String                      `tfsdk:"ca_bundle"`
+	ClientCertificate     types.String                      `tfsdk:"client_certificate"`
+	ClientKey             types.String                      `tfsdk:"client_key"`
+	HTTPProxy             types.String                      `tfsdk:"http_proxy"`
+	InsecureSkipVerify    types.Bool                        `tfsdk:"insecure_skip_verify"`
+	PollInterval          types.String                      `tfsdk:"poll_interval"`
+	PollFunction          types.String                      `tfsdk:"poll_function"`
+	ReadOnly              types.Bool                        `tfsdk:"read_only"`
+	MaxRequestsPerSecond  types.Float64                     `tfsdk:"max_requests_per_second"`
+	MaxConcurrentRequests types.Int64                       `tfsdk:"max_concurrent_requests"`
+	DefaultLocation       types.String                      `tfsdk:"default_location"`
+	DefaultDatacenter     types.String                      `tfsdk:"default_datacenter"`
+	DefaultLabels         types.Map                         `tfsdk:"default_labels"`
+	IgnoreLabels          []PluginProviderIgnoreLabelsModel `tfsdk:"ignore_labels"`
+	Retry                 []PluginProviderRetryModel        `tfsdk:"retry"`
+}
+
+// insecureSkipVerifyWarning is the detail of the warning raised when the verification of
+// the API certificates is disabled.
+const insecureSkipVerifyWarning = "The provider insecure_skip_verify attribute is enabled: the certificate presented by the API endpoints is not verified, " +
+	"and the API token could be intercepted by anyone able to tamper with the connection. Only use it to reach test endpoints."
+
+// PluginProviderRetryModel describes the provider retry data model.
+type PluginProviderRetryModel struct {
+	MaxAttempts         types.Int64  `tfsdk:"max_attempts"`
+	MaxBackoff          types.String `tfsdk:"max_backoff"`
+	RetryableErrorCodes []string     `tfsdk:"retryable_error_codes"`
+}
+
+// PluginProviderIgnoreLabelsModel describes the provider ignore_labels data model.
+type PluginProviderIgnoreLabelsModel struct {
+	Keys        []string `tfsdk:"keys"`
+	KeyPrefixes []string `tfsdk:"key_prefixes"`
 }
 
 // Configure is called at the beginning of the provider lifecycle, when
@@ -135,24 +298,43 @@ func (p *PluginProvider) Configure(ctx context.Context, req provider.ConfigureRe
 
 	endpointHetzner := os.Getenv("HETZNER_ENDPOINT")
 	if data.EndpointHetzner.ValueString() != "" {
-		endpointHetzner = data.Endpoint.ValueString()
+		endpointHetzner = data.EndpointHetzner.ValueString()
 	}
 	if endpointHetzner != "" {
 		opts = append(opts, hcloud.WithHetznerEndpoint(endpointHetzner))
 	}
 
-	token := os.Getenv("HCLOUD_TOKEN")
-	if data.Token.ValueString() != "" {
-		token = data.Token.ValueString()
+	tokenSources := hcloudutil.TokenSources{
+		Token: data.Token.ValueString(),
+		Env:   os.Getenv("HCLOUD_TOKEN"),
+		File:  data.TokenFile.ValueString(),
 	}
-	if token != "" {
-		opts = append(opts, hcloud.WithToken(token))
-	} else {
+	if !data.TokenCommand.IsNull() && !data.TokenCommand.IsUnknown() {
+		resp.Diagnostics.Append(data.TokenCommand.ElementsAs(ctx, &tokenSources.Command, false)...)
+	}
+
+	token, tokenSource, err := hcloudutil.ResolveToken(ctx, tokenSources)
+	switch {
+	case errors.Is(err, hcloudutil.ErrMissingToken):
 		resp.Diagnostics.AddAttributeError(
 			path.Root("token"),
 			"Missing Hetzner Cloud API token",
-			"While configuring the provider, the Hetzner Cloud API token was not found in the HCLOUD_TOKEN environment variable or provider configuration block token attribute.",
+			"While configuring the provider, the Hetzner Cloud API token was not found in the provider configuration block token, token_file or token_command attributes, or the HCLOUD_TOKEN environment variable.\n\n"+hcloudutil.TokenPrecedence,
+		)
+	case err != nil:
+		resp.Diagnostics.AddError(
+			"Invalid Hetzner Cloud API token",
+			fmt.Sprintf("While configuring the provider, the Hetzner Cloud API token could not be read from %s.\n\n%s\n\n%s", tokenSource, err.Error(), hcloudutil.TokenPrecedence),
 		)
+	default:
+		opts = append(opts, hcloud.WithToken(token))
+
+		if configured := hcloudutil.ConfiguredTokenSources(tokenSources); len(configured) > 1 {
+			resp.Diagnostics.AddWarning(
+				"Multiple Hetzner Cloud API token sources",
+				fmt.Sprintf("The Hetzner Cloud API token is configured in multiple sources (%s), the token from %s is used.\n\n%s", strings.Join(configured, ", "), tokenSource, hcloudutil.TokenPrecedence),
+			)
+		}
 	}
 
 	pollOpts := hcloud.PollOpts{
@@ -179,6 +361,77 @@ func (p *PluginProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	}
 	opts = append(opts, hcloud.WithPollOpts(pollOpts))
 
+	transportConfig := transportutil.Config{
+		MaxRequestsPerSecond:  data.MaxRequestsPerSecond.ValueFloat64(),
+		MaxConcurrentRequests: int(data.MaxConcurrentRequests.ValueInt64()),
+		TLS: transportutil.TLSConfig{
+			CABundle:           data.CABundle.ValueString(),
+			ClientCertificate:  data.ClientCertificate.ValueString(),
+			ClientKey:          data.ClientKey.ValueString(),
+			InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+		},
+		HTTPProxy: data.HTTPProxy.ValueString(),
+	}
+	if transportConfig.TLS.InsecureSkipVerify {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"Insecure TLS connections to the API",
+			insecureSkipVerifyWarning,
+		)
+	}
+
+	if data.ReadOnly.IsNull() {
+		if value := os.Getenv("HCLOUD_READ_ONLY"); value != "" {
+			readOnly, err := strconv.ParseBool(value)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("read_only"),
+					"Unparsable HCLOUD_READ_ONLY environment variable value",
+					fmt.Sprintf("An unexpected error was encountered trying to parse the value.\n\n%s", err.Error()),
+				)
+			}
+			transportConfig.ReadOnly = readOnly
+		}
+	} else {
+		transportConfig.ReadOnly = data.ReadOnly.ValueBool()
+	}
+	for _, item := range data.Retry {
+		transportConfig.Retry.MaxAttempts = int(item.MaxAttempts.ValueInt64())
+		if item.MaxBackoff.ValueString() != "" {
+			maxBackoff, err := time.ParseDuration(item.MaxBackoff.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("retry").AtListIndex(0).AtName("max_backoff"),
+					"Unparsable max backoff value",
+					fmt.Sprintf("An unexpected error was encountered trying to parse the value.\n\n%s", err.Error()),
+				)
+			}
+			transportConfig.Retry.MaxBackoff = maxBackoff
+		}
+		if len(item.RetryableErrorCodes) > 0 {
+			transportConfig.Retry.RetryableErrorCodes = item.RetryableErrorCodes
+		}
+	}
+	transportOpts, err := transportutil.ClientOptions(transportConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid HTTP client configuration",
+			fmt.Sprintf("While configuring the provider, the HTTP client could not be created from the ca_bundle, client_certificate, client_key or http_proxy attributes.\n\n%s", err.Error()),
+		)
+	}
+	opts = append(opts, transportOpts...)
+
+	var defaultLabels map[string]string
+	if !data.DefaultLabels.IsNull() {
+		resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, data.DefaultLabels, &defaultLabels)...)
+	}
+
+	var ignoreLabelKeys, ignoreLabelKeyPrefixes []string
+	for _, item := range data.IgnoreLabels {
+		ignoreLabelKeys = append(ignoreLabelKeys, item.Keys...)
+		ignoreLabelKeyPrefixes = append(ignoreLabelKeyPrefixes, item.KeyPrefixes...)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -192,10 +445,21 @@ func (p *PluginProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		),
 	)
 
-	client := hcloud.NewClient(opts...)
-	resp.DataSourceData = client
-	resp.ResourceData = client
-	resp.ActionData = client
+	providerData := &hcloudutil.ProviderData{
+		Client: hcloud.NewClient(opts...),
+		Labels: hcloudutil.LabelsConfig{
+			Default:           defaultLabels,
+			IgnoreKeys:        ignoreLabelKeys,
+			IgnoreKeyPrefixes: ignoreLabelKeyPrefixes,
+		},
+		Defaults: hcloudutil.DefaultsConfig{
+			Location:   data.DefaultLocation.ValueString(),
+			Datacenter: data.DefaultDatacenter.ValueString(),
+		},
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.ActionData = providerData
 
 	tflog.Info(ctx, "terraform-provider-hcloud info", map[string]any{"version": Version, "commit": Commit})
 	tflog.Info(ctx, "hcloud-go info", map[string]any{"version": hcloud.Version})
@@ -212,12 +476,14 @@ func (p *PluginProvider) DataSources(_ context.Context) []func() datasource.Data
 		datacenter.NewDataSourceList,
 		image.NewDataSource,
 		image.NewDataSourceList,
+		loadbalancer.NewMetricsDataSource,
 		loadbalancertype.NewDataSource,
 		loadbalancertype.NewDataSourceList,
 		location.NewDataSource,
 		location.NewDataSourceList,
 		primaryip.NewDataSource,
 		primaryip.NewDataSourceList,
+		server.NewMetricsDataSource,
 		servertype.NewDataSource,
 		servertype.NewDataSourceList,
 		sshkey.NewDataSource,
@@ -247,6 +513,7 @@ func (p *PluginProvider) Resources(_ context.Context) []func() resource.Resource
 		loadbalancer.NewNetworkResource,
 		primaryip.NewResource,
 		rdns.NewResource,
+		server.NewResource,
 		server.NewNetworkResource,
 		sshkey.NewResource,
 		storagebox.NewResource,
@@ -264,6 +531,10 @@ func (p *PluginProvider) Actions(_ context.Context) []func() action.Action {
 		server.NewPoweroffAction,
 		server.NewRebootAction,
 		server.NewResetAction,
+		server.NewShutdownAction,
+		server.NewRebuildAction,
+		server.NewCreateImageAction,
+		server.NewChangeTypeAction,
 	}
 }
 
@@ -3,9 +3,13 @@ package hcloud
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"os"
+	"strconv"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -21,6 +25,7 @@ import (
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/server"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/snapshot"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/transportutil"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/volume"
 )
 
@@ -40,14 +45,25 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("HCLOUD_TOKEN", nil),
 				Description: "The Hetzner Cloud API token, can also be specified with the HCLOUD_TOKEN environment variable.",
 				ValidateFunc: func(val any, key string) (warns []string, errs []error) { // nolint:revive
-					token := val.(string)
-					if len(token) != 64 {
-						errs = append(errs, errors.New("entered token is invalid (must be exactly 64 characters long)"))
+					if err := hcloudutil.ValidateToken(val.(string)); err != nil {
+						errs = append(errs, err)
 					}
 					return
 				},
 				Sensitive: true,
 			},
+			"token_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a file containing the Hetzner Cloud API token. Used when neither the token attribute nor the HCLOUD_TOKEN environment variable are set.",
+			},
+			"token_command": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Command printing the Hetzner Cloud API token on its standard output, as a list of the executable and its arguments. The command runs once per Terraform run. Used when neither the token attribute, the HCLOUD_TOKEN environment variable nor the token_file attribute are set.",
+			},
 			"endpoint": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -60,6 +76,34 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("HETZNER_ENDPOINT", nil),
 				Description: "The Hetzner API endpoint, can be used to override the default API Endpoint https://api.hetzner.com/v1.",
 			},
+			"ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM encoded CA certificates, trusted in addition to the system certificates to verify the certificate presented by the API endpoints.",
+			},
+			"client_certificate": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key"},
+				Description:  "PEM encoded client certificate presented to the API endpoints. Requires client_key.",
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"client_certificate"},
+				Description:  "PEM encoded private key of the client certificate. Requires client_certificate.",
+			},
+			"http_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of the proxy the API requests are sent through, the http, https and socks5 schemes are supported. By default, the proxy is read from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.",
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Skip the verification of the certificate presented by the API endpoints. This is insecure, only use it to reach test endpoints.",
+			},
 			"poll_interval": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -73,6 +117,100 @@ func Provider() *schema.Provider {
 				Description:  "The type of function to be used during the polling.",
 				ValidateFunc: validation.StringInSlice([]string{"constant", "exponential"}, false),
 			},
+			"read_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				DefaultFunc: func() (any, error) {
+					if value := os.Getenv("HCLOUD_READ_ONLY"); value != "" {
+						return strconv.ParseBool(value)
+					}
+					return false, nil
+				},
+				Description: "Reject every request that could mutate a resource, only reading resources is allowed. Can also be specified with the HCLOUD_READ_ONLY environment variable.",
+			},
+			"max_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Description:  "The maximum number of requests per second sent to the API, shared by all the resources. By default, requests are only slowed down when the API rate limit budget is running low.",
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The maximum number of concurrent requests sent to the API, shared by all the resources. By default, the number of concurrent requests is not limited.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"default_location": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Default location of the resources that do not configure any location. Applied when creating a resource.",
+			},
+			"default_datacenter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Default datacenter of the resources that accept a datacenter and do not configure any location or datacenter, when no default_location is configured. Applied when creating a resource.",
+			},
+			"default_labels": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Default labels merged into the labels of every resource. Labels set on a resource take precedence over the default labels.",
+				ValidateDiagFunc: func(i any, _ cty.Path) diag.Diagnostics {
+					if ok, err := hcloud.ValidateResourceLabels(i.(map[string]any)); !ok {
+						return diag.FromErr(err)
+					}
+					return nil
+				},
+			},
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Retry policy of the requests failing with a transient error.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "The maximum number of attempts of a request. Default `5`.",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"max_backoff": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The maximum duration to wait between two attempts. Default `30s`.",
+						},
+						"retryable_error_codes": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The API error codes to retry. Requests failing with a 5xx status code are always retried. Default `[\"conflict\", \"locked\", \"rate_limit_exceeded\", \"timeout\"]`.",
+						},
+					},
+				},
+			},
+			"ignore_labels": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Labels managed outside of Terraform, ignored when reading and preserved when updating the labels of every resource.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"keys": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Label keys to ignore.",
+						},
+						"key_prefixes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Label key prefixes to ignore.",
+						},
+					},
+				},
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			certificate.UploadedResourceType:  certificate.UploadedResource(),
@@ -88,7 +226,6 @@ func Provider() *schema.Provider {
 			network.ResourceType:              network.Resource(),
 			network.RouteResourceType:         network.RouteResource(),
 			network.SubnetResourceType:        network.SubnetResource(),
-			server.ResourceType:               server.Resource(),
 			snapshot.ResourceType:             snapshot.Resource(),
 			volume.AttachmentResourceType:     volume.AttachmentResource(),
 			volume.ResourceType:               volume.Resource(),
@@ -116,9 +253,26 @@ func Provider() *schema.Provider {
 	}
 }
 
-func providerConfigure(_ context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
+	tokenSources := hcloudutil.TokenSources{
+		Token: d.Get("token").(string), // Includes the HCLOUD_TOKEN environment variable
+		File:  d.Get("token_file").(string),
+	}
+	for _, arg := range d.Get("token_command").([]any) {
+		tokenSources.Command = append(tokenSources.Command, arg.(string))
+	}
+
+	token, tokenSource, err := hcloudutil.ResolveToken(ctx, tokenSources)
+	if err != nil && !errors.Is(err, hcloudutil.ErrMissingToken) {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid Hetzner Cloud API token",
+			Detail:   fmt.Sprintf("While configuring the provider, the Hetzner Cloud API token could not be read from %s.\n\n%s\n\n%s", tokenSource, err.Error(), hcloudutil.TokenPrecedence),
+		}}
+	}
+
 	opts := []hcloud.ClientOption{
-		hcloud.WithToken(d.Get("token").(string)),
+		hcloud.WithToken(token),
 		hcloud.WithApplication("hcloud-terraform", Version),
 	}
 	if endpoint, ok := d.GetOk("endpoint"); ok {
@@ -139,10 +293,81 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (any, diag.Dia
 			opts = append(opts, hcloud.WithPollOpts(hcloud.PollOpts{BackoffFunc: hcloud.ExponentialBackoff(2, pollInterval)}))
 		}
 	}
+	transportConfig := transportutil.Config{
+		MaxRequestsPerSecond:  d.Get("max_requests_per_second").(float64),
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+		ReadOnly:              d.Get("read_only").(bool),
+		TLS: transportutil.TLSConfig{
+			CABundle:           d.Get("ca_bundle").(string),
+			ClientCertificate:  d.Get("client_certificate").(string),
+			ClientKey:          d.Get("client_key").(string),
+			InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+		},
+		HTTPProxy: d.Get("http_proxy").(string),
+	}
+	if transportConfig.TLS.InsecureSkipVerify {
+		// The warning diagnostic is raised by the framework provider.
+		log.Printf("[WARN] %s", insecureSkipVerifyWarning)
+	}
+	if retry, ok := d.GetOk("retry"); ok {
+		for _, item := range retry.([]any) {
+			item, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			transportConfig.Retry.MaxAttempts = item["max_attempts"].(int)
+			if maxBackoff := item["max_backoff"].(string); maxBackoff != "" {
+				maxBackoff, err := time.ParseDuration(maxBackoff)
+				if err != nil {
+					return nil, hcloudutil.ErrorToDiag(err)
+				}
+				transportConfig.Retry.MaxBackoff = maxBackoff
+			}
+			if codes := item["retryable_error_codes"].([]any); len(codes) > 0 {
+				transportConfig.Retry.RetryableErrorCodes = make([]string, 0, len(codes))
+				for _, code := range codes {
+					transportConfig.Retry.RetryableErrorCodes = append(transportConfig.Retry.RetryableErrorCodes, code.(string))
+				}
+			}
+		}
+	}
+	transportOpts, err := transportutil.ClientOptions(transportConfig)
+	if err != nil {
+		return nil, hcloudutil.ErrorToDiag(err)
+	}
+	opts = append(opts, transportOpts...)
 	if logging.LogLevel() != "" {
 		opts = append(opts, hcloud.WithDebugWriter(log.Writer()))
 	}
 	log.Printf("[DEBUG] hcloud terraform provider version: %s commit: %s", Version, Commit)
 	log.Printf("[DEBUG] hcloud-go version: %s", hcloud.Version)
-	return hcloud.NewClient(opts...), nil
+
+	data := &hcloudutil.ProviderData{
+		Client: hcloud.NewClient(opts...),
+		Defaults: hcloudutil.DefaultsConfig{
+			Location:   d.Get("default_location").(string),
+			Datacenter: d.Get("default_datacenter").(string),
+		},
+	}
+	if defaultLabels, ok := d.GetOk("default_labels"); ok {
+		data.Labels.Default = make(map[string]string)
+		for k, v := range defaultLabels.(map[string]any) {
+			data.Labels.Default[k] = v.(string)
+		}
+	}
+	if ignoreLabels, ok := d.GetOk("ignore_labels"); ok {
+		for _, item := range ignoreLabels.([]any) {
+			item, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			for _, key := range item["keys"].(*schema.Set).List() {
+				data.Labels.IgnoreKeys = append(data.Labels.IgnoreKeys, key.(string))
+			}
+			for _, prefix := range item["key_prefixes"].(*schema.Set).List() {
+				data.Labels.IgnoreKeyPrefixes = append(data.Labels.IgnoreKeyPrefixes, prefix.(string))
+			}
+		}
+	}
+	return data, nil
 }
@@ -38,7 +38,6 @@ func TestProvider_Resources(t *testing.T) {
 		network.ResourceType,
 		network.RouteResourceType,
 		network.SubnetResourceType,
-		server.ResourceType,
 		snapshot.ResourceType,
 		volume.AttachmentResourceType,
 		volume.ResourceType,
@@ -109,7 +109,7 @@ func DataSourceList() *schema.Resource {
 }
 
 func dataSourceHcloudCertificateRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	if id, ok := d.GetOk("id"); ok {
 		cert, _, err := client.Certificate.GetByID(ctx, util.CastInt64(id))
@@ -119,7 +119,7 @@ func dataSourceHcloudCertificateRead(ctx context.Context, d *schema.ResourceData
 		if cert == nil {
 			return diag.Errorf("certificate not found: id: %d", id)
 		}
-		setCertificateSchema(d, cert)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getCertificateAttributes(cert)))
 		return nil
 	}
 	if name, ok := d.GetOk("name"); ok {
@@ -130,7 +130,7 @@ func dataSourceHcloudCertificateRead(ctx context.Context, d *schema.ResourceData
 		if cert == nil {
 			return diag.Errorf("certificate not found: name: %s", name)
 		}
-		setCertificateSchema(d, cert)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getCertificateAttributes(cert)))
 		return nil
 	}
 	if selector, ok := d.GetOk("with_selector"); ok && selector != "" {
@@ -150,7 +150,7 @@ func dataSourceHcloudCertificateRead(ctx context.Context, d *schema.ResourceData
 		if len(allCertificates) > 1 {
 			return hcloudutil.ErrorToDiag(fmt.Errorf("more than one Certificate found for selector %q", selector))
 		}
-		setCertificateSchema(d, allCertificates[0])
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getCertificateAttributes(allCertificates[0])))
 		return nil
 	}
 
@@ -158,7 +158,7 @@ func dataSourceHcloudCertificateRead(ctx context.Context, d *schema.ResourceData
 }
 
 func dataSourceHcloudCertificateListRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	selector := d.Get("with_selector")
 	opts := hcloud.CertificateListOpts{
@@ -175,7 +175,7 @@ func dataSourceHcloudCertificateListRead(ctx context.Context, d *schema.Resource
 	tfCertificates := make([]map[string]any, len(allCertificates))
 	for i, certificate := range allCertificates {
 		ids[i] = util.FormatID(certificate.ID)
-		tfCertificates[i] = getCertificateAttributes(certificate)
+		tfCertificates[i] = hcloudutil.DataSourceAttributes(m, getCertificateAttributes(certificate))
 	}
 	d.Set("certificates", tfCertificates)
 	d.SetId(datasourceutil.ListID(ids))
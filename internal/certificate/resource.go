@@ -42,6 +42,7 @@ func UploadedResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: hcloudutil.CustomizeDiffLabelsAll,
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
 			{
@@ -89,6 +90,7 @@ func UploadedResource() *schema.Resource {
 					return nil
 				},
 			},
+			"labels_all": hcloudutil.LabelsAllSchema(),
 			"domain_names": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -127,6 +129,7 @@ func ManagedResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: hcloudutil.CustomizeDiffLabelsAll,
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
 			{
@@ -158,6 +161,7 @@ func ManagedResource() *schema.Resource {
 				Optional: true,
 				Elem:     schema.TypeString,
 			},
+			"labels_all": hcloudutil.LabelsAllSchema(),
 			"certificate": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -183,19 +187,14 @@ func ManagedResource() *schema.Resource {
 }
 
 func createUploadedResource(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	opts := hcloud.CertificateCreateOpts{
 		Name:        d.Get("name").(string),
 		PrivateKey:  d.Get("private_key").(string),
 		Certificate: d.Get("certificate").(string),
 	}
-	if labels, ok := d.GetOk("labels"); ok {
-		opts.Labels = make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			opts.Labels[k] = v.(string)
-		}
-	}
+	opts.Labels = hcloudutil.LabelsFromResourceData(d, m)
 
 	res, _, err := client.Certificate.Create(ctx, opts)
 	if err != nil {
@@ -206,7 +205,7 @@ func createUploadedResource(ctx context.Context, d *schema.ResourceData, m any)
 }
 
 func createManagedResource(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
 	opts := hcloud.CertificateCreateOpts{
 		Name: d.Get("name").(string),
@@ -219,19 +218,14 @@ func createManagedResource(ctx context.Context, d *schema.ResourceData, m any) d
 		opts.DomainNames[i] = n.(string)
 	}
 
-	if labels, ok := d.GetOk("labels"); ok {
-		opts.Labels = make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			opts.Labels[k] = v.(string)
-		}
-	}
+	opts.Labels = hcloudutil.LabelsFromResourceData(d, m)
 
 	res, _, err := c.Certificate.CreateCertificate(ctx, opts)
 	if err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 	d.SetId(util.FormatID(res.Certificate.ID))
-	if err = c.Action.WaitFor(ctx, res.Action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, res.Action); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 
@@ -239,7 +233,7 @@ func createManagedResource(ctx context.Context, d *schema.ResourceData, m any) d
 }
 
 func readResource(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	cert, _, err := client.Certificate.Get(ctx, d.Id())
 	if err != nil {
@@ -252,7 +246,11 @@ func readResource(ctx context.Context, d *schema.ResourceData, m any) diag.Diagn
 		d.SetId("")
 		return nil
 	}
+	labels := d.Get("labels")
 	setCertificateSchema(d, cert)
+	if err := hcloudutil.SetResourceDataLabels(d, m, labels, cert.Labels); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
@@ -287,7 +285,7 @@ func getCertificateAttributes(cert *hcloud.Certificate) map[string]any {
 }
 
 func updateResource(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	cert, _, err := client.Certificate.Get(ctx, d.Id())
 	if err != nil {
@@ -307,12 +305,19 @@ func updateResource(ctx context.Context, d *schema.ResourceData, m any) diag.Dia
 			return hcloudutil.ErrorToDiag(err)
 		}
 	}
-	if d.HasChange("labels") {
-		opts := hcloud.CertificateUpdateOpts{
-			Labels: make(map[string]string),
+	if d.HasChanges("labels", "labels_all") {
+		labels, err := hcloudutil.UpdateLabelsFromResourceData(d, m, func() (map[string]string, error) {
+			in, _, err := client.Certificate.GetByID(ctx, cert.ID)
+			if err != nil || in == nil {
+				return nil, err
+			}
+			return in.Labels, nil
+		})
+		if err != nil {
+			return hcloudutil.ErrorToDiag(err)
 		}
-		for k, v := range d.Get("labels").(map[string]any) {
-			opts.Labels[k] = v.(string)
+		opts := hcloud.CertificateUpdateOpts{
+			Labels: labels,
 		}
 		if _, _, err := client.Certificate.Update(ctx, cert, opts); err != nil {
 			return hcloudutil.ErrorToDiag(err)
@@ -323,7 +328,7 @@ func updateResource(ctx context.Context, d *schema.ResourceData, m any) diag.Dia
 }
 
 func deleteResource(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	certID, err := util.ParseID(d.Id())
 	if err != nil {
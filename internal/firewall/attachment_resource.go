@@ -67,7 +67,7 @@ func readAttachment(ctx context.Context, d *schema.ResourceData, m any) diag.Dia
 		}
 	}
 
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 	fw, _, err := client.Firewall.GetByID(ctx, att.FirewallID)
 	if err != nil {
 		return hcloudutil.ErrorToDiag(err)
@@ -93,7 +93,7 @@ func createAttachment(ctx context.Context, d *schema.ResourceData, m any) (diags
 		return diag.FromErr(err)
 	}
 
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 	actions, _, err := client.Firewall.ApplyResources(ctx, &hcloud.Firewall{ID: att.FirewallID}, att.AllResources())
 	if hcloud.IsError(err, hcloud.ErrorCodeFirewallAlreadyApplied) {
 		return readAttachment(ctx, d, m)
@@ -101,7 +101,7 @@ func createAttachment(ctx context.Context, d *schema.ResourceData, m any) (diags
 	if err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
-	if err = client.Action.WaitFor(ctx, actions...); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &client.Action, actions...); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 
@@ -118,7 +118,7 @@ func updateAttachment(ctx context.Context, d *schema.ResourceData, m any) diag.D
 		return diag.FromErr(err)
 	}
 
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 	fw, _, err := client.Firewall.GetByID(ctx, tf.FirewallID)
 	if err != nil {
 		return hcloudutil.ErrorToDiag(err)
@@ -145,7 +145,7 @@ func updateAttachment(ctx context.Context, d *schema.ResourceData, m any) diag.D
 	}
 	actions = append(actions, as...)
 
-	if err = client.Action.WaitFor(ctx, actions...); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &client.Action, actions...); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 
@@ -165,12 +165,12 @@ func deleteAttachment(ctx context.Context, d *schema.ResourceData, m any) (diags
 	if err := att.FromResourceData(d); err != nil {
 		return diag.FromErr(err)
 	}
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 	actions, _, err := client.Firewall.RemoveResources(ctx, &hcloud.Firewall{ID: att.FirewallID}, att.AllResources())
 	if err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
-	if err = client.Action.WaitFor(ctx, actions...); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &client.Action, actions...); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 	return nil
@@ -141,7 +141,7 @@ func DataSourceList() *schema.Resource {
 }
 
 func dataSourceHcloudFirewallRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 	if id, ok := d.GetOk("id"); ok {
 		i, _, err := client.Firewall.GetByID(ctx, util.CastInt64(id))
 		if err != nil {
@@ -150,7 +150,7 @@ func dataSourceHcloudFirewallRead(ctx context.Context, d *schema.ResourceData, m
 		if i == nil {
 			return diag.Errorf("no firewall found with id %d", id)
 		}
-		setFirewallSchema(d, i)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getFirewallAttributes(i)))
 		return nil
 	}
 	if name, ok := d.GetOk("name"); ok {
@@ -161,7 +161,7 @@ func dataSourceHcloudFirewallRead(ctx context.Context, d *schema.ResourceData, m
 		if i == nil {
 			return diag.Errorf("no firewall found with name %v", name)
 		}
-		setFirewallSchema(d, i)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getFirewallAttributes(i)))
 		return nil
 	}
 
@@ -183,14 +183,14 @@ func dataSourceHcloudFirewallRead(ctx context.Context, d *schema.ResourceData, m
 			sortFirewallListByCreated(allFirewalls)
 			log.Printf("[INFO] %d firewalls found for selector %q, using %d as the most recent one", len(allFirewalls), selector, allFirewalls[0].ID)
 		}
-		setFirewallSchema(d, allFirewalls[0])
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getFirewallAttributes(allFirewalls[0])))
 		return nil
 	}
 	return diag.Errorf("please specify an id, a name or a selector to lookup the firewall")
 }
 
 func dataSourceHcloudFirewallListRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	selector := d.Get("with_selector").(string)
 
@@ -208,7 +208,7 @@ func dataSourceHcloudFirewallListRead(ctx context.Context, d *schema.ResourceDat
 	tfFirewalls := make([]map[string]any, len(allFirewalls))
 	for i, firewall := range allFirewalls {
 		ids[i] = util.FormatID(firewall.ID)
-		tfFirewalls[i] = getFirewallAttributes(firewall)
+		tfFirewalls[i] = hcloudutil.DataSourceAttributes(m, getFirewallAttributes(firewall))
 	}
 	d.Set("firewalls", tfFirewalls)
 	d.SetId(datasourceutil.ListID(ids))
@@ -29,6 +29,7 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: hcloudutil.CustomizeDiffLabelsAll,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -46,6 +47,7 @@ func Resource() *schema.Resource {
 					return nil
 				},
 			},
+			"labels_all": hcloudutil.LabelsAllSchema(),
 			"apply_to": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -141,7 +143,7 @@ func Resource() *schema.Resource {
 }
 
 func resourceFirewallCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	opts := hcloud.FirewallCreateOpts{
 		Name: d.Get("name").(string),
@@ -153,13 +155,7 @@ func resourceFirewallCreate(ctx context.Context, d *schema.ResourceData, m any)
 			}
 		}
 	}
-	if labels, ok := d.GetOk("labels"); ok {
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
-		}
-		opts.Labels = tmpLabels
-	}
+	opts.Labels = hcloudutil.LabelsFromResourceData(d, m)
 	if applyTo, ok := d.GetOk("apply_to"); ok {
 		for _, tfApplyToRaw := range applyTo.(*schema.Set).List() {
 			tfApplyTo := tfApplyToRaw.(map[string]any)
@@ -174,7 +170,7 @@ func resourceFirewallCreate(ctx context.Context, d *schema.ResourceData, m any)
 		return hcloudutil.ErrorToDiag(err)
 	}
 
-	if err = client.Action.WaitFor(ctx, res.Actions...); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &client.Action, res.Actions...); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 
@@ -250,7 +246,7 @@ func toTFRule(hcloudRule hcloud.FirewallRule) map[string]any {
 }
 
 func resourceFirewallRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	id, err := util.ParseID(d.Id())
 	if err != nil {
@@ -269,12 +265,16 @@ func resourceFirewallRead(ctx context.Context, d *schema.ResourceData, m any) di
 		return nil
 	}
 
+	labels := d.Get("labels")
 	setFirewallSchema(d, firewall)
+	if err := hcloudutil.SetResourceDataLabels(d, m, labels, firewall.Labels); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
 func resourceFirewallUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	id, err := util.ParseID(d.Id())
 	if err != nil {
@@ -326,14 +326,19 @@ func resourceFirewallUpdate(ctx context.Context, d *schema.ResourceData, m any)
 		}
 	}
 
-	if d.HasChange("labels") {
-		labels := d.Get("labels")
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
+	if d.HasChanges("labels", "labels_all") {
+		labels, err := hcloudutil.UpdateLabelsFromResourceData(d, m, func() (map[string]string, error) {
+			in, _, err := client.Firewall.GetByID(ctx, firewall.ID)
+			if err != nil || in == nil {
+				return nil, err
+			}
+			return in.Labels, nil
+		})
+		if err != nil {
+			return hcloudutil.ErrorToDiag(err)
 		}
-		_, _, err := client.Firewall.Update(ctx, firewall, hcloud.FirewallUpdateOpts{
-			Labels: tmpLabels,
+		_, _, err = client.Firewall.Update(ctx, firewall, hcloud.FirewallUpdateOpts{
+			Labels: labels,
 		})
 		if err != nil {
 			if resourceFirewallIsNotFound(err, d) {
@@ -411,7 +416,7 @@ func toHcloudFirewallResource(field map[string]any) (hcloud.FirewallResource, er
 }
 
 func resourceFirewallDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	firewallID, err := util.ParseID(d.Id())
 	if err != nil {
@@ -431,7 +436,7 @@ func resourceFirewallDelete(ctx context.Context, d *schema.ResourceData, m any)
 	}
 	// Removing resources from the firewall can sometimes take longer. We
 	// thus retry two times the number of DefaultRetries.
-	err = control.Retry(2*control.DefaultRetries, func() error {
+	err = control.Retry(ctx, 2*control.DefaultRetries, func() error {
 		var hcerr hcloud.Error
 		_, err := client.Firewall.Delete(ctx, firewall)
 		if errors.As(err, &hcerr) {
@@ -507,7 +512,7 @@ func getFirewallAttributes(f *hcloud.Firewall) map[string]any {
 
 func waitForFirewallActions(ctx context.Context, client *hcloud.Client, actions []*hcloud.Action, firewall *hcloud.Firewall) error {
 	log.Printf("[INFO] firewall (%d) waiting for %v actions to complete...", firewall.ID, len(actions))
-	if err := client.Action.WaitFor(ctx, actions...); err != nil {
+	if err := hcloudutil.WaitForActions(ctx, &client.Action, actions...); err != nil {
 		return err
 	}
 	log.Printf("[INFO] firewall (%d) %v actions succeeded", firewall.ID, len(actions))
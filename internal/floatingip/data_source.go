@@ -113,7 +113,7 @@ func DataSourceList() *schema.Resource {
 }
 
 func dataSourceHcloudFloatingIPRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	if id, ok := d.GetOk("id"); ok {
 		f, _, err := client.FloatingIP.GetByID(ctx, util.CastInt64(id))
@@ -123,7 +123,7 @@ func dataSourceHcloudFloatingIPRead(ctx context.Context, d *schema.ResourceData,
 		if f == nil {
 			return diag.Errorf("no Floating IP found with id %d", id)
 		}
-		setFloatingIPSchema(d, f)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getFloatingIPAttributes(f)))
 		return nil
 	}
 	if name, ok := d.GetOk("name"); ok {
@@ -134,7 +134,7 @@ func dataSourceHcloudFloatingIPRead(ctx context.Context, d *schema.ResourceData,
 		if f == nil {
 			return diag.Errorf("no Floating IP found with name %s", name)
 		}
-		setFloatingIPSchema(d, f)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getFloatingIPAttributes(f)))
 		return nil
 	}
 	if ip, ok := d.GetOk("ip_address"); ok {
@@ -147,7 +147,7 @@ func dataSourceHcloudFloatingIPRead(ctx context.Context, d *schema.ResourceData,
 		// Find by 'ip_address'
 		for _, f := range allIPs {
 			if f.IP.String() == ip.(string) {
-				setFloatingIPSchema(d, f)
+				util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getFloatingIPAttributes(f)))
 				return nil
 			}
 		}
@@ -177,7 +177,7 @@ func dataSourceHcloudFloatingIPRead(ctx context.Context, d *schema.ResourceData,
 		if len(allIPs) > 1 {
 			return diag.Errorf("more than one Floating IP found for selector %q", selector)
 		}
-		setFloatingIPSchema(d, allIPs[0])
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getFloatingIPAttributes(allIPs[0])))
 		return nil
 	}
 
@@ -185,7 +185,7 @@ func dataSourceHcloudFloatingIPRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func dataSourceHcloudFloatingIPListRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	selector := d.Get("with_selector").(string)
 
@@ -204,7 +204,7 @@ func dataSourceHcloudFloatingIPListRead(ctx context.Context, d *schema.ResourceD
 	tfIPs := make([]map[string]any, len(allIPs))
 	for i, ip := range allIPs {
 		ids[i] = util.FormatID(ip.ID)
-		tfIPs[i] = getFloatingIPAttributes(ip)
+		tfIPs[i] = hcloudutil.DataSourceAttributes(m, getFloatingIPAttributes(ip))
 	}
 	d.Set("floating_ips", tfIPs)
 	d.SetId(datasourceutil.ListID(ids))
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
@@ -26,6 +27,10 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			hcloudutil.CustomizeDiffLabelsAll,
+			hcloudutil.CustomizeDiffDefaultLocation("home_location", "", "server_id"),
+		),
 		Schema: map[string]*schema.Schema{
 			"type": {
 				Type:     schema.TypeString,
@@ -70,6 +75,7 @@ func Resource() *schema.Resource {
 					return nil
 				},
 			},
+			"labels_all": hcloudutil.LabelsAllSchema(),
 			"delete_protection": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -80,7 +86,7 @@ func Resource() *schema.Resource {
 }
 
 func resourceFloatingIPCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	opts := hcloud.FloatingIPCreateOpts{
 		Type:        hcloud.FloatingIPType(d.Get("type").(string)),
@@ -95,13 +101,7 @@ func resourceFloatingIPCreate(ctx context.Context, d *schema.ResourceData, m any
 	if homeLocation, ok := d.GetOk("home_location"); ok {
 		opts.HomeLocation = &hcloud.Location{Name: homeLocation.(string)}
 	}
-	if labels, ok := d.GetOk("labels"); ok {
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
-		}
-		opts.Labels = tmpLabels
-	}
+	opts.Labels = hcloudutil.LabelsFromResourceData(d, m)
 
 	res, _, err := client.FloatingIP.Create(ctx, opts)
 	if err != nil {
@@ -109,7 +109,7 @@ func resourceFloatingIPCreate(ctx context.Context, d *schema.ResourceData, m any
 	}
 
 	d.SetId(util.FormatID(res.FloatingIP.ID))
-	if err = client.Action.WaitFor(ctx, res.Action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &client.Action, res.Action); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 
@@ -124,7 +124,7 @@ func resourceFloatingIPCreate(ctx context.Context, d *schema.ResourceData, m any
 }
 
 func resourceFloatingIPRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	id, err := util.ParseID(d.Id())
 	if err != nil {
@@ -143,12 +143,16 @@ func resourceFloatingIPRead(ctx context.Context, d *schema.ResourceData, m any)
 		return nil
 	}
 
+	labels := d.Get("labels")
 	setFloatingIPSchema(d, floatingIP)
+	if err := hcloudutil.SetResourceDataLabels(d, m, labels, floatingIP.Labels); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
 func resourceFloatingIPUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	id, err := util.ParseID(d.Id())
 	if err != nil {
@@ -196,7 +200,7 @@ func resourceFloatingIPUpdate(ctx context.Context, d *schema.ResourceData, m any
 				}
 				return hcloudutil.ErrorToDiag(err)
 			}
-			if err = client.Action.WaitFor(ctx, action); err != nil {
+			if err = hcloudutil.WaitForActions(ctx, &client.Action, action); err != nil {
 				return hcloudutil.ErrorToDiag(err)
 			}
 		} else {
@@ -207,19 +211,24 @@ func resourceFloatingIPUpdate(ctx context.Context, d *schema.ResourceData, m any
 				}
 				return hcloudutil.ErrorToDiag(err)
 			}
-			if err = client.Action.WaitFor(ctx, action); err != nil {
+			if err = hcloudutil.WaitForActions(ctx, &client.Action, action); err != nil {
 				return hcloudutil.ErrorToDiag(err)
 			}
 		}
 	}
-	if d.HasChange("labels") {
-		labels := d.Get("labels")
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
+	if d.HasChanges("labels", "labels_all") {
+		labels, err := hcloudutil.UpdateLabelsFromResourceData(d, m, func() (map[string]string, error) {
+			in, _, err := client.FloatingIP.GetByID(ctx, floatingIP.ID)
+			if err != nil || in == nil {
+				return nil, err
+			}
+			return in.Labels, nil
+		})
+		if err != nil {
+			return hcloudutil.ErrorToDiag(err)
 		}
-		_, _, err := client.FloatingIP.Update(ctx, floatingIP, hcloud.FloatingIPUpdateOpts{
-			Labels: tmpLabels,
+		_, _, err = client.FloatingIP.Update(ctx, floatingIP, hcloud.FloatingIPUpdateOpts{
+			Labels: labels,
 		})
 		if err != nil {
 			if resourceFloatingIPIsNotFound(err, d) {
@@ -242,7 +251,7 @@ func resourceFloatingIPUpdate(ctx context.Context, d *schema.ResourceData, m any
 }
 
 func resourceFloatingIPDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	floatingIPID, err := util.ParseID(d.Id())
 	if err != nil {
@@ -272,7 +281,7 @@ func resourceFloatingIPDelete(ctx context.Context, d *schema.ResourceData, m any
 			return hcloudutil.ErrorToDiag(err)
 		}
 
-		if err = client.Action.WaitFor(ctx, action); err != nil {
+		if err = hcloudutil.WaitForActions(ctx, &client.Action, action); err != nil {
 			return hcloudutil.ErrorToDiag(err)
 		}
 	}
@@ -333,5 +342,5 @@ func setProtection(ctx context.Context, c *hcloud.Client, f *hcloud.FloatingIP,
 		return err
 	}
 
-	return c.Action.WaitFor(ctx, action)
+	return hcloudutil.WaitForActions(ctx, &c.Action, action)
 }
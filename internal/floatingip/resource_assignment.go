@@ -41,7 +41,7 @@ func AssignmentResource() *schema.Resource {
 }
 
 func resourceFloatingIPAssignmentCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	floatingIPID := d.Get("floating_ip_id")
 	floatingIP := &hcloud.FloatingIP{ID: util.CastInt64(floatingIPID)}
@@ -54,7 +54,7 @@ func resourceFloatingIPAssignmentCreate(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
-	if err = client.Action.WaitFor(ctx, action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &client.Action, action); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 
@@ -65,7 +65,7 @@ func resourceFloatingIPAssignmentCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceFloatingIPAssignmentRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	floatingIPID, err := util.ParseID(d.Id())
 	if err != nil {
@@ -117,7 +117,7 @@ func resourceFloatingIPAssignmentRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceFloatingIPAssignmentUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	floatingIPID, err := util.ParseID(d.Id())
 	if err != nil {
@@ -150,7 +150,7 @@ func resourceFloatingIPAssignmentUpdate(ctx context.Context, d *schema.ResourceD
 				}
 				return hcloudutil.ErrorToDiag(err)
 			}
-			if err = client.Action.WaitFor(ctx, action); err != nil {
+			if err = hcloudutil.WaitForActions(ctx, &client.Action, action); err != nil {
 				return hcloudutil.ErrorToDiag(err)
 			}
 		} else {
@@ -161,7 +161,7 @@ func resourceFloatingIPAssignmentUpdate(ctx context.Context, d *schema.ResourceD
 				}
 				return hcloudutil.ErrorToDiag(err)
 			}
-			if err = client.Action.WaitFor(ctx, action); err != nil {
+			if err = hcloudutil.WaitForActions(ctx, &client.Action, action); err != nil {
 				return hcloudutil.ErrorToDiag(err)
 			}
 		}
@@ -173,7 +173,7 @@ func resourceFloatingIPAssignmentUpdate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceFloatingIPAssignmentDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	floatingIPID, err := util.ParseID(d.Id())
 	if err != nil {
@@ -199,7 +199,7 @@ func resourceFloatingIPAssignmentDelete(ctx context.Context, d *schema.ResourceD
 			return hcloudutil.ErrorToDiag(err)
 		}
 
-		if err = client.Action.WaitFor(ctx, action); err != nil {
+		if err = hcloudutil.WaitForActions(ctx, &client.Action, action); err != nil {
 			return hcloudutil.ErrorToDiag(err)
 		}
 	}
@@ -24,6 +24,7 @@ var _ datasource.DataSourceWithConfigure = (*DataSourceList)(nil)
 
 type DataSourceList struct {
 	client *hcloud.Client
+	labels hcloudutil.LabelsConfig
 }
 
 func NewDataSourceList() datasource.DataSource {
@@ -35,13 +36,14 @@ func (d *DataSourceList) Metadata(_ context.Context, _ datasource.MetadataReques
 }
 
 func (d *DataSourceList) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	var newDiags diag.Diagnostics
-
-	d.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
 	resp.Diagnostics.Append(newDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	d.client = providerData.Client
+	d.labels = providerData.Labels
 }
 
 func (d *DataSourceList) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -97,6 +99,8 @@ type dataSourceListModel struct {
 	WithArchitecture  types.Set    `tfsdk:"with_architecture"`
 	MostRecent        types.Bool   `tfsdk:"most_recent"`
 	IncludeDeprecated types.Bool   `tfsdk:"include_deprecated"`
+
+	labelsConfig hcloudutil.LabelsConfig
 }
 
 var _ util.ModelFromAPI[[]*hcloud.Image] = &dataSourceListModel{}
@@ -109,6 +113,7 @@ func (m *dataSourceListModel) FromAPI(ctx context.Context, in []*hcloud.Image) d
 	tfItems := make([]attr.Value, 0, len(in))
 	for _, item := range in {
 		var value model
+		value.labelsConfig = m.labelsConfig
 		diags.Append(value.FromAPI(ctx, item)...)
 
 		tfItem, newDiags := value.ToTerraform(ctx)
@@ -177,6 +182,7 @@ func (d *DataSourceList) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
+	data.labelsConfig = d.labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, result)...)
 	if resp.Diagnostics.HasError() {
 		return
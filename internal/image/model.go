@@ -11,6 +11,7 @@ import (
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/resourceutil"
 )
 
@@ -26,6 +27,9 @@ type model struct {
 	Architecture types.String `tfsdk:"architecture"`
 	RapidDeploy  types.Bool   `tfsdk:"rapid_deploy"`
 	Deprecated   types.String `tfsdk:"deprecated"`
+
+	// labelsConfig filters the labels ignored by the provider.
+	labelsConfig hcloudutil.LabelsConfig
 }
 
 var _ util.ModelFromAPI[*hcloud.Image] = &model{}
@@ -59,7 +63,7 @@ func (m *model) FromAPI(ctx context.Context, hc *hcloud.Image) diag.Diagnostics
 	m.Type = types.StringValue(string(hc.Type))
 	m.Name = types.StringValue(hc.Name)
 	m.Description = types.StringValue(hc.Description)
-	m.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, hc.Labels)
+	m.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, m.labelsConfig, hc.Labels)
 	diags.Append(newDiags...)
 	m.Created = types.StringValue(hc.Created.Format(time.RFC3339))
 	m.OSFlavor = types.StringValue(hc.OSFlavor)
@@ -261,7 +261,7 @@ func DataSourceList() *schema.Resource {
 }
 
 func dataSourceHcloudLoadBalancerRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 	if id, ok := d.GetOk("id"); ok {
 		lb, _, err := client.LoadBalancer.GetByID(ctx, util.CastInt64(id))
 		if err != nil {
@@ -270,7 +270,7 @@ func dataSourceHcloudLoadBalancerRead(ctx context.Context, d *schema.ResourceDat
 		if lb == nil {
 			return diag.Errorf("no Load Balancer found with id %d", id)
 		}
-		setLoadBalancerSchema(d, lb)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getLoadBalancerAttributes(lb)))
 		return nil
 	}
 	if name, ok := d.GetOk("name"); ok {
@@ -281,7 +281,7 @@ func dataSourceHcloudLoadBalancerRead(ctx context.Context, d *schema.ResourceDat
 		if lb == nil {
 			return diag.Errorf("no Load Balancer found with name %s", name)
 		}
-		setLoadBalancerSchema(d, lb)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getLoadBalancerAttributes(lb)))
 		return nil
 	}
 
@@ -304,14 +304,14 @@ func dataSourceHcloudLoadBalancerRead(ctx context.Context, d *schema.ResourceDat
 		if len(allLoadBalancers) > 1 {
 			return diag.Errorf("more than one Load Balancer found for selector %q", selector)
 		}
-		setLoadBalancerSchema(d, allLoadBalancers[0])
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getLoadBalancerAttributes(allLoadBalancers[0])))
 		return nil
 	}
 	return diag.Errorf("please specify an id, a name or a selector to lookup the Load Balancer")
 }
 
 func dataSourceHcloudLoadBalancerListRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	selector := d.Get("with_selector").(string)
 
@@ -325,7 +325,7 @@ func dataSourceHcloudLoadBalancerListRead(ctx context.Context, d *schema.Resourc
 	tfLoadBalancers := make([]map[string]any, len(allLoadBalancers))
 	for i, loadBalancer := range allLoadBalancers {
 		ids[i] = util.FormatID(loadBalancer.ID)
-		tfLoadBalancers[i] = getLoadBalancerAttributes(loadBalancer)
+		tfLoadBalancers[i] = hcloudutil.DataSourceAttributes(m, getLoadBalancerAttributes(loadBalancer))
 	}
 	d.Set("load_balancers", tfLoadBalancers)
 	d.SetId(datasourceutil.ListID(ids))
@@ -0,0 +1,140 @@
+package loadbalancer
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/datasourceutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/merge"
+)
+
+// MetricsDataSourceType is the type name of the Hetzner Cloud Load Balancer metrics datasource.
+const MetricsDataSourceType = "hcloud_load_balancer_metrics"
+
+type metricsData struct {
+	LoadBalancerID types.Int64  `tfsdk:"load_balancer_id"`
+	Type           types.String `tfsdk:"type"`
+
+	datasourceutil.MetricsData
+}
+
+var _ datasource.DataSource = (*metricsDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*metricsDataSource)(nil)
+
+type metricsDataSource struct {
+	client *hcloud.Client
+}
+
+func NewMetricsDataSource() datasource.DataSource {
+	return &metricsDataSource{}
+}
+
+// Metadata should return the full name of the data source.
+func (d *metricsDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = MetricsDataSourceType
+}
+
+// Configure enables provider-level data or clients to be set in the
+// provider-defined DataSource type. It is separately executed for each
+// ReadDataSource RPC.
+func (d *metricsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	var newDiags diag.Diagnostics
+
+	d.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	resp.Diagnostics.Append(newDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Schema should return the schema for this data source.
+func (d *metricsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema.MarkdownDescription = util.MarkdownDescription(`
+Provides the metrics of a Hetzner Cloud Load Balancer for a period of time.
+
+Use this data source to inspect the recent usage of a Load Balancer, e.g. before changing its Load Balancer Type.
+`)
+	resp.Schema.Attributes = merge.Maps(
+		map[string]schema.Attribute{
+			"load_balancer_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the Load Balancer.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the metrics: `open_connections`, `connections_per_second`, `requests_per_second` or `bandwidth`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(hcloud.LoadBalancerMetricOpenConnections),
+						string(hcloud.LoadBalancerMetricConnectionsPerSecond),
+						string(hcloud.LoadBalancerMetricRequestsPerSecond),
+						string(hcloud.LoadBalancerMetricBandwidth),
+					),
+				},
+			},
+		},
+		datasourceutil.MetricsSchema(),
+	)
+}
+
+// Read is called when the provider must read data source values in
+// order to update state. Config values should be read from the
+// ReadRequest and new state values set on the ReadResponse.
+func (d *metricsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data metricsData
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(datasourceutil.ReadMetrics(ctx, &data.MetricsData, time.Now(), d.getMetrics(data))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *metricsDataSource) getMetrics(data metricsData) datasourceutil.MetricsGetter {
+	return func(ctx context.Context, start, end time.Time, step int) (*datasourceutil.MetricsResult, diag.Diagnostics) {
+		opts := hcloud.LoadBalancerGetMetricsOpts{
+			Types: []hcloud.LoadBalancerMetricType{hcloud.LoadBalancerMetricType(data.Type.ValueString())},
+			Start: start,
+			End:   end,
+			Step:  step,
+		}
+
+		result, _, err := d.client.LoadBalancer.GetMetrics(ctx, &hcloud.LoadBalancer{ID: data.LoadBalancerID.ValueInt64()}, opts)
+		if err != nil {
+			if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+				return nil, diag.Diagnostics{hcloudutil.NotFoundDiagnostic("load balancer", "id", data.LoadBalancerID.String())}
+			}
+			return nil, hcloudutil.APIErrorDiagnostics(err)
+		}
+
+		timeSeries := make(map[string][]datasourceutil.MetricsValue, len(result.TimeSeries))
+		for name, values := range result.TimeSeries {
+			for _, value := range values {
+				timeSeries[name] = append(timeSeries[name], datasourceutil.MetricsValue(value))
+			}
+		}
+
+		return &datasourceutil.MetricsResult{
+			Start:      result.Start,
+			End:        result.End,
+			Step:       result.Step,
+			TimeSeries: timeSeries,
+		}, nil
+	}
+}
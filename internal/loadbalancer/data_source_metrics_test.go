@@ -0,0 +1,68 @@
+package loadbalancer_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/loadbalancer"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/teste2e"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/testmux"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/testsupport"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/testtemplate"
+)
+
+func TestAccLoadBalancerMetricsDataSource(t *testing.T) {
+	tmplMan := testtemplate.Manager{}
+
+	res := &loadbalancer.RData{
+		Name:         "lb-metrics-ds",
+		LocationName: teste2e.TestLocationName,
+	}
+	res.SetRName("lb-metrics-ds")
+
+	metricsConnections := &loadbalancer.DDataMetrics{
+		LoadBalancerID: res.TFID() + ".id",
+		Type:           "open_connections",
+		Duration:       "30m",
+		Step:           60,
+	}
+	metricsConnections.SetRName("open_connections")
+
+	metricsBandwidth := &loadbalancer.DDataMetrics{
+		LoadBalancerID: res.TFID() + ".id",
+		Type:           "bandwidth",
+	}
+	metricsBandwidth.SetRName("bandwidth")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 teste2e.PreCheck(t),
+		ProtoV6ProviderFactories: testmux.ProtoV6ProviderFactories(),
+		CheckDestroy:             testsupport.CheckResourcesDestroyed(loadbalancer.ResourceType, loadbalancer.ByID(t, nil)),
+		Steps: []resource.TestStep{
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_load_balancer", res,
+				),
+			},
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_load_balancer", res,
+					"testdata/d/hcloud_load_balancer_metrics", metricsConnections,
+					"testdata/d/hcloud_load_balancer_metrics", metricsBandwidth,
+				),
+
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(metricsConnections.TFID(), "start"),
+					resource.TestCheckResourceAttrSet(metricsConnections.TFID(), "end"),
+					resource.TestCheckResourceAttr(metricsConnections.TFID(), "step", "60"),
+					resource.TestCheckResourceAttr(metricsConnections.TFID(), "series.#", "1"),
+					resource.TestCheckResourceAttr(metricsConnections.TFID(), "series.0.name", "open_connections"),
+
+					resource.TestCheckResourceAttr(metricsBandwidth.TFID(), "series.#", "2"),
+					resource.TestCheckResourceAttr(metricsBandwidth.TFID(), "series.0.name", "bandwidth.in"),
+				),
+			},
+		},
+	})
+}
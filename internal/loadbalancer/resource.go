@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -27,6 +28,10 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			hcloudutil.CustomizeDiffLabelsAll,
+			hcloudutil.CustomizeDiffDefaultLocation("location", "", "network_zone"),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -95,6 +100,7 @@ func Resource() *schema.Resource {
 					return nil
 				},
 			},
+			"labels_all": hcloudutil.LabelsAllSchema(),
 			"target": {
 				Type:       schema.TypeSet,
 				Optional:   true,
@@ -132,7 +138,7 @@ func Resource() *schema.Resource {
 }
 
 func resourceLoadBalancerCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
 	opts := hcloud.LoadBalancerCreateOpts{
 		Name:             d.Get("name").(string),
@@ -148,13 +154,7 @@ func resourceLoadBalancerCreate(ctx context.Context, d *schema.ResourceData, m a
 	if networkZone, ok := d.GetOk("network_zone"); ok {
 		opts.NetworkZone = hcloud.NetworkZone(networkZone.(string))
 	}
-	if labels, ok := d.GetOk("labels"); ok {
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
-		}
-		opts.Labels = tmpLabels
-	}
+	opts.Labels = hcloudutil.LabelsFromResourceData(d, m)
 	if targets, ok := d.GetOk("target"); ok {
 		opts.Targets = parseTerraformTarget(targets.(*schema.Set))
 	}
@@ -165,7 +165,7 @@ func resourceLoadBalancerCreate(ctx context.Context, d *schema.ResourceData, m a
 	}
 
 	d.SetId(util.FormatID(res.LoadBalancer.ID))
-	if err = c.Action.WaitFor(ctx, res.Action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, res.Action); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 
@@ -180,7 +180,7 @@ func resourceLoadBalancerCreate(ctx context.Context, d *schema.ResourceData, m a
 }
 
 func resourceLoadBalancerRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	loadBalancer, _, err := client.LoadBalancer.Get(ctx, d.Id())
 	if err != nil {
@@ -193,12 +193,16 @@ func resourceLoadBalancerRead(ctx context.Context, d *schema.ResourceData, m any
 		d.SetId("")
 		return nil
 	}
+	labels := d.Get("labels")
 	setLoadBalancerSchema(d, loadBalancer)
+	if err := hcloudutil.SetResourceDataLabels(d, m, labels, loadBalancer.Labels); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
 func resourceLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 	loadBalancer, _, err := c.LoadBalancer.Get(ctx, d.Id())
 	if err != nil {
 		return hcloudutil.ErrorToDiag(err)
@@ -233,7 +237,7 @@ func resourceLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData, m a
 			}
 			return hcloudutil.ErrorToDiag(err)
 		}
-		if err = c.Action.WaitFor(ctx, action); err != nil {
+		if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 			return hcloudutil.ErrorToDiag(err)
 		}
 	}
@@ -252,19 +256,24 @@ func resourceLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData, m a
 			}
 			return hcloudutil.ErrorToDiag(err)
 		}
-		if err = c.Action.WaitFor(ctx, action); err != nil {
+		if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 			return hcloudutil.ErrorToDiag(err)
 		}
 	}
 
-	if d.HasChange("labels") {
-		labels := d.Get("labels")
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
+	if d.HasChanges("labels", "labels_all") {
+		labels, err := hcloudutil.UpdateLabelsFromResourceData(d, m, func() (map[string]string, error) {
+			in, _, err := c.LoadBalancer.GetByID(ctx, loadBalancer.ID)
+			if err != nil || in == nil {
+				return nil, err
+			}
+			return in.Labels, nil
+		})
+		if err != nil {
+			return hcloudutil.ErrorToDiag(err)
 		}
-		_, _, err := c.LoadBalancer.Update(ctx, loadBalancer, hcloud.LoadBalancerUpdateOpts{
-			Labels: tmpLabels,
+		_, _, err = c.LoadBalancer.Update(ctx, loadBalancer, hcloud.LoadBalancerUpdateOpts{
+			Labels: labels,
 		})
 		if err != nil {
 			if resourceLoadBalancerIsNotFound(err, d) {
@@ -296,7 +305,7 @@ func resourceLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData, m a
 					}
 					return hcloudutil.ErrorToDiag(err)
 				}
-				if err = c.Action.WaitFor(ctx, action); err != nil {
+				if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 					return hcloudutil.ErrorToDiag(err)
 				}
 			}
@@ -333,7 +342,7 @@ func resourceLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData, m a
 					}
 					return hcloudutil.ErrorToDiag(err)
 				}
-				if err = c.Action.WaitFor(ctx, action); err != nil {
+				if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 					return hcloudutil.ErrorToDiag(err)
 				}
 			}
@@ -352,7 +361,7 @@ func resourceLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData, m a
 }
 
 func resourceLoadBalancerDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	loadBalancer, _, err := client.LoadBalancer.Get(ctx, d.Id())
 	if err != nil {
@@ -460,5 +469,5 @@ func setProtection(ctx context.Context, c *hcloud.Client, lb *hcloud.LoadBalance
 		return err
 	}
 
-	return c.Action.WaitFor(ctx, action)
+	return hcloudutil.WaitForActions(ctx, &c.Action, action)
 }
@@ -186,7 +186,7 @@ func ServiceResource() *schema.Resource {
 func resourceLoadBalancerServiceCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	var action *hcloud.Action
 
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
 	lbID, err := util.ParseID(d.Get("load_balancer_id").(string))
 	if err != nil {
@@ -225,7 +225,7 @@ func resourceLoadBalancerServiceCreate(ctx context.Context, d *schema.ResourceDa
 		opts.HealthCheck = parseTFHealthCheckAdd(tfHealthCheck.([]any))
 	}
 
-	err = control.Retry(control.DefaultRetries, func() error {
+	err = control.Retry(ctx, control.DefaultRetries, func() error {
 		var err error
 
 		action, _, err = c.LoadBalancer.AddService(ctx, &lb, opts)
@@ -250,7 +250,7 @@ func resourceLoadBalancerServiceCreate(ctx context.Context, d *schema.ResourceDa
 	svcID := fmt.Sprintf("%d__%d", lb.ID, listenPort)
 	d.SetId(svcID)
 
-	if err = c.Action.WaitFor(ctx, action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 
@@ -258,7 +258,7 @@ func resourceLoadBalancerServiceCreate(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceLoadBalancerServiceUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
 	lb, svc, err := lookupLoadBalancerServiceID(ctx, d.Id(), c)
 	if errors.Is(err, errInvalidLoadBalancerServiceID) {
@@ -296,14 +296,14 @@ func resourceLoadBalancerServiceUpdate(ctx context.Context, d *schema.ResourceDa
 		}
 		return hcloudutil.ErrorToDiag(err)
 	}
-	if err = c.Action.WaitFor(ctx, action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 	return resourceLoadBalancerServiceRead(ctx, d, m)
 }
 
 func resourceLoadBalancerServiceRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 	lb, svc, err := lookupLoadBalancerServiceID(ctx, d.Id(), client)
 	if errors.Is(err, errInvalidLoadBalancerServiceID) {
 		log.Printf("[WARN] Invalid id (%s), removing from state: %s", d.Id(), err)
@@ -322,7 +322,7 @@ func resourceLoadBalancerServiceRead(ctx context.Context, d *schema.ResourceData
 func resourceLoadBalancerServiceDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	const op = "hcloud/resourceLoadBalancerServiceDelete"
 
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
 	lb, svc, err := lookupLoadBalancerServiceID(ctx, d.Id(), c)
 	if errors.Is(err, errInvalidLoadBalancerServiceID) {
@@ -341,7 +341,7 @@ func resourceLoadBalancerServiceDelete(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return diag.Errorf("%s: %v", op, err)
 	}
-	if err = c.Action.WaitFor(ctx, action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 		return diag.Errorf("%s: %v", op, err)
 	}
 
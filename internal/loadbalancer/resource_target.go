@@ -90,7 +90,7 @@ func resourceLoadBalancerTargetCreate(ctx context.Context, d *schema.ResourceDat
 		err    error
 	)
 
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
 	lbID := util.CastInt64(d.Get("load_balancer_id"))
 	lb, _, err = c.LoadBalancer.GetByID(ctx, lbID)
@@ -115,7 +115,7 @@ func resourceLoadBalancerTargetCreate(ctx context.Context, d *schema.ResourceDat
 	if err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
-	if err = c.Action.WaitFor(ctx, action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 		return diag.Errorf("add load balancer target: %v", err)
 	}
 	setLoadBalancerTarget(d, lbID, tgt)
@@ -154,7 +154,7 @@ func resourceLoadBalancerCreateServerTarget(
 		opts.UsePrivateIP = new(usePrivateIP)
 	}
 
-	err = control.Retry(control.DefaultRetries, func() error {
+	err = control.Retry(ctx, control.DefaultRetries, func() error {
 		var err error
 
 		if usePrivateIP && len(lb.PrivateNet) == 0 {
@@ -257,7 +257,7 @@ func resourceLoadBalancerCreateIPTarget(
 }
 
 func resourceLoadBalancerTargetRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 	lbID := util.CastInt64(d.Get("load_balancer_id"))
 	tgtType := hcloud.LoadBalancerTargetType(d.Get("type").(string))
 
@@ -332,7 +332,7 @@ func resourceLoadBalancerTargetImport(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceLoadBalancerTargetUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 	lbID := util.CastInt64(d.Get("load_balancer_id"))
 	tgtType := hcloud.LoadBalancerTargetType(d.Get("type").(string))
 
@@ -351,7 +351,7 @@ func resourceLoadBalancerTargetUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceLoadBalancerTargetDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 	tgtType := hcloud.LoadBalancerTargetType(d.Get("type").(string))
 	lbID := util.CastInt64(d.Get("load_balancer_id"))
 
@@ -375,7 +375,7 @@ func removeLoadBalancerTarget(ctx context.Context, c *hcloud.Client, lb *hcloud.
 		hcErr  hcloud.Error
 	)
 
-	err = control.Retry(control.DefaultRetries, func() error {
+	err = control.Retry(ctx, control.DefaultRetries, func() error {
 		switch tgt.Type {
 		case hcloud.LoadBalancerTargetTypeServer:
 			action, _, err = c.LoadBalancer.RemoveServerTarget(ctx, lb, tgt.Server.Server)
@@ -400,7 +400,7 @@ func removeLoadBalancerTarget(ctx context.Context, c *hcloud.Client, lb *hcloud.
 		return fmt.Errorf("remove server target: %w", err)
 	}
 
-	if err = c.Action.WaitFor(ctx, action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 		return fmt.Errorf("remove server target: wait for action: %w", err)
 	}
 	return nil
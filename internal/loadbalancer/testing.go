@@ -62,6 +62,24 @@ func (d *DDataList) TFID() string {
 	return fmt.Sprintf("data.%s.%s", DataSourceListType, d.RName())
 }
 
+// DDataMetrics defines the fields for the
+// "testdata/d/hcloud_load_balancer_metrics" template.
+type DDataMetrics struct {
+	testtemplate.DataCommon
+
+	LoadBalancerID string
+	Type           string
+	Start          string
+	End            string
+	Duration       string
+	Step           int
+}
+
+// TFID returns the data source identifier.
+func (d *DDataMetrics) TFID() string {
+	return fmt.Sprintf("data.%s.%s", MetricsDataSourceType, d.RName())
+}
+
 // RData defines the fields for the "testdata/r/hcloud_load_balancer"
 // template.
 type RData struct {
@@ -97,7 +97,7 @@ func DataSourceList() *schema.Resource {
 }
 
 func dataSourceHcloudNetworkRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	if id, ok := d.GetOk("id"); ok {
 		n, _, err := client.Network.GetByID(ctx, util.CastInt64(id))
@@ -107,7 +107,7 @@ func dataSourceHcloudNetworkRead(ctx context.Context, d *schema.ResourceData, m
 		if n == nil {
 			return diag.Errorf("no network found with id %d", id)
 		}
-		setNetworkSchema(d, n)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getNetworkAttributes(n)))
 		return nil
 	}
 	if name, ok := d.GetOk("name"); ok {
@@ -118,7 +118,7 @@ func dataSourceHcloudNetworkRead(ctx context.Context, d *schema.ResourceData, m
 		if n == nil {
 			return diag.Errorf("no network found with name %s", name)
 		}
-		setNetworkSchema(d, n)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getNetworkAttributes(n)))
 		return nil
 	}
 
@@ -141,14 +141,14 @@ func dataSourceHcloudNetworkRead(ctx context.Context, d *schema.ResourceData, m
 		if len(allNetworks) > 1 {
 			return diag.Errorf("more than one network found for selector %q", selector)
 		}
-		setNetworkSchema(d, allNetworks[0])
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getNetworkAttributes(allNetworks[0])))
 		return nil
 	}
 	return diag.Errorf("please specify an id, a name or a selector to lookup the network")
 }
 
 func dataSourceHcloudNetworkListRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	selector := d.Get("with_selector").(string)
 
@@ -166,7 +166,7 @@ func dataSourceHcloudNetworkListRead(ctx context.Context, d *schema.ResourceData
 	tsNetworks := make([]map[string]any, len(allNetworks))
 	for i, firewall := range allNetworks {
 		ids[i] = util.FormatID(firewall.ID)
-		tsNetworks[i] = getNetworkAttributes(firewall)
+		tsNetworks[i] = hcloudutil.DataSourceAttributes(m, getNetworkAttributes(firewall))
 	}
 	d.Set("networks", tsNetworks)
 	d.SetId(datasourceutil.ListID(ids))
@@ -28,6 +28,7 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: hcloudutil.CustomizeDiffLabelsAll,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -50,6 +51,7 @@ func Resource() *schema.Resource {
 					return nil
 				},
 			},
+			"labels_all": hcloudutil.LabelsAllSchema(),
 			"delete_protection": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -66,7 +68,7 @@ func Resource() *schema.Resource {
 }
 
 func resourceNetworkCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	_, ipRange, err := net.ParseCIDR(d.Get("ip_range").(string))
 	if err != nil {
@@ -78,13 +80,7 @@ func resourceNetworkCreate(ctx context.Context, d *schema.ResourceData, m any) d
 		IPRange:               ipRange,
 		ExposeRoutesToVSwitch: d.Get("expose_routes_to_vswitch").(bool),
 	}
-	if labels, ok := d.GetOk("labels"); ok {
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
-		}
-		opts.Labels = tmpLabels
-	}
+	opts.Labels = hcloudutil.LabelsFromResourceData(d, m)
 
 	network, _, err := client.Network.Create(ctx, opts)
 	if err != nil {
@@ -104,7 +100,7 @@ func resourceNetworkCreate(ctx context.Context, d *schema.ResourceData, m any) d
 }
 
 func resourceNetworkRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	network, _, err := client.Network.Get(ctx, d.Id())
 	if err != nil {
@@ -117,12 +113,16 @@ func resourceNetworkRead(ctx context.Context, d *schema.ResourceData, m any) dia
 		d.SetId("")
 		return nil
 	}
+	labels := d.Get("labels")
 	setNetworkSchema(d, network)
+	if err := hcloudutil.SetResourceDataLabels(d, m, labels, network.Labels); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
 func resourceNetworkUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	network, _, err := client.Network.Get(ctx, d.Id())
 	if err != nil {
@@ -146,14 +146,19 @@ func resourceNetworkUpdate(ctx context.Context, d *schema.ResourceData, m any) d
 			return hcloudutil.ErrorToDiag(err)
 		}
 	}
-	if d.HasChange("labels") {
-		labels := d.Get("labels")
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
+	if d.HasChanges("labels", "labels_all") {
+		labels, err := hcloudutil.UpdateLabelsFromResourceData(d, m, func() (map[string]string, error) {
+			in, _, err := client.Network.GetByID(ctx, network.ID)
+			if err != nil || in == nil {
+				return nil, err
+			}
+			return in.Labels, nil
+		})
+		if err != nil {
+			return hcloudutil.ErrorToDiag(err)
 		}
-		_, _, err := client.Network.Update(ctx, network, hcloud.NetworkUpdateOpts{
-			Labels: tmpLabels,
+		_, _, err = client.Network.Update(ctx, network, hcloud.NetworkUpdateOpts{
+			Labels: labels,
 		})
 		if err != nil {
 			if resourceNetworkIsNotFound(err, d) {
@@ -188,7 +193,7 @@ func resourceNetworkUpdate(ctx context.Context, d *schema.ResourceData, m any) d
 }
 
 func resourceNetworkDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	networkID, err := util.ParseID(d.Id())
 
@@ -242,5 +247,5 @@ func setProtection(ctx context.Context, c *hcloud.Client, n *hcloud.Network, del
 		return err
 	}
 
-	return c.Action.WaitFor(ctx, action)
+	return hcloudutil.WaitForActions(ctx, &c.Action, action)
 }
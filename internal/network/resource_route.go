@@ -53,7 +53,7 @@ func RouteResource() *schema.Resource {
 func resourceNetworkRouteCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	var action *hcloud.Action
 
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
 	_, destination, err := net.ParseCIDR(d.Get("destination").(string))
 	if err != nil {
@@ -75,7 +75,7 @@ func resourceNetworkRouteCreate(ctx context.Context, d *schema.ResourceData, m a
 		},
 	}
 
-	err = control.Retry(control.DefaultRetries, func() error {
+	err = control.Retry(ctx, control.DefaultRetries, func() error {
 		var err error
 
 		action, _, err = c.Network.AddRoute(ctx, network, opts)
@@ -88,7 +88,7 @@ func resourceNetworkRouteCreate(ctx context.Context, d *schema.ResourceData, m a
 		return hcloudutil.ErrorToDiag(err)
 	}
 	d.SetId(generateNetworkRouteID(network, destination.String()))
-	if err = c.Action.WaitFor(ctx, action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 
@@ -96,7 +96,7 @@ func resourceNetworkRouteCreate(ctx context.Context, d *schema.ResourceData, m a
 }
 
 func resourceNetworkRouteRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	network, route, err := lookupNetworkRouteID(ctx, d.Id(), client)
 	if errors.Is(err, errInvalidNetworkRouteID) {
@@ -120,7 +120,7 @@ func resourceNetworkRouteRead(ctx context.Context, d *schema.ResourceData, m any
 func resourceNetworkRouteDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	var action *hcloud.Action
 
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
 	network, route, err := lookupNetworkRouteID(ctx, d.Id(), c)
 	if err != nil {
@@ -128,7 +128,7 @@ func resourceNetworkRouteDelete(ctx context.Context, d *schema.ResourceData, m a
 		d.SetId("")
 		return nil
 	}
-	err = control.Retry(control.DefaultRetries, func() error {
+	err = control.Retry(ctx, control.DefaultRetries, func() error {
 		var err error
 
 		action, _, err = c.Network.DeleteRoute(ctx, network, hcloud.NetworkDeleteRouteOpts{
@@ -147,7 +147,7 @@ func resourceNetworkRouteDelete(ctx context.Context, d *schema.ResourceData, m a
 		return hcloudutil.ErrorToDiag(err)
 	}
 
-	if err = c.Action.WaitFor(ctx, action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 	return nil
@@ -73,7 +73,7 @@ func SubnetResource() *schema.Resource {
 func resourceNetworkSubnetCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	var action *hcloud.Action
 
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
 	_, ipRange, err := net.ParseCIDR(d.Get("ip_range").(string))
 	if err != nil {
@@ -96,7 +96,7 @@ func resourceNetworkSubnetCreate(ctx context.Context, d *schema.ResourceData, m
 		opts.Subnet.VSwitchID = util.CastInt64(vSwitchID)
 	}
 
-	err = control.Retry(control.DefaultRetries, func() error {
+	err = control.Retry(ctx, control.DefaultRetries, func() error {
 		var err error
 
 		action, _, err = c.Network.AddSubnet(ctx, network, opts)
@@ -113,7 +113,7 @@ func resourceNetworkSubnetCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 	d.SetId(generateNetworkSubnetID(network, ipRange.String()))
 
-	if err = c.Action.WaitFor(ctx, action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 
@@ -121,7 +121,7 @@ func resourceNetworkSubnetCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceNetworkSubnetRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	network, subnet, err := lookupNetworkSubnetID(ctx, d.Id(), client)
 	if errors.Is(err, errInvalidNetworkSubnetID) {
@@ -148,9 +148,9 @@ func resourceNetworkSubnetDelete(ctx context.Context, d *schema.ResourceData, m
 		network *hcloud.Network
 	)
 
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
-	err := control.Retry(control.DefaultRetries*10, func() error {
+	err := control.Retry(ctx, control.DefaultRetries*10, func() error {
 		var (
 			subnet hcloud.NetworkSubnet
 			err    error
@@ -186,7 +186,7 @@ func resourceNetworkSubnetDelete(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
-	if err = c.Action.WaitFor(ctx, action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 	return nil
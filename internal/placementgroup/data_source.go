@@ -99,7 +99,7 @@ func DataSourceList() *schema.Resource {
 }
 
 func dataSourceHcloudPlacementGroupRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 	if id, ok := d.GetOk("id"); ok {
 		i, _, err := client.PlacementGroup.GetByID(ctx, util.CastInt64(id))
 		if err != nil {
@@ -108,7 +108,7 @@ func dataSourceHcloudPlacementGroupRead(ctx context.Context, d *schema.ResourceD
 		if i == nil {
 			return diag.Errorf("no placement group found with id %d", id)
 		}
-		setSchema(d, i)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getAttributes(i)))
 		return nil
 	}
 	if name, ok := d.GetOk("name"); ok {
@@ -119,7 +119,7 @@ func dataSourceHcloudPlacementGroupRead(ctx context.Context, d *schema.ResourceD
 		if i == nil {
 			return diag.Errorf("no placement group found with name %v", name)
 		}
-		setSchema(d, i)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getAttributes(i)))
 		return nil
 	}
 	if selector, ok := d.GetOk("with_selector"); ok {
@@ -140,14 +140,14 @@ func dataSourceHcloudPlacementGroupRead(ctx context.Context, d *schema.ResourceD
 			sortPlacementGroupListByCreated(allPlacementGroups)
 			log.Printf("[INFO] %d placement groups found for selector %q, using %d as the most recent one", len(allPlacementGroups), selector, allPlacementGroups[0].ID)
 		}
-		setSchema(d, allPlacementGroups[0])
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getAttributes(allPlacementGroups[0])))
 		return nil
 	}
 	return diag.Errorf("please specify an id, a name or a selector to lookup the placement group")
 }
 
 func dataSourceHcloudPlacementGroupListRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	selector := d.Get("with_selector")
 
@@ -165,7 +165,7 @@ func dataSourceHcloudPlacementGroupListRead(ctx context.Context, d *schema.Resou
 	tfPlacementGroups := make([]map[string]any, len(allPlacementGroups))
 	for i, firewall := range allPlacementGroups {
 		ids[i] = util.FormatID(firewall.ID)
-		tfPlacementGroups[i] = getAttributes(firewall)
+		tfPlacementGroups[i] = hcloudutil.DataSourceAttributes(m, getAttributes(firewall))
 	}
 	d.Set("placement_groups", tfPlacementGroups)
 	d.SetId(datasourceutil.ListID(ids))
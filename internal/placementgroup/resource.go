@@ -24,6 +24,7 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: hcloudutil.CustomizeDiffLabelsAll,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -40,6 +41,7 @@ func Resource() *schema.Resource {
 					return nil
 				},
 			},
+			"labels_all": hcloudutil.LabelsAllSchema(),
 			"servers": {
 				Type:     schema.TypeSet,
 				Computed: true,
@@ -65,19 +67,13 @@ func Resource() *schema.Resource {
 }
 
 func resourcePlacementGroupCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	opts := hcloud.PlacementGroupCreateOpts{
 		Name: d.Get("name").(string),
 		Type: hcloud.PlacementGroupType(d.Get("type").(string)),
 	}
-	if labels, ok := d.GetOk("labels"); ok {
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
-		}
-		opts.Labels = tmpLabels
-	}
+	opts.Labels = hcloudutil.LabelsFromResourceData(d, m)
 
 	res, _, err := client.PlacementGroup.Create(ctx, opts)
 	if err != nil {
@@ -85,7 +81,7 @@ func resourcePlacementGroupCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 	d.SetId(util.FormatID(res.PlacementGroup.ID))
 
-	if err = client.Action.WaitFor(ctx, res.Action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &client.Action, res.Action); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 
@@ -93,7 +89,7 @@ func resourcePlacementGroupCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourcePlacementGroupRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	id, err := util.ParseID(d.Id())
 	if err != nil {
@@ -112,12 +108,16 @@ func resourcePlacementGroupRead(ctx context.Context, d *schema.ResourceData, m a
 		return nil
 	}
 
+	labels := d.Get("labels")
 	setSchema(d, placementGroup)
+	if err := hcloudutil.SetResourceDataLabels(d, m, labels, placementGroup.Labels); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
 func resourcePlacementGroupUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	id, err := util.ParseID(d.Id())
 	if err != nil {
@@ -149,14 +149,19 @@ func resourcePlacementGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	if d.HasChange("labels") {
-		labels := d.Get("labels")
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
+	if d.HasChanges("labels", "labels_all") {
+		labels, err := hcloudutil.UpdateLabelsFromResourceData(d, m, func() (map[string]string, error) {
+			in, _, err := client.PlacementGroup.GetByID(ctx, placementGroup.ID)
+			if err != nil || in == nil {
+				return nil, err
+			}
+			return in.Labels, nil
+		})
+		if err != nil {
+			return hcloudutil.ErrorToDiag(err)
 		}
-		_, _, err := client.PlacementGroup.Update(ctx, placementGroup, hcloud.PlacementGroupUpdateOpts{
-			Labels: tmpLabels,
+		_, _, err = client.PlacementGroup.Update(ctx, placementGroup, hcloud.PlacementGroupUpdateOpts{
+			Labels: labels,
 		})
 		if err != nil {
 			if handleNotFound(err, d) {
@@ -171,7 +176,7 @@ func resourcePlacementGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourcePlacementGroupDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	id, err := util.ParseID(d.Id())
 	if err != nil {
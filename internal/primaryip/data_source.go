@@ -82,6 +82,7 @@ var _ datasource.DataSourceWithConfigValidators = (*DataSource)(nil)
 
 type DataSource struct {
 	client *hcloud.Client
+	labels hcloudutil.LabelsConfig
 }
 
 func NewDataSource() datasource.DataSource {
@@ -93,13 +94,14 @@ func (d *DataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, r
 }
 
 func (d *DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	var newDiags diag.Diagnostics
-
-	d.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
 	resp.Diagnostics.Append(newDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	d.client = providerData.Client
+	d.labels = providerData.Labels
 }
 
 func (d *DataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -220,6 +222,7 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 		}
 	}
 
+	data.labelsConfig = d.labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, result)...)
 	if resp.Diagnostics.HasError() {
 		return
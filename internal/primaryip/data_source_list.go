@@ -22,6 +22,7 @@ var _ datasource.DataSourceWithConfigure = (*DataSourceList)(nil)
 
 type DataSourceList struct {
 	client *hcloud.Client
+	labels hcloudutil.LabelsConfig
 }
 
 func NewDataSourceList() datasource.DataSource {
@@ -33,13 +34,14 @@ func (d *DataSourceList) Metadata(_ context.Context, _ datasource.MetadataReques
 }
 
 func (d *DataSourceList) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	var newDiags diag.Diagnostics
-
-	d.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
 	resp.Diagnostics.Append(newDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	d.client = providerData.Client
+	d.labels = providerData.Labels
 }
 
 func (d *DataSourceList) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -83,6 +85,8 @@ type dataSourceListModel struct {
 	PrimaryIPs types.List   `tfsdk:"primary_ips"`
 
 	WithSelector types.String `tfsdk:"with_selector"`
+
+	labelsConfig hcloudutil.LabelsConfig
 }
 
 var _ util.ModelFromAPI[[]*hcloud.PrimaryIP] = &dataSourceListModel{}
@@ -97,6 +101,7 @@ func (m *dataSourceListModel) FromAPI(ctx context.Context, in []*hcloud.PrimaryI
 		ids = append(ids, util.FormatID(item.ID))
 
 		var value model
+		value.labelsConfig = m.labelsConfig
 		diags.Append(value.FromAPI(ctx, item)...)
 
 		tfItem, newDiags := value.ToTerraform(ctx)
@@ -134,6 +139,7 @@ func (d *DataSourceList) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
+	data.labelsConfig = d.labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, result)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -5,7 +5,7 @@ import (
 	"math/rand"
 	"strconv"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
@@ -18,29 +18,23 @@ func AssignPrimaryIP(ctx context.Context, c *hcloud.Client, primaryIPID int64, a
 		AssigneeType: assigneeType,
 	})
 	if err != nil {
-		return hcloudutil.ErrorToDiag(err)
+		return hcloudutil.APIErrorDiagnostics(err)
 	}
-	if err = c.Action.WaitFor(ctx, action); err != nil {
-		return hcloudutil.ErrorToDiag(err)
-	}
-	return nil
+	return hcloudutil.SettleActions(ctx, &c.Action, action)
 }
 
 func UnassignPrimaryIP(ctx context.Context, c *hcloud.Client, v int64) diag.Diagnostics {
 	action, _, err := c.PrimaryIP.Unassign(ctx, v)
 	if err != nil {
-		return hcloudutil.ErrorToDiag(err)
+		return hcloudutil.APIErrorDiagnostics(err)
 	}
-	if err = c.Action.WaitFor(ctx, action); err != nil {
-		return hcloudutil.ErrorToDiag(err)
-	}
-	return nil
+	return hcloudutil.SettleActions(ctx, &c.Action, action)
 }
 
 func DeletePrimaryIP(ctx context.Context, c *hcloud.Client, p *hcloud.PrimaryIP) diag.Diagnostics {
 	_, err := c.PrimaryIP.Delete(ctx, p)
 	if err != nil {
-		return hcloudutil.ErrorToDiag(err)
+		return hcloudutil.APIErrorDiagnostics(err)
 	}
 	return nil
 }
@@ -54,14 +48,10 @@ func CreateRandomPrimaryIP(ctx context.Context, c *hcloud.Client, server *hcloud
 		Type:         ipType,
 	})
 	if err != nil {
-		return hcloudutil.ErrorToDiag(err)
+		return hcloudutil.APIErrorDiagnostics(err)
 	}
 
-	if err = c.Action.WaitFor(ctx, create.Action); err != nil {
-		return hcloudutil.ErrorToDiag(err)
-	}
-
-	return nil
+	return hcloudutil.SettleActions(ctx, &c.Action, create.Action)
 }
 
 func randomNumberBetween(low, hi int) int {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/resourceutil"
 )
 
@@ -26,6 +27,9 @@ type model struct {
 	AutoDelete       types.Bool   `tfsdk:"auto_delete"`
 	Labels           types.Map    `tfsdk:"labels"`
 	DeleteProtection types.Bool   `tfsdk:"delete_protection"`
+
+	// labelsConfig filters the labels ignored by the provider.
+	labelsConfig hcloudutil.LabelsConfig
 }
 
 var _ util.ModelFromAPI[*hcloud.PrimaryIP] = &model{}
@@ -85,7 +89,7 @@ func (m *model) FromAPI(ctx context.Context, hc *hcloud.PrimaryIP) diag.Diagnost
 	m.AssigneeID = types.Int64Value(hc.AssigneeID)
 	m.AssigneeType = types.StringValue(hc.AssigneeType)
 
-	m.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, hc.Labels)
+	m.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, m.labelsConfig, hc.Labels)
 	diags.Append(newDiags...)
 
 	m.DeleteProtection = types.BoolValue(hc.Protection.Delete)
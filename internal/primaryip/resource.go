@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -28,12 +27,15 @@ const ResourceType = "hcloud_primary_ip"
 
 var _ resource.Resource = (*Resource)(nil)
 var _ resource.ResourceWithConfigure = (*Resource)(nil)
+var _ resource.ResourceWithModifyPlan = (*Resource)(nil)
 var _ resource.ResourceWithConfigValidators = (*Resource)(nil)
 var _ resource.ResourceWithValidateConfig = (*Resource)(nil)
 var _ resource.ResourceWithImportState = (*Resource)(nil)
 
 type Resource struct {
-	client *hcloud.Client
+	client   *hcloud.Client
+	labels   hcloudutil.LabelsConfig
+	defaults hcloudutil.DefaultsConfig
 }
 
 func NewResource() resource.Resource {
@@ -45,13 +47,15 @@ func (r *Resource) Metadata(_ context.Context, _ resource.MetadataRequest, resp
 }
 
 func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	var newDiags diag.Diagnostics
-
-	r.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
 	resp.Diagnostics.Append(newDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	r.client = providerData.Client
+	r.labels = providerData.Labels
+	r.defaults = providerData.Defaults
 }
 
 func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -94,7 +98,7 @@ communicating with the API.
 			},
 		},
 		"location": schema.StringAttribute{
-			MarkdownDescription: "Name of the Location for the Primary IP. See the [Hetzner Docs](https://docs.hetzner.com/cloud/general/locations/#what-locations-are-there) for more details about locations.",
+			MarkdownDescription: "Name of the Location for the Primary IP. See the [Hetzner Docs](https://docs.hetzner.com/cloud/general/locations/#what-locations-are-there) for more details about locations. Defaults to the provider `default_location` when none of `location`, `datacenter` and `assignee_id` are set.",
 			Optional:            true,
 			Computed:            true,
 			PlanModifiers: []planmodifier.String{
@@ -103,7 +107,7 @@ communicating with the API.
 			},
 		},
 		"datacenter": schema.StringAttribute{
-			MarkdownDescription: "Name of the Datacenter for the Primary IP. See the [Hetzner Docs](https://docs.hetzner.com/cloud/general/locations/#what-datacenters-are-there) for more details about datacenters.",
+			MarkdownDescription: "Name of the Datacenter for the Primary IP. See the [Hetzner Docs](https://docs.hetzner.com/cloud/general/locations/#what-datacenters-are-there) for more details about datacenters. Defaults to the provider `default_datacenter` when none of `location`, `datacenter` and `assignee_id` are set, and no provider `default_location` is configured.",
 			Optional:            true,
 			Computed:            true,
 			PlanModifiers: []planmodifier.String{
@@ -128,7 +132,8 @@ communicating with the API.
 			Computed:            true,
 			Default:             booldefault.StaticBool(false),
 		},
-		"labels": resourceutil.LabelsSchema(),
+		"labels":     resourceutil.LabelsSchema(),
+		"labels_all": resourceutil.LabelsAllSchema(),
 		"delete_protection": schema.BoolAttribute{
 			MarkdownDescription: " Whether delete protection is enabled.",
 			Optional:            true,
@@ -149,9 +154,24 @@ communicating with the API.
 				stringplanmodifier.UseStateForUnknown(),
 			},
 		},
+		"shutdown_timeout":  resourceutil.ShutdownTimeoutSchema(),
+		"shutdown_fallback": resourceutil.ShutdownFallbackSchema(),
 	}
 }
 
+type resourceModel struct {
+	model
+
+	LabelsAll        types.Map    `tfsdk:"labels_all"`
+	ShutdownTimeout  types.String `tfsdk:"shutdown_timeout"`
+	ShutdownFallback types.String `tfsdk:"shutdown_fallback"`
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resourceutil.ModifyPlanLabelsAll(ctx, r.labels, req, resp)
+	resourceutil.ModifyPlanDefaultLocation(ctx, r.defaults, req, resp, "location", "datacenter", "assignee_id")
+}
+
 func (r *Resource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
 		resourcevalidator.ExactlyOneOf(
@@ -163,7 +183,7 @@ func (r *Resource) ConfigValidators(_ context.Context) []resource.ConfigValidato
 }
 
 func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var data model
+	var data resourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -193,7 +213,7 @@ func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConf
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data model
+	var data resourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -209,7 +229,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		opts.AutoDelete = data.AutoDelete.ValueBoolPointer()
 	}
 
-	resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, data.Labels, &opts.Labels)...)
+	resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, data.LabelsAll, &opts.Labels)...)
 
 	switch {
 	case !data.Location.IsUnknown() && !data.Location.IsNull():
@@ -281,16 +301,22 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		in.Datacenter = &hcloud.Datacenter{Name: data.Datacenter.ValueString()}
 	}
 
+	configuredLabels := data.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data model
+	var data resourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -308,6 +334,15 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	// Imported resources and states written before the attributes were added do not
+	// hold the shutdown settings, use their defaults.
+	if data.ShutdownTimeout.IsNull() {
+		data.ShutdownTimeout = types.StringValue(hcloudutil.DefaultShutdownTimeout.String())
+	}
+	if data.ShutdownFallback.IsNull() {
+		data.ShutdownFallback = types.StringValue(string(hcloudutil.ShutdownFallbackPoweroff))
+	}
+
 	// backwards-compatibility: Datacenter deprecation
 	//nolint:staticcheck
 	if in.Datacenter == nil && data.Datacenter.ValueString() != "" {
@@ -315,16 +350,22 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		in.Datacenter = &hcloud.Datacenter{Name: data.Datacenter.ValueString()}
 	}
 
+	configuredLabels := data.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data, plan model
+	var data, plan resourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -395,10 +436,16 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		opts.Name = plan.Name.ValueString()
 	}
 
-	if !plan.Labels.IsUnknown() && !plan.Labels.Equal(data.Labels) {
+	if !plan.LabelsAll.IsUnknown() && !plan.LabelsAll.Equal(data.LabelsAll) {
 		// Primary IPs labels are weird, opts.Labels is a pointer to a map.
-		var labels map[string]string
-		resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, plan.Labels, &labels)...)
+		labels, newDiags := resourceutil.LabelsForUpdate(ctx, r.labels, plan.LabelsAll, func() (map[string]string, error) {
+			in, _, err := r.client.PrimaryIP.GetByID(ctx, primaryIP.ID)
+			if err != nil || in == nil {
+				return nil, err
+			}
+			return in.Labels, nil
+		})
+		resp.Diagnostics.Append(newDiags...)
 		opts.Labels = &labels
 	}
 
@@ -426,16 +473,25 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	}
 
 	// Write data to state
+	data.ShutdownTimeout = plan.ShutdownTimeout
+	data.ShutdownFallback = plan.ShutdownFallback
+
+	configuredLabels := plan.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data model
+	var data resourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -456,10 +512,14 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 					server.PublicNet.IPv6.ID == primaryIP.ID {
 
 					{ // Power off
-						action, _, _ := r.client.Server.Poweroff(ctx, server)
-						// No error handling
+						shutdownOpts, newDiags := resourceutil.ShutdownOptsFrom(data.ShutdownTimeout, data.ShutdownFallback)
+						resp.Diagnostics.Append(newDiags...)
+						if resp.Diagnostics.HasError() {
+							return
+						}
 
-						resp.Diagnostics.Append(hcloudutil.SettleActions(ctx, &r.client.Action, action)...)
+						_, newDiags = hcloudutil.StopServer(ctx, r.client, server, shutdownOpts)
+						resp.Diagnostics.Append(newDiags...)
 						if resp.Diagnostics.HasError() {
 							return
 						}
@@ -496,7 +556,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		}
 	}
 
-	err := control.Retry(2*control.DefaultRetries, func() error {
+	err := control.Retry(ctx, 2*control.DefaultRetries, func() error {
 		_, err := r.client.PrimaryIP.Delete(ctx, primaryIP)
 		if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
 			// Primary IP was already deleted
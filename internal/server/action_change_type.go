@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	actionschema "github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
+)
+
+const ChangeTypeActionType = "hcloud_server_change_type"
+
+var _ action.Action = (*changeTypeAction)(nil)
+var _ action.ActionWithConfigure = (*changeTypeAction)(nil)
+
+type changeTypeActionData struct {
+	ServerID    types.Int64  `tfsdk:"server_id"`
+	ServerType  types.String `tfsdk:"server_type"`
+	UpgradeDisk types.Bool   `tfsdk:"upgrade_disk"`
+}
+
+type changeTypeAction struct {
+	client *hcloud.Client
+}
+
+func NewChangeTypeAction() action.Action {
+	return &changeTypeAction{}
+}
+
+func (a *changeTypeAction) Metadata(_ context.Context, _ action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = ChangeTypeActionType
+}
+
+func (a *changeTypeAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	var newDiags diag.Diagnostics
+
+	a.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	resp.Diagnostics.Append(newDiags...)
+}
+
+func (a *changeTypeAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = actionschema.Schema{
+		MarkdownDescription: util.MarkdownDescription(`
+Change the Server Type of a server in Hetzner Cloud.
+
+A running server is shut down before the change, and powered on again afterwards. The
+Server Type is validated before the server is shut down.
+
+The "server_type" of a server managed by the "hcloud_server" resource is not updated,
+configure "lifecycle { ignore_changes = [server_type] }" on the resource to keep the new
+Server Type.
+
+See the [Change the Type of a Server documentation](https://docs.hetzner.cloud/reference/cloud#tag/server-actions/change_server_type) for more details.
+`),
+		Attributes: map[string]actionschema.Attribute{
+			"server_id": actionschema.Int64Attribute{
+				MarkdownDescription: "ID of the server to change the Server Type of.",
+				Required:            true,
+			},
+			"server_type": actionschema.StringAttribute{
+				MarkdownDescription: "Name or ID of the new Server Type.",
+				Required:            true,
+			},
+			"upgrade_disk": actionschema.BoolAttribute{
+				MarkdownDescription: "Whether to resize the disk to the size of the new Server Type. A server with an upgraded disk can not be changed back to a Server Type with a smaller disk. Defaults to `false`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (a *changeTypeAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	if a.client == nil {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider client is not configured. This is an issue in the provider. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data changeTypeActionData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	server, _, err := a.client.Server.GetByID(ctx, data.ServerID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		return
+	}
+	if server == nil {
+		resp.Diagnostics.Append(hcloudutil.NotFoundDiagnostic("server", "id", data.ServerID.String()))
+		return
+	}
+
+	serverType, diags := getServerType(ctx, a.client, data.ServerType.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if serverType.ID == server.ServerType.ID {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Server %d already has the Server Type %q", server.ID, serverType.Name),
+		})
+		return
+	}
+
+	// Validate the Server Type before the server is shut down.
+	resp.Diagnostics.Append(validateServerTypeForServer(serverType, server)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	running := server.Status == hcloud.ServerStatusRunning
+	if running {
+		_, diags = hcloudutil.StopServer(ctx, a.client, server, hcloudutil.ShutdownOpts{
+			Fallback: hcloudutil.ShutdownFallbackPoweroff,
+			Progress: func(message string) {
+				resp.SendProgress(action.InvokeProgressEvent{Message: message})
+			},
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	waiter := &progressActionWaiter{
+		ActionWaiter: &a.client.Action,
+		sendProgress: resp.SendProgress,
+	}
+
+	apiAction, _, err := a.client.Server.ChangeType(ctx, server, hcloud.ServerChangeTypeOpts{
+		ServerType:  serverType,
+		UpgradeDisk: data.UpgradeDisk.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+	} else {
+		resp.Diagnostics.Append(hcloudutil.SettleActions(ctx, waiter, apiAction)...)
+	}
+
+	// Power the server on again, even if the change failed, to not leave it stopped.
+	if running {
+		apiAction, _, err := a.client.Server.Poweron(ctx, server)
+		if err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
+		}
+		resp.Diagnostics.Append(hcloudutil.SettleActions(ctx, waiter, apiAction)...)
+	}
+}
+
+// validateServerTypeForServer returns an error when the server can not be changed
+// to the Server Type, and a warning when the Server Type is deprecated in the
+// location of the server.
+func validateServerTypeForServer(serverType *hcloud.ServerType, server *hcloud.Server) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if serverType.Architecture != server.ServerType.Architecture {
+		diags.AddAttributeError(
+			path.Root("server_type"),
+			"Incompatible Server Type",
+			fmt.Sprintf("Server Type %q has the architecture %s, but server %d has the architecture %s.", serverType.Name, serverType.Architecture, server.ID, server.ServerType.Architecture),
+		)
+		return diags
+	}
+
+	if serverType.Disk < server.PrimaryDiskSize {
+		diags.AddAttributeError(
+			path.Root("server_type"),
+			"Incompatible Server Type",
+			fmt.Sprintf("Server Type %q has a disk of %d GB, smaller than the %d GB disk of server %d.", serverType.Name, serverType.Disk, server.PrimaryDiskSize, server.ID),
+		)
+		return diags
+	}
+
+	available := slices.ContainsFunc(serverType.Locations, func(o hcloud.ServerTypeLocation) bool {
+		return o.Location != nil && o.Location.Name == server.Location.Name && o.Available
+	})
+	if !available {
+		diags.AddAttributeError(
+			path.Root("server_type"),
+			"Server Type unavailable",
+			fmt.Sprintf("Server Type %q is not available in the location %q of server %d.", serverType.Name, server.Location.Name, server.ID),
+		)
+		return diags
+	}
+
+	if d := serverTypeDeprecationDiagnostic(serverType, server.Location.Name); d != nil {
+		diags.Append(d)
+	}
+
+	return diags
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func TestValidateServerTypeForServer(t *testing.T) {
+	server := &hcloud.Server{
+		ID:              1,
+		ServerType:      &hcloud.ServerType{Name: "cx23", Architecture: hcloud.ArchitectureX86, Disk: 40},
+		PrimaryDiskSize: 40,
+		Location:        &hcloud.Location{Name: "fsn1"},
+	}
+
+	newServerType := func(architecture hcloud.Architecture, disk int, locations ...hcloud.ServerTypeLocation) *hcloud.ServerType {
+		return &hcloud.ServerType{Name: "cpx22", Architecture: architecture, Disk: disk, Locations: locations}
+	}
+	fsn1 := hcloud.ServerTypeLocation{Location: &hcloud.Location{Name: "fsn1"}, Available: true}
+
+	testCases := []struct {
+		name         string
+		serverType   *hcloud.ServerType
+		wantSeverity diag.Severity
+		wantSummary  string
+	}{
+		{
+			name:       "valid",
+			serverType: newServerType(hcloud.ArchitectureX86, 80, fsn1),
+		},
+		{
+			name:         "architecture",
+			serverType:   newServerType(hcloud.ArchitectureARM, 80, fsn1),
+			wantSeverity: diag.SeverityError,
+			wantSummary:  "Incompatible Server Type",
+		},
+		{
+			name:         "disk",
+			serverType:   newServerType(hcloud.ArchitectureX86, 20, fsn1),
+			wantSeverity: diag.SeverityError,
+			wantSummary:  "Incompatible Server Type",
+		},
+		{
+			name:         "other location",
+			serverType:   newServerType(hcloud.ArchitectureX86, 80, hcloud.ServerTypeLocation{Location: &hcloud.Location{Name: "nbg1"}, Available: true}),
+			wantSeverity: diag.SeverityError,
+			wantSummary:  "Server Type unavailable",
+		},
+		{
+			name:         "out of stock",
+			serverType:   newServerType(hcloud.ArchitectureX86, 80, hcloud.ServerTypeLocation{Location: &hcloud.Location{Name: "fsn1"}}),
+			wantSeverity: diag.SeverityError,
+			wantSummary:  "Server Type unavailable",
+		},
+		{
+			name: "deprecated",
+			serverType: newServerType(hcloud.ArchitectureX86, 80, hcloud.ServerTypeLocation{
+				Location:  &hcloud.Location{Name: "fsn1"},
+				Available: true,
+				DeprecatableResource: hcloud.DeprecatableResource{Deprecation: &hcloud.DeprecationInfo{
+					Announced:        time.Now().Add(-time.Hour),
+					UnavailableAfter: time.Now().Add(time.Hour),
+				}},
+			}),
+			wantSeverity: diag.SeverityWarning,
+			wantSummary:  `Server Type "cpx22" is deprecated in "fsn1"`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateServerTypeForServer(tt.serverType, server)
+			if tt.wantSeverity == diag.SeverityInvalid {
+				assert.Empty(t, diags)
+				return
+			}
+			require.Len(t, diags, 1)
+			assert.Equal(t, tt.wantSeverity, diags[0].Severity())
+			assert.Contains(t, diags[0].Summary(), tt.wantSummary)
+		})
+	}
+}
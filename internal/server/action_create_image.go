@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	actionschema "github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/resourceutil"
+)
+
+const CreateImageActionType = "hcloud_server_create_image"
+
+var _ action.Action = (*createImageAction)(nil)
+var _ action.ActionWithConfigure = (*createImageAction)(nil)
+
+type createImageActionData struct {
+	ServerID    types.Int64  `tfsdk:"server_id"`
+	Type        types.String `tfsdk:"type"`
+	Description types.String `tfsdk:"description"`
+	Labels      types.Map    `tfsdk:"labels"`
+}
+
+type createImageAction struct {
+	client *hcloud.Client
+	labels hcloudutil.LabelsConfig
+}
+
+func NewCreateImageAction() action.Action {
+	return &createImageAction{}
+}
+
+func (a *createImageAction) Metadata(_ context.Context, _ action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = CreateImageActionType
+}
+
+func (a *createImageAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
+	resp.Diagnostics.Append(newDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	a.client = providerData.Client
+	a.labels = providerData.Labels
+}
+
+func (a *createImageAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = actionschema.Schema{
+		MarkdownDescription: util.MarkdownDescription(`
+Create an image (snapshot or backup) of a server in Hetzner Cloud, and wait until it is available.
+
+Unlike the "hcloud_snapshot" resource, the created image is not managed by Terraform, and is
+kept when the configuration is destroyed.
+
+See the [Create Image from a Server documentation](https://docs.hetzner.cloud/reference/cloud#tag/server-actions/create_image) for more details.
+`),
+		Attributes: map[string]actionschema.Attribute{
+			"server_id": actionschema.Int64Attribute{
+				MarkdownDescription: "ID of the server to create the image from.",
+				Required:            true,
+			},
+			"type": actionschema.StringAttribute{
+				MarkdownDescription: "Type of the image: `snapshot` or `backup`. Creating a backup requires backups to be enabled on the server. Defaults to `snapshot`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(hcloud.ImageTypeSnapshot),
+						string(hcloud.ImageTypeBackup),
+					),
+				},
+			},
+			"description": actionschema.StringAttribute{
+				MarkdownDescription: "Description of the image.",
+				Optional:            true,
+			},
+			"labels": actionschema.MapAttribute{
+				MarkdownDescription: "User-defined [labels](https://docs.hetzner.cloud/reference/cloud#labels) (key-value pairs) for the image. The default labels of the provider are added.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Map{
+					resourceutil.LabelsValidator(),
+				},
+			},
+		},
+	}
+}
+
+func (a *createImageAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	if a.client == nil {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider client is not configured. This is an issue in the provider. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data createImageActionData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := hcloud.ServerCreateImageOpts{
+		Type:        hcloud.ImageTypeSnapshot,
+		Description: data.Description.ValueStringPointer(),
+	}
+	if !data.Type.IsNull() {
+		opts.Type = hcloud.ImageType(data.Type.ValueString())
+	}
+
+	var labels map[string]string
+	resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &labels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	opts.Labels = a.labels.All(labels)
+
+	result, _, err := a.client.Server.CreateImage(ctx, &hcloud.Server{ID: data.ServerID.ValueInt64()}, &opts)
+	if err != nil {
+		if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+			resp.Diagnostics.Append(hcloudutil.NotFoundDiagnostic("server", "id", data.ServerID.String()))
+			return
+		}
+		resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Creating %s %d of server %d", opts.Type, result.Image.ID, data.ServerID.ValueInt64()),
+	})
+
+	waiter := &progressActionWaiter{
+		ActionWaiter: &a.client.Action,
+		sendProgress: resp.SendProgress,
+	}
+	resp.Diagnostics.Append(hcloudutil.SettleActions(ctx, waiter, result.Action)...)
+}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	actionschema "github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/validateutil"
+)
+
+const RebuildActionType = "hcloud_server_rebuild"
+
+// rebuildWaitForRunningTimeout is the maximum time to wait until the server is
+// running after the rebuild, when wait_for_running_timeout is not set.
+const rebuildWaitForRunningTimeout = 10 * time.Minute
+
+var _ action.Action = (*rebuildAction)(nil)
+var _ action.ActionWithConfigure = (*rebuildAction)(nil)
+
+type rebuildActionData struct {
+	ServerID              types.Int64  `tfsdk:"server_id"`
+	Image                 types.String `tfsdk:"image"`
+	WaitForRunning        types.Bool   `tfsdk:"wait_for_running"`
+	WaitForRunningTimeout types.String `tfsdk:"wait_for_running_timeout"`
+}
+
+type rebuildAction struct {
+	client *hcloud.Client
+}
+
+func NewRebuildAction() action.Action {
+	return &rebuildAction{}
+}
+
+func (a *rebuildAction) Metadata(_ context.Context, _ action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = RebuildActionType
+}
+
+func (a *rebuildAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	var newDiags diag.Diagnostics
+
+	a.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	resp.Diagnostics.Append(newDiags...)
+}
+
+func (a *rebuildAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = actionschema.Schema{
+		MarkdownDescription: util.MarkdownDescription(`
+Rebuild a server in Hetzner Cloud from an image. All data on the disk of the server is lost.
+
+The server keeps its ID, IPs and networks. To re-image a server managed by the
+"hcloud_server" resource, add "image" to the "ignore_changes" of its "lifecycle" block,
+or set its "image" to the image used by the action. Otherwise, the next plan replaces the
+server to restore the configured image, or rebuilds it again when "rebuild_on_image_change"
+is enabled.
+
+Only use it for servers with SSH keys: for servers without SSH keys, the API generates a
+new root password which can not be returned by the action, a warning is raised instead.
+
+See the [Rebuild a Server from an Image documentation](https://docs.hetzner.cloud/reference/cloud#tag/server-actions/rebuild_server) for more details.
+`),
+		Attributes: map[string]actionschema.Attribute{
+			"server_id": actionschema.Int64Attribute{
+				MarkdownDescription: "ID of the server to rebuild.",
+				Required:            true,
+			},
+			"image": actionschema.StringAttribute{
+				MarkdownDescription: "Name or ID of the image to rebuild the server from. For images available for several architectures, the one matching the server is used.",
+				Required:            true,
+			},
+			"wait_for_running": actionschema.BoolAttribute{
+				MarkdownDescription: "Whether to wait until the server is running after the rebuild.",
+				Optional:            true,
+			},
+			"wait_for_running_timeout": actionschema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait until the server is running, when `wait_for_running` is enabled, e.g. `30s` or `5m`. Defaults to `10m`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validateutil.Duration(),
+				},
+			},
+		},
+	}
+}
+
+func (a *rebuildAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	if a.client == nil {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider client is not configured. This is an issue in the provider. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data rebuildActionData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	waitForRunningTimeout := rebuildWaitForRunningTimeout
+	if data.WaitForRunningTimeout.ValueString() != "" {
+		var err error
+		waitForRunningTimeout, err = time.ParseDuration(data.WaitForRunningTimeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("wait_for_running_timeout"), "Invalid timeout", fmt.Sprintf("Value is not a valid duration: %s", err))
+			return
+		}
+	}
+
+	server, _, err := a.client.Server.GetByID(ctx, data.ServerID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		return
+	}
+	if server == nil {
+		resp.Diagnostics.Append(hcloudutil.NotFoundDiagnostic("server", "id", data.ServerID.String()))
+		return
+	}
+
+	image, diags := getImage(ctx, a.client, data.Image.ValueString(), server.ServerType.Architecture)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, _, err := a.client.Server.RebuildWithResult(ctx, server, hcloud.ServerRebuildOpts{Image: image})
+	if err != nil {
+		resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		return
+	}
+
+	// Actions can not return values, the generated root password is lost.
+	if result.RootPassword != "" {
+		resp.Diagnostics.AddWarning(
+			"Root password discarded",
+			fmt.Sprintf("The API generated a new root password for server %d, because it has no SSH keys. "+
+				"The password can not be returned by the action, only rebuild servers with SSH keys, "+
+				"or reset the root password in the Hetzner Console.", server.ID),
+		)
+	}
+
+	waiter := &progressActionWaiter{
+		ActionWaiter: &a.client.Action,
+		sendProgress: resp.SendProgress,
+	}
+	resp.Diagnostics.Append(hcloudutil.SettleActions(ctx, waiter, result.Action)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.WaitForRunning.ValueBool() {
+		waitCtx, cancel := context.WithTimeout(ctx, waitForRunningTimeout)
+		defer cancel()
+
+		err = hcloudutil.WaitForServerStatus(waitCtx, a.client, server, hcloud.ServerStatusRunning, func(message string) {
+			resp.SendProgress(action.InvokeProgressEvent{Message: message})
+		})
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			resp.Diagnostics.AddError(
+				"Server not running",
+				fmt.Sprintf("Server %d was rebuilt, but is not running after %s.", server.ID, waitForRunningTimeout),
+			)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	actionschema "github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/resourceutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/validateutil"
+)
+
+const ShutdownActionType = "hcloud_server_shutdown"
+
+var _ action.Action = (*shutdownAction)(nil)
+var _ action.ActionWithConfigure = (*shutdownAction)(nil)
+
+type shutdownActionData struct {
+	ServerID types.Int64  `tfsdk:"server_id"`
+	Timeout  types.String `tfsdk:"timeout"`
+	Fallback types.String `tfsdk:"fallback"`
+}
+
+type shutdownAction struct {
+	client *hcloud.Client
+}
+
+func NewShutdownAction() action.Action {
+	return &shutdownAction{}
+}
+
+func (a *shutdownAction) Metadata(_ context.Context, _ action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = ShutdownActionType
+}
+
+func (a *shutdownAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	var newDiags diag.Diagnostics
+
+	a.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	resp.Diagnostics.Append(newDiags...)
+}
+
+func (a *shutdownAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = actionschema.Schema{
+		MarkdownDescription: util.MarkdownDescription(`
+Gracefully shut down a server in Hetzner Cloud, and wait until it is off.
+
+The shutdown is requested through ACPI, the operating system of the server must support it.
+
+See the [Shutdown a Server documentation](https://docs.hetzner.cloud/reference/cloud#tag/server-actions/shutdown_server) for more details.
+`),
+		Attributes: map[string]actionschema.Attribute{
+			"server_id": actionschema.Int64Attribute{
+				MarkdownDescription: "ID of the server to shut down.",
+				Required:            true,
+			},
+			"timeout": actionschema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Time given to the server to shut down before `fallback` is applied, e.g. `30s` or `5m`. Defaults to `%s`.", hcloudutil.DefaultShutdownTimeout),
+				Optional:            true,
+				Validators: []validator.String{
+					validateutil.Duration(),
+				},
+			},
+			"fallback": actionschema.StringAttribute{
+				MarkdownDescription: "Action taken when the server did not shut down within `timeout`: `poweroff` forcefully powers it off, `fail` fails the action. Defaults to `fail`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(hcloudutil.ShutdownFallbackPoweroff),
+						string(hcloudutil.ShutdownFallbackFail),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (a *shutdownAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	if a.client == nil {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider client is not configured. This is an issue in the provider. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var data shutdownActionData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts, diags := resourceutil.ShutdownOptsFrom(data.Timeout, data.Fallback)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Fallback.IsNull() {
+		opts.Fallback = hcloudutil.ShutdownFallbackFail
+	}
+	opts.Progress = func(message string) {
+		resp.SendProgress(action.InvokeProgressEvent{Message: message})
+	}
+
+	server, _, err := a.client.Server.GetByID(ctx, data.ServerID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		return
+	}
+	if server == nil {
+		resp.Diagnostics.Append(hcloudutil.NotFoundDiagnostic("server", "id", data.ServerID.String()))
+		return
+	}
+
+	_, diags = hcloudutil.StopServer(ctx, a.client, server, opts)
+	resp.Diagnostics.Append(diags...)
+}
@@ -2,15 +2,25 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/actionvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/action"
 	actionschema "github.com/hashicorp/terraform-plugin-framework/action/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/validateutil"
 )
 
 const (
@@ -22,9 +32,14 @@ const (
 
 var _ action.Action = (*serverAction)(nil)
 var _ action.ActionWithConfigure = (*serverAction)(nil)
+var _ action.ActionWithConfigValidators = (*serverAction)(nil)
 
 type serverActionData struct {
-	ServerID types.Int64 `tfsdk:"server_id"`
+	ServerID      types.Int64  `tfsdk:"server_id"`
+	ServerIDs     types.Set    `tfsdk:"server_ids"`
+	LabelSelector types.String `tfsdk:"label_selector"`
+	MaxParallel   types.Int64  `tfsdk:"max_parallel"`
+	BatchPause    types.String `tfsdk:"batch_pause"`
 }
 
 type serverActionInvoke func(ctx context.Context, client *hcloud.Client, server *hcloud.Server) (*hcloud.Action, error)
@@ -34,6 +49,9 @@ type serverAction struct {
 	typeName            string
 	markdownDescription string
 	invoke              serverActionInvoke
+	// defaultMaxParallel is the number of servers the action is applied to at the
+	// same time, when max_parallel is not set. Zero means all servers at once.
+	defaultMaxParallel int64
 }
 
 func NewPoweronAction() action.Action {
@@ -63,6 +81,7 @@ See the [Power off a Server documentation](https://docs.hetzner.cloud/reference/
 			apiAction, _, err := client.Server.Poweroff(ctx, server)
 			return apiAction, err
 		},
+		defaultMaxParallel: 1,
 	}
 }
 
@@ -78,6 +97,7 @@ See the [Soft-reboot a Server documentation](https://docs.hetzner.cloud/referenc
 			apiAction, _, err := client.Server.Reboot(ctx, server)
 			return apiAction, err
 		},
+		defaultMaxParallel: 1,
 	}
 }
 
@@ -93,6 +113,7 @@ See the [Reset a Server documentation](https://docs.hetzner.cloud/reference/clou
 			apiAction, _, err := client.Server.Reset(ctx, server)
 			return apiAction, err
 		},
+		defaultMaxParallel: 1,
 	}
 }
 
@@ -108,17 +129,58 @@ func (a *serverAction) Configure(_ context.Context, req action.ConfigureRequest,
 }
 
 func (a *serverAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	maxParallelDefault := "all servers at once"
+	if a.defaultMaxParallel > 0 {
+		maxParallelDefault = strconv.FormatInt(a.defaultMaxParallel, 10)
+	}
+
 	resp.Schema = actionschema.Schema{
 		MarkdownDescription: a.markdownDescription,
 		Attributes: map[string]actionschema.Attribute{
 			"server_id": actionschema.Int64Attribute{
 				MarkdownDescription: "ID of the server to apply the action to.",
-				Required:            true,
+				Optional:            true,
+			},
+			"server_ids": actionschema.SetAttribute{
+				MarkdownDescription: "IDs of the servers to apply the action to.",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"label_selector": actionschema.StringAttribute{
+				MarkdownDescription: "[Label selector](https://docs.hetzner.cloud/reference/cloud#label-selector) of the servers to apply the action to.",
+				Optional:            true,
+			},
+			"max_parallel": actionschema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of servers the action is applied to at the same time. The servers are processed in batches of this size, a batch is only started once the previous one completed successfully. Defaults to %s.", maxParallelDefault),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"batch_pause": actionschema.StringAttribute{
+				MarkdownDescription: "Time to wait between two batches of servers, e.g. `30s` or `5m`.",
+				Optional:            true,
+				Validators: []validator.String{
+					validateutil.Duration(),
+				},
 			},
 		},
 	}
 }
 
+func (a *serverAction) ConfigValidators(_ context.Context) []action.ConfigValidator {
+	return []action.ConfigValidator{
+		actionvalidator.ExactlyOneOf(
+			path.MatchRoot("server_id"),
+			path.MatchRoot("server_ids"),
+			path.MatchRoot("label_selector"),
+		),
+	}
+}
+
 func (a *serverAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
 	if a.client == nil {
 		resp.Diagnostics.AddError(
@@ -134,13 +196,175 @@ func (a *serverAction) Invoke(ctx context.Context, req action.InvokeRequest, res
 		return
 	}
 
-	server := &hcloud.Server{ID: data.ServerID.ValueInt64()}
+	var batchPause time.Duration
+	if data.BatchPause.ValueString() != "" {
+		var err error
+		batchPause, err = time.ParseDuration(data.BatchPause.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("batch_pause"), "Invalid batch pause", fmt.Sprintf("Value is not a valid duration: %s", err))
+			return
+		}
+	}
 
-	apiAction, err := a.invoke(ctx, a.client, server)
-	if err != nil {
-		resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+	servers, diags := a.servers(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	if len(servers) == 0 {
+		resp.Diagnostics.AddWarning(
+			"No servers matched",
+			fmt.Sprintf("No servers match the label selector %q, %s was not applied to any server.", data.LabelSelector.ValueString(), a.typeName),
+		)
+		return
+	}
+
+	maxParallel := a.defaultMaxParallel
+	if !data.MaxParallel.IsNull() {
+		maxParallel = data.MaxParallel.ValueInt64()
+	}
+
+	resp.Diagnostics.Append(a.invokeBatches(ctx, servers, maxParallel, batchPause, resp.SendProgress)...)
+}
+
+// invokeBatches applies the action to the servers, in batches of at most
+// maxParallel servers, or all servers at once if maxParallel is zero. The remaining
+// batches are not processed once a batch failed.
+func (a *serverAction) invokeBatches(ctx context.Context, servers []*hcloud.Server, maxParallel int64, batchPause time.Duration, sendProgress func(action.InvokeProgressEvent)) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	batchSize := len(servers)
+	if maxParallel > 0 {
+		batchSize = int(min(maxParallel, int64(batchSize)))
+	}
+	batches := slices.Collect(slices.Chunk(servers, batchSize))
+
+	for i, batch := range batches {
+		if i > 0 && batchPause > 0 {
+			sendProgress(action.InvokeProgressEvent{
+				Message: fmt.Sprintf("Waiting %s before the next batch of servers", batchPause),
+			})
+			select {
+			case <-ctx.Done():
+				diags.AddError("Action cancelled", fmt.Sprintf("The action was cancelled before all servers were processed: %s", ctx.Err()))
+				return diags
+			case <-time.After(batchPause):
+			}
+		}
+
+		if len(batches) > 1 {
+			sendProgress(action.InvokeProgressEvent{
+				Message: fmt.Sprintf("Processing batch %d/%d", i+1, len(batches)),
+			})
+		}
+
+		diags.Append(a.invokeBatch(ctx, batch, sendProgress)...)
+		if diags.HasError() {
+			if i < len(batches)-1 {
+				diags.AddError(
+					"Action aborted",
+					fmt.Sprintf("%s failed for at least one server of batch %d/%d, the remaining %d batches were not processed.", a.typeName, i+1, len(batches), len(batches)-i-1),
+				)
+			}
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// servers returns the servers configured by the server_id, server_ids or
+// label_selector fields.
+func (a *serverAction) servers(ctx context.Context, data serverActionData) ([]*hcloud.Server, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case !data.ServerID.IsNull():
+		return []*hcloud.Server{{ID: data.ServerID.ValueInt64()}}, diags
+
+	case !data.ServerIDs.IsNull():
+		var ids []int64
+		diags.Append(data.ServerIDs.ElementsAs(ctx, &ids, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		slices.Sort(ids)
+
+		servers := make([]*hcloud.Server, 0, len(ids))
+		for _, id := range ids {
+			servers = append(servers, &hcloud.Server{ID: id})
+		}
+		return servers, diags
+
+	default:
+		servers, err := a.client.Server.AllWithOpts(ctx, hcloud.ServerListOpts{
+			ListOpts: hcloud.ListOpts{LabelSelector: data.LabelSelector.ValueString()},
+		})
+		if err != nil {
+			diags.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return nil, diags
+		}
+		return servers, diags
+	}
+}
+
+// invokeBatch applies the action to all servers of the batch, and waits until the
+// resulting API actions completed. The failures of all servers are reported.
+func (a *serverAction) invokeBatch(ctx context.Context, batch []*hcloud.Server, sendProgress func(action.InvokeProgressEvent)) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	resp.Diagnostics.Append(hcloudutil.SettleActions(ctx, &a.client.Action, apiAction)...)
+	apiActions := make([]*hcloud.Action, 0, len(batch))
+	for _, server := range batch {
+		apiAction, err := a.invoke(ctx, a.client, server)
+		if err != nil {
+			if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+				diags.Append(hcloudutil.NotFoundDiagnostic("server", "id", server.ID))
+				continue
+			}
+			diags.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			continue
+		}
+		apiActions = append(apiActions, apiAction)
+	}
+
+	waiter := &progressActionWaiter{
+		ActionWaiter: &a.client.Action,
+		sendProgress: sendProgress,
+	}
+	diags.Append(hcloudutil.SettleActions(ctx, waiter, apiActions...)...)
+
+	return diags
+}
+
+// progressActionWaiter sends a progress event every time one of the actions it
+// waits for completes.
+type progressActionWaiter struct {
+	hcloudutil.ActionWaiter
+	sendProgress func(action.InvokeProgressEvent)
+}
+
+func (w *progressActionWaiter) WaitForFunc(ctx context.Context, handleUpdate func(update *hcloud.Action) error, actions ...*hcloud.Action) error {
+	return w.ActionWaiter.WaitForFunc(ctx, func(update *hcloud.Action) error {
+		switch update.Status {
+		case hcloud.ActionStatusSuccess:
+			w.sendProgress(action.InvokeProgressEvent{
+				Message: fmt.Sprintf("%s succeeded for %s", update.Command, actionServers(update)),
+			})
+		case hcloud.ActionStatusError:
+			w.sendProgress(action.InvokeProgressEvent{
+				Message: fmt.Sprintf("%s failed for %s: %s", update.Command, actionServers(update), update.ErrorMessage),
+			})
+		}
+		return handleUpdate(update)
+	}, actions...)
+}
+
+// actionServers returns a description of the servers an API action applies to.
+func actionServers(apiAction *hcloud.Action) string {
+	for _, resource := range apiAction.Resources {
+		if resource.Type == hcloud.ActionResourceTypeServer {
+			return fmt.Sprintf("server %d", resource.ID)
+		}
+	}
+	return fmt.Sprintf("action %d", apiAction.ID)
 }
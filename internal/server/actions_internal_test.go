@@ -0,0 +1,121 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/testsupport/fakeapi"
+)
+
+func TestServerActionInvokeBatches(t *testing.T) {
+	// setup returns the fake API, the poweroff action and count running servers.
+	setup := func(t *testing.T, count int) (*fakeapi.Server, *serverAction, []*hcloud.Server) {
+		t.Helper()
+
+		api := fakeapi.New()
+		t.Cleanup(api.Close)
+
+		client := hcloud.NewClient(
+			hcloud.WithEndpoint(api.Endpoint()),
+			hcloud.WithToken("token"),
+			hcloud.WithPollOpts(hcloud.PollOpts{BackoffFunc: hcloud.ConstantBackoff(time.Millisecond)}),
+		)
+
+		servers := make([]*hcloud.Server, 0, count)
+		for i := range count {
+			result, _, err := client.Server.Create(t.Context(), hcloud.ServerCreateOpts{
+				Name:       fmt.Sprintf("server-%d", i),
+				ServerType: &hcloud.ServerType{Name: "cpx22"},
+				Image:      &hcloud.Image{Name: "ubuntu-24.04"},
+			})
+			require.NoError(t, err)
+			require.NoError(t, client.Action.WaitFor(t.Context(), result.Action))
+			servers = append(servers, result.Server)
+		}
+
+		a := NewPoweroffAction().(*serverAction)
+		a.client = client
+		return api, a, servers
+	}
+
+	invoke := func(t *testing.T, a *serverAction, servers []*hcloud.Server, maxParallel int64, batchPause time.Duration) ([]string, []string) {
+		t.Helper()
+
+		var progress []string
+		diags := a.invokeBatches(t.Context(), servers, maxParallel, batchPause, func(event action.InvokeProgressEvent) {
+			progress = append(progress, event.Message)
+		})
+
+		var errors []string
+		for _, d := range diags.Errors() {
+			errors = append(errors, d.Summary())
+		}
+		return progress, errors
+	}
+
+	assertStatus := func(t *testing.T, a *serverAction, servers []*hcloud.Server, want ...hcloud.ServerStatus) {
+		t.Helper()
+
+		for i, server := range servers {
+			result, _, err := a.client.Server.GetByID(t.Context(), server.ID)
+			require.NoError(t, err)
+			assert.Equal(t, want[i], result.Status, "server %d", i)
+		}
+	}
+
+	t.Run("max parallel and batch pause", func(t *testing.T) {
+		_, a, servers := setup(t, 3)
+
+		progress, errors := invoke(t, a, servers, 2, 10*time.Millisecond)
+		assert.Empty(t, errors)
+		assert.Subset(t, progress, []string{
+			"Processing batch 1/2",
+			"Waiting 10ms before the next batch of servers",
+			"Processing batch 2/2",
+		})
+		assertStatus(t, a, servers, hcloud.ServerStatusOff, hcloud.ServerStatusOff, hcloud.ServerStatusOff)
+	})
+
+	t.Run("all servers at once", func(t *testing.T) {
+		_, a, servers := setup(t, 3)
+
+		progress, errors := invoke(t, a, servers, 0, 0)
+		assert.Empty(t, errors)
+		assert.NotContains(t, progress, "Processing batch 1/1")
+		assertStatus(t, a, servers, hcloud.ServerStatusOff, hcloud.ServerStatusOff, hcloud.ServerStatusOff)
+	})
+
+	t.Run("failures of a batch are aggregated", func(t *testing.T) {
+		api, a, servers := setup(t, 2)
+		api.FailNextAction("stop_server", "action_failed", "stop failed")
+
+		_, errors := invoke(t, a, []*hcloud.Server{servers[0], {ID: 999999}, servers[1]}, 0, 0)
+		assert.Len(t, errors, 2)
+		assert.Contains(t, errors, "Resource not found")
+		assertStatus(t, a, servers, hcloud.ServerStatusOff, hcloud.ServerStatusOff)
+	})
+
+	t.Run("abort after failed batch", func(t *testing.T) {
+		_, a, servers := setup(t, 3)
+
+		_, errors := invoke(t, a, []*hcloud.Server{servers[0], {ID: 999999}, servers[1], servers[2]}, 2, 0)
+		assert.Equal(t, []string{"Resource not found", "Action aborted"}, errors)
+		assertStatus(t, a, servers, hcloud.ServerStatusOff, hcloud.ServerStatusRunning, hcloud.ServerStatusRunning)
+	})
+
+	t.Run("abort after failed action", func(t *testing.T) {
+		api, a, servers := setup(t, 2)
+		api.FailNextAction("stop_server", "action_failed", "stop failed")
+
+		_, errors := invoke(t, a, servers, 1, 0)
+		assert.Len(t, errors, 2)
+		assert.Equal(t, "Action aborted", errors[len(errors)-1])
+		assertStatus(t, a, servers, hcloud.ServerStatusOff, hcloud.ServerStatusRunning)
+	})
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
@@ -51,11 +53,23 @@ func TestAccServerActions(t *testing.T) {
 	resActionReset := testtemplate.DeepCopy(t, resActionPoweroff)
 	resActionReset.Type = "reset"
 
+	resActionShutdown := testtemplate.DeepCopy(t, resActionPoweroff)
+	resActionShutdown.Type = "shutdown"
+	resActionShutdown.Timeout = "2m"
+	resActionShutdown.Fallback = "poweroff"
+
+	resActionRebuild := testtemplate.DeepCopy(t, resActionPoweroff)
+	resActionRebuild.Type = "rebuild"
+	resActionRebuild.Image = teste2e.TestImage
+	resActionRebuild.WaitForRunning = true
+
 	res.Raw = fmt.Sprintf(`
 		lifecycle {
 			action_trigger {
 				events  = [after_create]
 				actions = [
+					%s,
+					%s,
 					%s,
 					%s,
 					%s,
@@ -63,7 +77,7 @@ func TestAccServerActions(t *testing.T) {
 				]
 			}
 		}
-	`, resActionPoweroff.TFID(), resActionPoweron.TFID(), resActionReboot.TFID(), resActionReset.TFID())
+	`, resActionPoweroff.TFID(), resActionPoweron.TFID(), resActionReboot.TFID(), resActionReset.TFID(), resActionShutdown.TFID(), resActionRebuild.TFID())
 
 	resource.ParallelTest(t, resource.TestCase{
 		// Actions are only available in 1.14 and later
@@ -82,6 +96,8 @@ func TestAccServerActions(t *testing.T) {
 					"testdata/a/hcloud_server", resActionPoweron,
 					"testdata/a/hcloud_server", resActionReboot,
 					"testdata/a/hcloud_server", resActionReset,
+					"testdata/a/hcloud_server", resActionShutdown,
+					"testdata/a/hcloud_server", resActionRebuild,
 				),
 				Check: resource.ComposeTestCheckFunc(
 					testsupport.CheckAPIResourcePresent(res.TFID(), testsupport.CopyAPIResource(s, server.GetAPIResource())),
@@ -106,7 +122,242 @@ func TestAccServerActions(t *testing.T) {
 						assert.True(t, slices.ContainsFunc(actions, actionWithCommand("start_server")))
 						assert.True(t, slices.ContainsFunc(actions, actionWithCommand("reboot_server")))
 						assert.True(t, slices.ContainsFunc(actions, actionWithCommand("reset_server")))
+						assert.True(t, slices.ContainsFunc(actions, actionWithCommand("shutdown_server")))
+						assert.True(t, slices.ContainsFunc(actions, actionWithCommand("rebuild_server")))
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerActions_LabelSelector(t *testing.T) {
+	tmplMan := testtemplate.Manager{}
+
+	sA, sB := &hcloud.Server{}, &hcloud.Server{}
+
+	labels := map[string]string{
+		"key": strconv.Itoa(acctest.RandInt()),
+	}
+
+	resA := &server.RData{
+		Name:         "server-actions-a",
+		Type:         teste2e.TestServerType,
+		Image:        teste2e.TestImage,
+		LocationName: teste2e.TestLocationName,
+		Labels:       labels,
+	}
+	resA.SetRName("a")
+
+	resB := testtemplate.DeepCopy(t, resA)
+	resB.Name = "server-actions-b"
+	resB.SetRName("b")
+
+	resActionReboot := &server.AData{
+		Type:          "reboot",
+		LabelSelector: "key=" + labels["key"],
+		MaxParallel:   1,
+		BatchPause:    "5s",
+	}
+	resActionReboot.SetRName("default")
+
+	// Trigger the action once both servers exist.
+	resB.Raw = fmt.Sprintf(`
+		depends_on = [%s]
+
+		lifecycle {
+			action_trigger {
+				events  = [after_create]
+				actions = [%s]
+			}
+		}
+	`, resA.TFID(), resActionReboot.TFID())
+
+	hasRebootAction := func(s *hcloud.Server) resource.TestCheckFunc {
+		return func(_ *terraform.State) error {
+			client, err := testsupport.CreateClient()
+			if err != nil {
+				return err
+			}
+
+			actions, err := client.Server.Action.AllFor(context.Background(), s, hcloud.ActionListOpts{})
+			if err != nil {
+				return err
+			}
+
+			assert.True(t, slices.ContainsFunc(actions, func(action *hcloud.Action) bool {
+				return action.Command == "reboot_server"
+			}))
+			return nil
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		// Actions are only available in 1.14 and later
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		PreCheck:                 teste2e.PreCheck(t),
+		ProtoV6ProviderFactories: testmux.ProtoV6ProviderFactories(),
+
+		Steps: []resource.TestStep{
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_server", resA,
+					"testdata/r/hcloud_server", resB,
+					"testdata/a/hcloud_server", resActionReboot,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testsupport.CheckAPIResourcePresent(resA.TFID(), testsupport.CopyAPIResource(sA, server.GetAPIResource())),
+					testsupport.CheckAPIResourcePresent(resB.TFID(), testsupport.CopyAPIResource(sB, server.GetAPIResource())),
+					hasRebootAction(sA),
+					hasRebootAction(sB),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerActions_CreateImage(t *testing.T) {
+	tmplMan := testtemplate.Manager{}
+
+	labels := map[string]string{
+		"key": strconv.Itoa(acctest.RandInt()),
+	}
+
+	res := &server.RData{
+		Name:         "server-actions-create-image",
+		Type:         teste2e.TestServerType,
+		Image:        teste2e.TestImage,
+		LocationName: teste2e.TestLocationName,
+	}
+	res.SetRName("default")
+
+	resActionCreateImage := &server.AData{
+		Type:        "create_image",
+		ServerID:    res.TFID() + ".id",
+		ImageType:   "snapshot",
+		Description: "server-actions-create-image",
+		Labels:      labels,
+	}
+	resActionCreateImage.SetRName("default")
+
+	res.Raw = fmt.Sprintf(`
+		lifecycle {
+			action_trigger {
+				events  = [after_create]
+				actions = [%s]
+			}
+		}
+	`, resActionCreateImage.TFID())
+
+	resource.ParallelTest(t, resource.TestCase{
+		// Actions are only available in 1.14 and later
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		PreCheck:                 teste2e.PreCheck(t),
+		ProtoV6ProviderFactories: testmux.ProtoV6ProviderFactories(),
+
+		Steps: []resource.TestStep{
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_server", res,
+					"testdata/a/hcloud_server", resActionCreateImage,
+				),
+				Check: func(_ *terraform.State) error {
+					client, err := testsupport.CreateClient()
+					if err != nil {
+						return err
+					}
+
+					images, err := client.Image.AllWithOpts(context.Background(), hcloud.ImageListOpts{
+						ListOpts: hcloud.ListOpts{LabelSelector: "key=" + labels["key"]},
+						Type:     []hcloud.ImageType{hcloud.ImageTypeSnapshot},
+					})
+					if err != nil {
+						return err
+					}
+
+					// The image is not managed by Terraform, delete it ourselves.
+					for _, image := range images {
+						if _, err := client.Image.Delete(context.Background(), image); err != nil {
+							return err
+						}
+					}
+
+					if assert.Len(t, images, 1) {
+						assert.Equal(t, "server-actions-create-image", images[0].Description)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccServerActions_ChangeType(t *testing.T) {
+	tmplMan := testtemplate.Manager{}
+
+	s := &hcloud.Server{}
+
+	res := &server.RData{
+		Name:         "server-actions-change-type",
+		Type:         teste2e.TestServerType,
+		Image:        teste2e.TestImage,
+		LocationName: teste2e.TestLocationName,
+	}
+	res.SetRName("default")
+
+	resActionChangeType := &server.AData{
+		Type:       "change_type",
+		ServerID:   res.TFID() + ".id",
+		ServerType: teste2e.TestServerTypeUpgrade,
+	}
+	resActionChangeType.SetRName("default")
+
+	res.Raw = fmt.Sprintf(`
+		lifecycle {
+			ignore_changes = [server_type]
+
+			action_trigger {
+				events  = [after_create]
+				actions = [%s]
+			}
+		}
+	`, resActionChangeType.TFID())
+
+	resource.ParallelTest(t, resource.TestCase{
+		// Actions are only available in 1.14 and later
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		PreCheck:                 teste2e.PreCheck(t),
+		ProtoV6ProviderFactories: testmux.ProtoV6ProviderFactories(),
+
+		Steps: []resource.TestStep{
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_server", res,
+					"testdata/a/hcloud_server", resActionChangeType,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testsupport.CheckAPIResourcePresent(res.TFID(), testsupport.CopyAPIResource(s, server.GetAPIResource())),
+					func(_ *terraform.State) error {
+						client, err := testsupport.CreateClient()
+						if err != nil {
+							return err
+						}
+
+						result, _, err := client.Server.GetByID(context.Background(), s.ID)
+						if err != nil {
+							return err
+						}
 
+						assert.Equal(t, teste2e.TestServerTypeUpgrade, result.ServerType.Name)
+						assert.Equal(t, hcloud.ServerStatusRunning, result.Status)
 						return nil
 					},
 				),
@@ -7,11 +7,10 @@ import (
 	"net"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/control"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
 )
 
 func attachServerToNetwork(ctx context.Context, c *hcloud.Client, srv *hcloud.Server, nw *hcloud.Network, ip net.IP, aliasIPs []net.IP, ipRange *net.IPNet) error {
@@ -26,7 +25,7 @@ func attachServerToNetwork(ctx context.Context, c *hcloud.Client, srv *hcloud.Se
 		opts.IPRange = ipRange
 	}
 
-	err := control.Retry(control.DefaultRetries, func() error {
+	err := control.Retry(ctx, control.DefaultRetries, func() error {
 		var err error
 
 		action, _, err = c.Server.AttachToNetwork(ctx, srv, opts)
@@ -48,27 +47,24 @@ func attachServerToNetwork(ctx context.Context, c *hcloud.Client, srv *hcloud.Se
 	if err != nil {
 		return fmt.Errorf("attach server to network: %w", err)
 	}
-	if err = c.Action.WaitFor(ctx, action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 		return fmt.Errorf("attach server to network: %w", err)
 	}
 	return nil
 }
 
-func updateServerAliasIPs(ctx context.Context, c *hcloud.Client, s *hcloud.Server, n *hcloud.Network, aliasIPs *schema.Set) error {
+func updateServerAliasIPs(ctx context.Context, c *hcloud.Client, s *hcloud.Server, n *hcloud.Network, aliasIPs []net.IP) error {
 	const op = "hcloud/updateServerAliasIPs"
 
 	opts := hcloud.ServerChangeAliasIPsOpts{
 		Network:  n,
-		AliasIPs: make([]net.IP, aliasIPs.Len()),
-	}
-	for i, v := range aliasIPs.List() {
-		opts.AliasIPs[i] = net.ParseIP(v.(string))
+		AliasIPs: aliasIPs,
 	}
 	action, _, err := c.Server.ChangeAliasIPs(ctx, s, opts)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	if err = c.Action.WaitFor(ctx, action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	return nil
@@ -78,7 +74,7 @@ func detachServerFromNetwork(ctx context.Context, c *hcloud.Client, s *hcloud.Se
 	const op = "hcloud/detachServerFromNetwork"
 	var action *hcloud.Action
 
-	err := control.Retry(control.DefaultRetries, func() error {
+	err := control.Retry(ctx, control.DefaultRetries, func() error {
 		var err error
 
 		action, _, err = c.Server.DetachFromNetwork(ctx, s, hcloud.ServerDetachFromNetworkOpts{Network: n})
@@ -97,12 +93,71 @@ func detachServerFromNetwork(ctx context.Context, c *hcloud.Client, s *hcloud.Se
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err = c.Action.WaitFor(ctx, action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func attachVolumeToServer(ctx context.Context, c *hcloud.Client, s *hcloud.Server, v *hcloud.Volume, automount *bool) error {
+	const op = "hcloud/attachVolumeToServer"
+	var action *hcloud.Action
+
+	err := control.Retry(ctx, control.DefaultRetries, func() error {
+		var err error
+
+		action, _, err = c.Volume.AttachWithOpts(ctx, v, hcloud.VolumeAttachOpts{Server: s, Automount: automount})
+		if hcloud.IsError(err, hcloud.ErrorCodeLocked) {
+			return err
+		}
+		return control.AbortRetry(err)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	return nil
 }
 
+func detachVolumeFromServer(ctx context.Context, c *hcloud.Client, v *hcloud.Volume) error {
+	const op = "hcloud/detachVolumeFromServer"
+	var action *hcloud.Action
+
+	err := control.Retry(ctx, control.DefaultRetries, func() error {
+		var err error
+
+		action, _, err = c.Volume.Detach(ctx, v)
+		if hcloud.IsError(err, hcloud.ErrorCodeLocked) {
+			return err
+		}
+		return control.AbortRetry(err)
+	})
+	if err != nil {
+		if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
+			// volume has already been deleted
+			return nil
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// trafficQuotaPercent returns the outgoing traffic of the server in percent of its
+// included traffic. Only the outgoing traffic is billed.
+func trafficQuotaPercent(s *hcloud.Server) float64 {
+	if s.IncludedTraffic == 0 {
+		return 0
+	}
+	return float64(s.OutgoingTraffic) / float64(s.IncludedTraffic) * 100
+}
+
 func ParseSubnetID(s string) (*hcloud.Network, *net.IPNet, error) {
 	parts := strings.SplitN(s, "-", 2)
 	if len(parts) != 2 {
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -133,6 +134,22 @@ func getCommonDataSchema() map[string]*schema.Schema {
 			Type:     schema.TypeInt,
 			Computed: true,
 		},
+		"outgoing_traffic": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"ingoing_traffic": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"included_traffic": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"traffic_quota_percent": {
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
 	}
 }
 
@@ -194,7 +211,7 @@ func DataSourceList() *schema.Resource {
 }
 
 func dataSourceHcloudServerRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	if id, ok := d.GetOk("id"); ok {
 		s, _, err := client.Server.GetByID(ctx, util.CastInt64(id))
@@ -204,7 +221,7 @@ func dataSourceHcloudServerRead(ctx context.Context, d *schema.ResourceData, m a
 		if s == nil {
 			return diag.Errorf("no Server found with id %d", id)
 		}
-		setServerSchema(d, s, true)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getServerAttributes(s)))
 		return nil
 	}
 
@@ -216,7 +233,7 @@ func dataSourceHcloudServerRead(ctx context.Context, d *schema.ResourceData, m a
 		if s == nil {
 			return diag.Errorf("no Server found with name %s", name)
 		}
-		setServerSchema(d, s, true)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getServerAttributes(s)))
 		return nil
 	}
 
@@ -249,7 +266,7 @@ func dataSourceHcloudServerRead(ctx context.Context, d *schema.ResourceData, m a
 		if len(allServers) > 1 {
 			return diag.Errorf("more than one Server found for selector %q", selector)
 		}
-		setServerSchema(d, allServers[0], true)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getServerAttributes(allServers[0])))
 		return nil
 	}
 
@@ -257,7 +274,7 @@ func dataSourceHcloudServerRead(ctx context.Context, d *schema.ResourceData, m a
 }
 
 func dataSourceHcloudServerListRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	selector := d.Get("with_selector").(string)
 
@@ -281,10 +298,94 @@ func dataSourceHcloudServerListRead(ctx context.Context, d *schema.ResourceData,
 	tfServers := make([]map[string]any, len(allServers))
 	for i, server := range allServers {
 		ids[i] = util.FormatID(server.ID)
-		tfServers[i] = getServerAttributes(d, server, true)
+		tfServers[i] = hcloudutil.DataSourceAttributes(m, getServerAttributes(server))
 	}
 	d.Set("servers", tfServers)
 	d.SetId(datasourceutil.ListID(ids))
 
 	return nil
 }
+
+func getServerAttributes(s *hcloud.Server) map[string]any {
+	firewallIDs := make([]int, len(s.PublicNet.Firewalls))
+	for i, firewall := range s.PublicNet.Firewalls {
+		firewallIDs[i] = util.CastInt(firewall.Firewall.ID)
+	}
+
+	res := map[string]any{
+		"id":                    s.ID,
+		"name":                  s.Name,
+		"location":              s.Location.Name,
+		"status":                s.Status,
+		"server_type":           s.ServerType.Name,
+		"ipv6_network":          s.PublicNet.IPv6.Network.String(),
+		"backup_window":         s.BackupWindow,
+		"backups":               s.BackupWindow != "",
+		"labels":                s.Labels,
+		"delete_protection":     s.Protection.Delete,
+		"rebuild_protection":    s.Protection.Rebuild,
+		"firewall_ids":          firewallIDs,
+		"primary_disk_size":     s.PrimaryDiskSize,
+		"outgoing_traffic":      int64(s.OutgoingTraffic), // nolint:gosec
+		"ingoing_traffic":       int64(s.IngoingTraffic),  // nolint:gosec
+		"included_traffic":      int64(s.IncludedTraffic), // nolint:gosec
+		"traffic_quota_percent": trafficQuotaPercent(s),
+		"network":               networkToTerraformNetworks(s.PrivateNet),
+	}
+	if s.PublicNet.IPv4.IsUnspecified() {
+		res["ipv4_address"] = nil
+	} else {
+		res["ipv4_address"] = s.PublicNet.IPv4.IP.String()
+	}
+
+	if len(s.PublicNet.IPv6.IP) == 0 {
+		// No IPv6 Primary IP assigned
+		res["ipv6_address"] = nil
+	} else {
+		// Set first IP in assigned subnet range
+		res["ipv6_address"] = s.PublicNet.IPv6.IP.String() + "1"
+	}
+
+	if s.Image != nil {
+		if s.Image.Name != "" {
+			// Only use the image name if the image is official (Name != "")
+			res["image"] = s.Image.Name
+		} else {
+			res["image"] = fmt.Sprintf("%d", s.Image.ID)
+		}
+	}
+
+	if s.PlacementGroup != nil {
+		res["placement_group_id"] = util.CastInt(s.PlacementGroup.ID)
+	} else {
+		res["placement_group_id"] = nil
+	}
+
+	// Pass through datacenter name as long as it is returned from the API.
+	//
+	// See https://docs.hetzner.cloud/changelog#2025-12-16-phasing-out-datacenters
+	//nolint:staticcheck // Backwards-compatibility
+	if s.Datacenter != nil {
+		//nolint:staticcheck // Backwards-compatibility
+		res["datacenter"] = s.Datacenter.Name
+	}
+
+	return res
+}
+
+func networkToTerraformNetworks(privateNetworks []hcloud.ServerPrivateNet) []map[string]any {
+	tfPrivateNetworks := make([]map[string]any, len(privateNetworks))
+	for i, privateNetwork := range privateNetworks {
+		tfPrivateNetwork := make(map[string]any)
+		tfPrivateNetwork["ip"] = privateNetwork.IP.String()
+		tfPrivateNetwork["mac_address"] = privateNetwork.MACAddress
+
+		aliasIPs := make([]string, len(privateNetwork.Aliases))
+		for in, ip := range privateNetwork.Aliases {
+			aliasIPs[in] = ip.String()
+		}
+		tfPrivateNetwork["alias_ips"] = aliasIPs
+		tfPrivateNetworks[i] = tfPrivateNetwork
+	}
+	return tfPrivateNetworks
+}
@@ -0,0 +1,69 @@
+package server_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/server"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/teste2e"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/testmux"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/testsupport"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/testtemplate"
+)
+
+func TestAccServerMetricsDataSource(t *testing.T) {
+	tmplMan := testtemplate.Manager{}
+
+	res := &server.RData{
+		Name:  "server-metrics-ds",
+		Type:  teste2e.TestServerType,
+		Image: teste2e.TestImage,
+	}
+	res.SetRName("server-metrics-ds")
+
+	metricsCPU := &server.DDataMetrics{
+		ServerID: res.TFID() + ".id",
+		Type:     "cpu",
+		Duration: "30m",
+		Step:     60,
+	}
+	metricsCPU.SetRName("cpu")
+
+	metricsNetwork := &server.DDataMetrics{
+		ServerID: res.TFID() + ".id",
+		Type:     "network",
+	}
+	metricsNetwork.SetRName("network")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 teste2e.PreCheck(t),
+		ProtoV6ProviderFactories: testmux.ProtoV6ProviderFactories(),
+		CheckDestroy:             testsupport.CheckAPIResourceAllAbsent(server.ResourceType, server.GetAPIResource()),
+		Steps: []resource.TestStep{
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_server", res,
+				),
+			},
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_server", res,
+					"testdata/d/hcloud_server_metrics", metricsCPU,
+					"testdata/d/hcloud_server_metrics", metricsNetwork,
+				),
+
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(metricsCPU.TFID(), "start"),
+					resource.TestCheckResourceAttrSet(metricsCPU.TFID(), "end"),
+					resource.TestCheckResourceAttr(metricsCPU.TFID(), "step", "60"),
+					resource.TestCheckResourceAttr(metricsCPU.TFID(), "series.#", "1"),
+					resource.TestCheckResourceAttr(metricsCPU.TFID(), "series.0.name", "cpu"),
+
+					resource.TestCheckResourceAttr(metricsNetwork.TFID(), "series.#", "4"),
+					resource.TestCheckResourceAttr(metricsNetwork.TFID(), "series.0.name", "network.0.bandwidth.in"),
+				),
+			},
+		},
+	})
+}
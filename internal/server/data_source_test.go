@@ -68,6 +68,10 @@ func TestAccServerDataSource(t *testing.T) {
 						"name", fmt.Sprintf("%s--%d", res.Name, tmplMan.RandInt)),
 					resource.TestCheckResourceAttr(serverBySel.TFID(),
 						"name", fmt.Sprintf("%s--%d", res.Name, tmplMan.RandInt)),
+					resource.TestCheckResourceAttrSet(serverByName.TFID(), "outgoing_traffic"),
+					resource.TestCheckResourceAttrSet(serverByName.TFID(), "ingoing_traffic"),
+					resource.TestCheckResourceAttrSet(serverByName.TFID(), "included_traffic"),
+					resource.TestCheckResourceAttrSet(serverByName.TFID(), "traffic_quota_percent"),
 				),
 			},
 		},
@@ -179,6 +183,7 @@ func TestAccServerDataSourceList(t *testing.T) {
 							"name": fmt.Sprintf("%s--%d", res.Name, tmplMan.RandInt),
 						},
 					),
+					resource.TestCheckResourceAttrSet(serversBySel.TFID(), "servers.0.included_traffic"),
 
 					resource.TestCheckTypeSetElemNestedAttrs(allServersSel.TFID(), "servers.*",
 						map[string]string{
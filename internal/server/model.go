@@ -6,11 +6,13 @@ import (
 	"net"
 
 	"github.com/hashicorp/terraform-plugin-framework-nettypes/iptypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/hcloud-go/v2/hcloud/exp/kit/sliceutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
 )
 
 type networkResourceData struct {
@@ -49,3 +51,278 @@ func populateNetworkResourceData(
 
 	return diags
 }
+
+type resourceModel struct {
+	ID                      types.Int64   `tfsdk:"id"`
+	Name                    types.String  `tfsdk:"name"`
+	ServerType              types.String  `tfsdk:"server_type"`
+	Image                   types.String  `tfsdk:"image"`
+	RebuildOnImageChange    types.Bool    `tfsdk:"rebuild_on_image_change"`
+	RootPassword            types.String  `tfsdk:"root_password"`
+	Location                types.String  `tfsdk:"location"`
+	Datacenter              types.String  `tfsdk:"datacenter"`
+	UserData                types.String  `tfsdk:"user_data"`
+	UserDataEncoding        types.String  `tfsdk:"user_data_encoding"`
+	SSHKeys                 types.List    `tfsdk:"ssh_keys"`
+	KeepDisk                types.Bool    `tfsdk:"keep_disk"`
+	AllowDeprecatedImages   types.Bool    `tfsdk:"allow_deprecated_images"`
+	BackupWindow            types.String  `tfsdk:"backup_window"`
+	Backups                 types.Bool    `tfsdk:"backups"`
+	IPv4Address             types.String  `tfsdk:"ipv4_address"`
+	IPv6Address             types.String  `tfsdk:"ipv6_address"`
+	IPv6Network             types.String  `tfsdk:"ipv6_network"`
+	Status                  types.String  `tfsdk:"status"`
+	ISO                     types.String  `tfsdk:"iso"`
+	Rescue                  types.String  `tfsdk:"rescue"`
+	Labels                  types.Map     `tfsdk:"labels"`
+	LabelsAll               types.Map     `tfsdk:"labels_all"`
+	PublicNet               types.Set     `tfsdk:"public_net"`
+	Network                 types.Set     `tfsdk:"network"`
+	IgnoreRemoteFirewallIDs types.Bool    `tfsdk:"ignore_remote_firewall_ids"`
+	FirewallIDs             types.Set     `tfsdk:"firewall_ids"`
+	VolumeIDs               types.Set     `tfsdk:"volume_ids"`
+	Automount               types.Bool    `tfsdk:"automount"`
+	PlacementGroupID        types.Int64   `tfsdk:"placement_group_id"`
+	DeleteProtection        types.Bool    `tfsdk:"delete_protection"`
+	RebuildProtection       types.Bool    `tfsdk:"rebuild_protection"`
+	ShutdownBeforeDeletion  types.Bool    `tfsdk:"shutdown_before_deletion"`
+	SnapshotBeforeDeletion  types.Bool    `tfsdk:"snapshot_before_deletion"`
+	ShutdownTimeout         types.String  `tfsdk:"shutdown_timeout"`
+	ShutdownFallback        types.String  `tfsdk:"shutdown_fallback"`
+	FinalSnapshot           types.Object  `tfsdk:"final_snapshot"`
+	WaitFor                 types.Object  `tfsdk:"wait_for"`
+	PrimaryDiskSize         types.Int64   `tfsdk:"primary_disk_size"`
+	OutgoingTraffic         types.Int64   `tfsdk:"outgoing_traffic"`
+	IngoingTraffic          types.Int64   `tfsdk:"ingoing_traffic"`
+	IncludedTraffic         types.Int64   `tfsdk:"included_traffic"`
+	TrafficQuotaPercent     types.Float64 `tfsdk:"traffic_quota_percent"`
+	Timeouts                types.Object  `tfsdk:"timeouts"`
+}
+
+type resourcePublicNetModel struct {
+	IPv4Enabled types.Bool  `tfsdk:"ipv4_enabled"`
+	IPv6Enabled types.Bool  `tfsdk:"ipv6_enabled"`
+	IPv4        types.Int64 `tfsdk:"ipv4"`
+	IPv6        types.Int64 `tfsdk:"ipv6"`
+}
+
+func (m resourcePublicNetModel) tfAttributesTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"ipv4_enabled": types.BoolType,
+		"ipv6_enabled": types.BoolType,
+		"ipv4":         types.Int64Type,
+		"ipv6":         types.Int64Type,
+	}
+}
+
+func (m resourcePublicNetModel) equal(o resourcePublicNetModel) bool {
+	return m.IPv4Enabled.Equal(o.IPv4Enabled) &&
+		m.IPv6Enabled.Equal(o.IPv6Enabled) &&
+		m.IPv4.Equal(o.IPv4) &&
+		m.IPv6.Equal(o.IPv6)
+}
+
+type resourceNetworkModel struct {
+	NetworkID  types.Int64  `tfsdk:"network_id"`
+	SubnetID   types.String `tfsdk:"subnet_id"`
+	IP         types.String `tfsdk:"ip"`
+	AliasIPs   types.Set    `tfsdk:"alias_ips"`
+	MACAddress types.String `tfsdk:"mac_address"`
+}
+
+func (m resourceNetworkModel) tfAttributesTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"network_id":  types.Int64Type,
+		"subnet_id":   types.StringType,
+		"ip":          types.StringType,
+		"alias_ips":   types.SetType{ElemType: types.StringType},
+		"mac_address": types.StringType,
+	}
+}
+
+// networkID returns the ID of the configured network, derived from the subnet ID
+// if set. Zero is returned if the network is not known yet.
+func (m resourceNetworkModel) networkID() int64 {
+	if subnetID := m.SubnetID.ValueString(); subnetID != "" {
+		if network, _, err := ParseSubnetID(subnetID); err == nil {
+			return network.ID
+		}
+		return 0
+	}
+	return m.NetworkID.ValueInt64()
+}
+
+// aliasIPs returns the configured alias IPs.
+func (m resourceNetworkModel) aliasIPs() []net.IP {
+	result := make([]net.IP, 0, len(m.AliasIPs.Elements()))
+	for _, v := range m.AliasIPs.Elements() {
+		result = append(result, net.ParseIP(v.(types.String).ValueString()))
+	}
+	return result
+}
+
+type resourceFinalSnapshotModel struct {
+	Description types.String `tfsdk:"description"`
+	Labels      types.Map    `tfsdk:"labels"`
+}
+
+func (m resourceFinalSnapshotModel) tfAttributesTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"description": types.StringType,
+		"labels":      types.MapType{ElemType: types.StringType},
+	}
+}
+
+type resourceTimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+}
+
+func (m resourceTimeoutsModel) tfAttributesTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"create": types.StringType,
+	}
+}
+
+type resourceIdentityModel struct {
+	ID types.Int64 `tfsdk:"id"`
+}
+
+// FromAPI updates the model with the server returned by the API. The current values
+// of the model are used to preserve the structure of the user configuration.
+func (m *resourceModel) FromAPI(ctx context.Context, s *hcloud.Server) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var newDiags diag.Diagnostics
+
+	m.ID = types.Int64Value(s.ID)
+	m.Name = types.StringValue(s.Name)
+	m.ServerType = types.StringValue(s.ServerType.Name)
+	m.Location = types.StringValue(s.Location.Name)
+	m.Status = types.StringValue(string(s.Status))
+	m.BackupWindow = types.StringValue(s.BackupWindow)
+	m.Backups = types.BoolValue(s.BackupWindow != "")
+	m.DeleteProtection = types.BoolValue(s.Protection.Delete)
+	m.RebuildProtection = types.BoolValue(s.Protection.Rebuild)
+	m.PrimaryDiskSize = types.Int64Value(int64(s.PrimaryDiskSize))
+	m.OutgoingTraffic = types.Int64Value(int64(s.OutgoingTraffic)) // nolint:gosec
+	m.IngoingTraffic = types.Int64Value(int64(s.IngoingTraffic))   // nolint:gosec
+	m.IncludedTraffic = types.Int64Value(int64(s.IncludedTraffic)) // nolint:gosec
+	m.TrafficQuotaPercent = types.Float64Value(trafficQuotaPercent(s))
+	m.IPv6Network = types.StringNull()
+	if s.PublicNet.IPv6.Network != nil {
+		m.IPv6Network = types.StringValue(s.PublicNet.IPv6.Network.String())
+	}
+
+	if s.PublicNet.IPv4.IsUnspecified() {
+		m.IPv4Address = types.StringNull()
+	} else {
+		m.IPv4Address = types.StringValue(s.PublicNet.IPv4.IP.String())
+	}
+
+	if len(s.PublicNet.IPv6.IP) == 0 {
+		// No IPv6 Primary IP assigned
+		m.IPv6Address = types.StringNull()
+	} else {
+		// Set first IP in assigned subnet range
+		m.IPv6Address = types.StringValue(s.PublicNet.IPv6.IP.String() + "1")
+	}
+
+	if s.Image != nil {
+		if s.Image.Name != "" && util.FormatID(s.Image.ID) != m.Image.ValueString() {
+			// Only use the image name if the image is official (Name != "")
+			// AND the user did not explicitly specify the image id
+			m.Image = types.StringValue(s.Image.Name)
+		} else {
+			m.Image = types.StringValue(util.FormatID(s.Image.ID))
+		}
+	} else if m.Image.IsUnknown() {
+		m.Image = types.StringNull()
+	}
+
+	// Pass through datacenter name as long as it is returned from the API.
+	//
+	// If the attribute is not returned from the API, we never set the attribute,
+	// so whatever is in the state or user config is kept.
+	//
+	// See https://docs.hetzner.cloud/changelog#2025-12-16-phasing-out-datacenters
+	//nolint:staticcheck // Backwards-compatibility
+	if s.Datacenter != nil {
+		//nolint:staticcheck // Backwards-compatibility
+		m.Datacenter = types.StringValue(s.Datacenter.Name)
+	} else if m.Datacenter.IsUnknown() {
+		m.Datacenter = types.StringNull()
+	}
+
+	if s.PlacementGroup != nil {
+		m.PlacementGroupID = types.Int64Value(s.PlacementGroup.ID)
+	} else {
+		m.PlacementGroupID = types.Int64Value(0)
+	}
+
+	// Firewalls attached from outside of this resource are ignored on demand, see the
+	// hcloud_firewall_attachment resource.
+	if !m.IgnoreRemoteFirewallIDs.ValueBool() || m.FirewallIDs.IsUnknown() || m.FirewallIDs.IsNull() {
+		firewallIDs := sliceutil.Transform(s.PublicNet.Firewalls, func(f *hcloud.ServerFirewallStatus) int64 { return f.Firewall.ID })
+		m.FirewallIDs, newDiags = types.SetValueFrom(ctx, types.Int64Type, firewallIDs)
+		diags.Append(newDiags...)
+	}
+
+	// Only write the volumes if they are managed by the resource. This avoids
+	// conflicts with the volumes managed by the "hcloud_volume_attachment" resource.
+	if !m.VolumeIDs.IsNull() {
+		volumeIDs := sliceutil.Transform(s.Volumes, func(v *hcloud.Volume) int64 { return v.ID })
+		m.VolumeIDs, newDiags = types.SetValueFrom(ctx, types.Int64Type, volumeIDs)
+		diags.Append(newDiags...)
+	}
+
+	// Only write the networks if the resource already contains such an entry. This
+	// avoids conflicts with the networks managed by the "hcloud_server_network"
+	// resource.
+	if len(m.Network.Elements()) > 0 || m.Network.IsUnknown() {
+		m.Network, newDiags = m.networksFromAPI(ctx, s.PrivateNet)
+		diags.Append(newDiags...)
+	}
+
+	return diags
+}
+
+func (m *resourceModel) networksFromAPI(ctx context.Context, privateNetworks []hcloud.ServerPrivateNet) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var configured []resourceNetworkModel
+	if !m.Network.IsUnknown() {
+		diags.Append(m.Network.ElementsAs(ctx, &configured, false)...)
+	}
+
+	elements := make([]resourceNetworkModel, 0, len(privateNetworks))
+	for _, privateNetwork := range privateNetworks {
+		element := resourceNetworkModel{
+			NetworkID:  types.Int64Value(privateNetwork.Network.ID),
+			SubnetID:   types.StringNull(),
+			IP:         types.StringValue(privateNetwork.IP.String()),
+			MACAddress: types.StringValue(privateNetwork.MACAddress),
+		}
+
+		// Check the user input to preserve the same structure in state
+		for _, item := range configured {
+			if item.networkID() == privateNetwork.Network.ID {
+				if item.SubnetID.ValueString() != "" {
+					element.SubnetID = item.SubnetID
+				}
+				break
+			}
+		}
+
+		aliasIPs := sliceutil.Transform(privateNetwork.Aliases, func(ip net.IP) string { return ip.String() })
+
+		var newDiags diag.Diagnostics
+		element.AliasIPs, newDiags = types.SetValueFrom(ctx, types.StringType, aliasIPs)
+		diags.Append(newDiags...)
+
+		elements = append(elements, element)
+	}
+
+	result, newDiags := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: resourceNetworkModel{}.tfAttributesTypes()}, elements)
+	diags.Append(newDiags...)
+
+	return result, diags
+}
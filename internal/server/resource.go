@@ -2,18 +2,35 @@ package server
 
 import (
 	"context"
-	"crypto/sha1" // nolint: gosec
-	"encoding/base64"
 	"errors"
 	"fmt"
-	"log"
+	"maps"
 	"net"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/hashicorp/go-cty/cty"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/hcloud-go/v2/hcloud/exp/deprecationutil"
@@ -21,347 +38,596 @@ import (
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/control"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/resourceutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/validateutil"
 )
 
 // ResourceType is the type name of the Hetzner Cloud Server resource.
 const ResourceType = "hcloud_server"
 
-// Resource creates a Terraform schema for the hcloud_server resource.
-func Resource() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceServerCreate,
-		ReadContext:   resourceServerRead,
-		UpdateContext: resourceServerUpdate,
-		DeleteContext: resourceServerDelete,
-		CustomizeDiff: resourceServerCustomizeDiff,
-
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
-		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(90 * time.Minute),
-		},
+// defaultCreateTimeout is the time a server creation may take, unless configured in
+// the timeouts block.
+const defaultCreateTimeout = 90 * time.Minute
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"server_type": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"image": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				ForceNew: true,
-				ValidateFunc: func(val any, key string) (i []string, errors []error) {
-					image := val.(string)
-					if len(image) == 0 {
-						errors = append(errors, fmt.Errorf("%q must have more than 0 characters. Have you set the name instead of an ID?", key))
-					}
-					return
-				},
-			},
-			"location": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Computed: true,
-			},
-			"datacenter": {
-				Type:       schema.TypeString,
-				Optional:   true,
-				ForceNew:   true,
-				Computed:   true,
-				Deprecated: "The datacenter attribute is deprecated and will be removed after 1 July 2026. Please use the location attribute instead. See https://docs.hetzner.cloud/changelog#2025-12-16-phasing-out-datacenters.",
-			},
-			"user_data": {
-				Type:             schema.TypeString,
-				Optional:         true,
-				ForceNew:         true,
-				DiffSuppressFunc: userDataDiffSuppress,
-				StateFunc: func(v any) string {
-					switch x := v.(type) {
-					case string:
-						return userDataHashSum(x)
-					default:
-						return ""
-					}
-				},
-			},
-			"ssh_keys": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Schema{Type: schema.TypeString,
-					ValidateDiagFunc: func(i any, _ cty.Path) diag.Diagnostics {
-						s := i.(string)
-						if len(s) == 0 {
-							return diag.Errorf("Invalid ssh key passed. You need to pass a string with at least 1 character")
-						}
-
-						return nil
-					}},
-				ForceNew: true,
+const ChangeDeprecatedServerTypeMessage = `Existing servers of that plan will ` +
+	`continue to work as before and no action is required on your part. ` +
+	`It is possible to migrate this Server to another Server Type by using ` +
+	`the "hcloud server change-type" command.`
+
+var _ resource.Resource = (*Resource)(nil)
+var _ resource.ResourceWithConfigure = (*Resource)(nil)
+var _ resource.ResourceWithModifyPlan = (*Resource)(nil)
+var _ resource.ResourceWithValidateConfig = (*Resource)(nil)
+var _ resource.ResourceWithImportState = (*Resource)(nil)
+var _ resource.ResourceWithIdentity = (*Resource)(nil)
+var _ resource.ResourceWithUpgradeState = (*Resource)(nil)
+
+type Resource struct {
+	client   *hcloud.Client
+	labels   hcloudutil.LabelsConfig
+	defaults hcloudutil.DefaultsConfig
+}
+
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+func (r *Resource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = ResourceType
+}
+
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
+	resp.Diagnostics.Append(newDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = providerData.Client
+	r.labels = providerData.Labels
+	r.defaults = providerData.Defaults
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema.MarkdownDescription = util.MarkdownDescription(`
+Provides an Hetzner Cloud server resource. This can be used to create, modify, and delete servers.
+
+See the [Servers API documentation](https://docs.hetzner.cloud/reference/cloud#tag/servers) for more details.
+`)
+
+	// Version 0 is the schema of the resource implemented with the plugin SDK.
+	resp.Schema.Version = 1
+
+	resp.Schema.Attributes = map[string]schema.Attribute{
+		"id": schema.Int64Attribute{
+			MarkdownDescription: "ID of the Server.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
 			},
-			"keep_disk": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the Server.",
+			Required:            true,
+		},
+		"server_type": schema.StringAttribute{
+			MarkdownDescription: "Name of the Server Type of the Server.",
+			Required:            true,
+		},
+		"image": schema.StringAttribute{
+			MarkdownDescription: "Name or ID of the Image the Server is created from.",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplaceIf(
+					imageRequiresReplace,
+					"Changing the image replaces the Server, unless rebuild_on_image_change is enabled.",
+					"Changing the image replaces the Server, unless `rebuild_on_image_change` is enabled.",
+				),
 			},
-			"allow_deprecated_images": {
-				Deprecated: "Unused attribute, consider removing it from your configuration.",
-				Type:       schema.TypeBool,
-				Optional:   true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
 			},
-			"backup_window": {
-				Type:       schema.TypeString,
-				Deprecated: "You should remove this property from your terraform configuration.",
-				Computed:   true,
+		},
+		"rebuild_on_image_change": schema.BoolAttribute{
+			MarkdownDescription: "Whether to rebuild the Server in place when the `image` changes, instead of replacing it. Rebuilding keeps the Server ID, its IPs and networks, but erases its disk.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"root_password": schema.StringAttribute{
+			MarkdownDescription: "Root password returned by the API when the Server was created or last rebuilt. Only set when the Server has no SSH keys.",
+			Computed:            true,
+			Sensitive:           true,
+		},
+		"location": schema.StringAttribute{
+			MarkdownDescription: "Name of the Location of the Server. Defaults to the provider `default_location` when neither `location` nor `datacenter` are set.",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
 			},
-			"backups": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
+		},
+		"datacenter": schema.StringAttribute{
+			MarkdownDescription: "Name of the Datacenter of the Server. Defaults to the provider `default_datacenter` when neither `location` nor `datacenter` are set, and no provider `default_location` is configured.",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				stringplanmodifier.RequiresReplace(),
 			},
-			"ipv4_address": {
-				Type:     schema.TypeString,
-				Computed: true,
+			DeprecationMessage: "The datacenter attribute is deprecated and will be removed after 1 July 2026. Please use the location attribute instead. See https://docs.hetzner.cloud/changelog#2025-12-16-phasing-out-datacenters.",
+		},
+		"user_data": schema.StringAttribute{
+			MarkdownDescription: "Cloud-Init user data to use during Server creation.",
+			Optional:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplaceIf(
+					userDataRequiresReplace,
+					"Changing the user data replaces the Server.",
+					"Changing the user data replaces the Server.",
+				),
 			},
-			"ipv6_address": {
-				Type:     schema.TypeString,
-				Computed: true,
+		},
+		"user_data_encoding": schema.StringAttribute{
+			MarkdownDescription: "Encoding of the `user_data` sent to the API. Set to `gzip+base64` to compress user data that exceeds the 32 KiB limit of the API, cloud-init decodes it transparently.",
+			Optional:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
 			},
-			"ipv6_network": {
-				Type:     schema.TypeString,
-				Computed: true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(userDataEncodingGzipBase64),
 			},
-			"status": {
-				Type:     schema.TypeString,
-				Computed: true,
+		},
+		"ssh_keys": schema.ListAttribute{
+			MarkdownDescription: "SSH key IDs or names which should be injected into the Server at creation time.",
+			ElementType:         types.StringType,
+			Optional:            true,
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.RequiresReplace(),
 			},
-			"iso": {
-				Type:     schema.TypeString,
-				Optional: true,
+		},
+		"keep_disk": schema.BoolAttribute{
+			MarkdownDescription: "If true, do not upgrade the disk. This allows downgrading the Server Type later.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"allow_deprecated_images": schema.BoolAttribute{
+			Optional:           true,
+			Computed:           true,
+			Default:            booldefault.StaticBool(false),
+			DeprecationMessage: "Unused attribute, consider removing it from your configuration.",
+		},
+		"backup_window": schema.StringAttribute{
+			Computed:           true,
+			DeprecationMessage: "You should remove this property from your terraform configuration.",
+		},
+		"backups": schema.BoolAttribute{
+			MarkdownDescription: "Whether backups are enabled.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"ipv4_address": schema.StringAttribute{
+			MarkdownDescription: "The IPv4 address of the Server.",
+			Computed:            true,
+		},
+		"ipv6_address": schema.StringAttribute{
+			MarkdownDescription: "The first IPv6 address of the assigned IPv6 network.",
+			Computed:            true,
+		},
+		"ipv6_network": schema.StringAttribute{
+			MarkdownDescription: "The IPv6 network of the Server.",
+			Computed:            true,
+		},
+		"status": schema.StringAttribute{
+			MarkdownDescription: "The status of the Server.",
+			Computed:            true,
+		},
+		"iso": schema.StringAttribute{
+			MarkdownDescription: "ID or Name of an ISO image to mount.",
+			Optional:            true,
+		},
+		"rescue": schema.StringAttribute{
+			MarkdownDescription: "Enable and boot in to the specified rescue system.",
+			Optional:            true,
+		},
+		"labels":     resourceutil.LabelsSchema(),
+		"labels_all": resourceutil.LabelsAllSchema(),
+		"ignore_remote_firewall_ids": schema.BoolAttribute{
+			MarkdownDescription: "Ignores any updates to the `firewall_ids` argument which were received from the Server.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"firewall_ids": schema.SetAttribute{
+			MarkdownDescription: "Firewall IDs the Server should be attached to.",
+			ElementType:         types.Int64Type,
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Set{
+				setplanmodifier.UseStateForUnknown(),
 			},
-			"rescue": {
-				Type:     schema.TypeString,
-				Optional: true,
+		},
+		"volume_ids": schema.SetAttribute{
+			MarkdownDescription: "Volume IDs the Server should be attached to. Volumes are only managed by this argument when it is set, it must not be used together with the `hcloud_volume_attachment` resource for the same Server.",
+			ElementType:         types.Int64Type,
+			Optional:            true,
+		},
+		"automount": schema.BoolAttribute{
+			MarkdownDescription: "Whether to automount the Volumes in `volume_ids` after attaching them.",
+			Optional:            true,
+		},
+		"placement_group_id": schema.Int64Attribute{
+			MarkdownDescription: "Placement Group ID the Server is added to.",
+			Optional:            true,
+			Computed:            true,
+			Default:             int64default.StaticInt64(0),
+		},
+		"delete_protection": schema.BoolAttribute{
+			MarkdownDescription: "Whether delete protection is enabled.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"rebuild_protection": schema.BoolAttribute{
+			MarkdownDescription: "Whether rebuild protection is enabled.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"shutdown_before_deletion": schema.BoolAttribute{
+			MarkdownDescription: "Whether to try shutting the Server down gracefully before deleting it.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"snapshot_before_deletion": schema.BoolAttribute{
+			MarkdownDescription: "Whether to shut the Server down and create a snapshot before deleting it. The deletion is aborted if the snapshot fails.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+		"shutdown_timeout":  resourceutil.ShutdownTimeoutSchema(),
+		"shutdown_fallback": resourceutil.ShutdownFallbackSchema(),
+		"primary_disk_size": schema.Int64Attribute{
+			MarkdownDescription: "The size of the primary disk in GB.",
+			Computed:            true,
+		},
+		"outgoing_traffic": schema.Int64Attribute{
+			MarkdownDescription: "Outbound traffic of the Server for the current billing period in bytes.",
+			Computed:            true,
+		},
+		"ingoing_traffic": schema.Int64Attribute{
+			MarkdownDescription: "Inbound traffic of the Server for the current billing period in bytes.",
+			Computed:            true,
+		},
+		"included_traffic": schema.Int64Attribute{
+			MarkdownDescription: "Free outbound traffic included with the Server for the current billing period in bytes.",
+			Computed:            true,
+		},
+		"traffic_quota_percent": schema.Float64Attribute{
+			MarkdownDescription: "Outbound traffic of the Server in percent of its included traffic.",
+			Computed:            true,
+		},
+	}
+
+	resp.Schema.Blocks = map[string]schema.Block{
+		"public_net": schema.SetNestedBlock{
+			MarkdownDescription: "Enable or disable the public IPv4 and IPv6 of the Server, or link existing Primary IPs.",
+			NestedObject: schema.NestedBlockObject{
+				Attributes: map[string]schema.Attribute{
+					"ipv4_enabled": schema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(true),
+					},
+					"ipv6_enabled": schema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(true),
+					},
+					"ipv4": schema.Int64Attribute{
+						MarkdownDescription: "ID of the IPv4 Primary IP to assign to the Server.",
+						Optional:            true,
+						Computed:            true,
+					},
+					"ipv6": schema.Int64Attribute{
+						MarkdownDescription: "ID of the IPv6 Primary IP to assign to the Server.",
+						Optional:            true,
+						Computed:            true,
+					},
+				},
 			},
-			"labels": {
-				Type:     schema.TypeMap,
-				Optional: true,
-				ValidateDiagFunc: func(i any, path cty.Path) diag.Diagnostics { // nolint:revive
-					if ok, err := hcloud.ValidateResourceLabels(i.(map[string]any)); !ok {
-						return diag.FromErr(err)
-					}
-					return nil
+		},
+		"network": schema.SetNestedBlock{
+			MarkdownDescription: "Network the Server should be attached to.",
+			NestedObject: schema.NestedBlockObject{
+				Attributes: map[string]schema.Attribute{
+					"network_id": schema.Int64Attribute{
+						MarkdownDescription: "ID of the Network to attach the Server to. Using `subnet_id` is preferred.",
+						Optional:            true,
+						Computed:            true,
+					},
+					"subnet_id": schema.StringAttribute{
+						MarkdownDescription: "ID of the Subnet to attach the Server to.",
+						Optional:            true,
+						Computed:            true,
+					},
+					"ip": schema.StringAttribute{
+						MarkdownDescription: "IP the Server should get in the Network.",
+						Optional:            true,
+						Computed:            true,
+					},
+					"alias_ips": schema.SetAttribute{
+						MarkdownDescription: "Alias IPs the Server should have in the Network.",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Computed:            true,
+					},
+					"mac_address": schema.StringAttribute{
+						MarkdownDescription: "MAC address of the Server in the Network.",
+						Computed:            true,
+					},
 				},
 			},
-			"public_net": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				DiffSuppressFunc: func(_, _, _ string, d *schema.ResourceData) bool {
-					// Diff is only valid if "public_net" resource is set in
-					// terraform configuration.
-					_, ok := d.GetOk("public_net")
-					return !ok // Negate because we do **not** want to suppress the diff.
+		},
+		"final_snapshot": schema.SingleNestedBlock{
+			MarkdownDescription: "Properties of the snapshot created before deleting the Server, when `snapshot_before_deletion` is enabled.",
+			Attributes: map[string]schema.Attribute{
+				"description": schema.StringAttribute{
+					MarkdownDescription: "Description of the snapshot.",
+					Optional:            true,
 				},
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"ipv4_enabled": {
-							Type:     schema.TypeBool,
-							Optional: true,
-							Default:  true,
-						},
-						"ipv6_enabled": {
-							Type:     schema.TypeBool,
-							Optional: true,
-							Default:  true,
-						},
-						"ipv4": {
-							Type:     schema.TypeInt,
-							Optional: true,
-							Computed: true,
-						},
-						"ipv6": {
-							Type:     schema.TypeInt,
-							Optional: true,
-							Computed: true,
-						},
+				"labels": schema.MapAttribute{
+					MarkdownDescription: "User-defined [labels](https://docs.hetzner.cloud/reference/cloud#labels) (key-value pairs) of the snapshot.",
+					ElementType:         types.StringType,
+					Optional:            true,
+					Validators: []validator.Map{
+						resourceutil.LabelsValidator(),
 					},
 				},
 			},
-			"network": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				DiffSuppressFunc: func(_, _, _ string, d *schema.ResourceData) bool {
-					// Diff is only valid if "network" resource is set in
-					// terraform configuration.
-					_, ok := d.GetOk("network")
-					return !ok // Negate because we do **not** want to suppress the diff.
+		},
+		"wait_for": schema.SingleNestedBlock{
+			MarkdownDescription: "Conditions the Server must meet before its creation completes, and before updates that restart or rebuild the Server complete, for example to let dependent provisioners connect once the Server booted.",
+			Attributes: map[string]schema.Attribute{
+				"tcp_port": schema.Int64Attribute{
+					MarkdownDescription: "Port on which the Server must accept TCP connections, for example `22`.",
+					Optional:            true,
+					Validators: []validator.Int64{
+						int64validator.Between(1, 65535),
+					},
+				},
+				"http_url": schema.StringAttribute{
+					MarkdownDescription: "URL that must respond with a 2xx status code, for example a health check endpoint of the Server.",
+					Optional:            true,
 				},
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"network_id": {
-							Type:     schema.TypeInt,
-							Optional: true,
-							Computed: true,
-						},
-						"subnet_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-						},
-						"ip": {
-							Type:     schema.TypeString,
-							Computed: true,
-							Optional: true,
-						},
-						"alias_ips": {
-							Type:     schema.TypeSet,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-							Computed: true,
-							Optional: true,
-						},
-						"mac_address": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
+				"address": schema.StringAttribute{
+					MarkdownDescription: "Address of the Server the TCP connections are opened to: `ipv4`, `ipv6` or `private` (the IP in the first attached network). Defaults to `ipv4`.",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(waitForAddressIPv4, waitForAddressIPv6, waitForAddressPrivate),
 					},
 				},
-			},
-			"ignore_remote_firewall_ids": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
-			},
-			"firewall_ids": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Computed: true,
-				Elem:     &schema.Schema{Type: schema.TypeInt},
-				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool { // nolint:revive
-					sup := d.Get("ignore_remote_firewall_ids").(bool)
-					if sup && oldValue != "" && newValue != "" {
-						return true
-					}
-					return false
+				"timeout": schema.StringAttribute{
+					MarkdownDescription: "Time the Server is given to meet the conditions, for example `10m`. Defaults to `5m`.",
+					Optional:            true,
+					Validators: []validator.String{
+						validateutil.Duration(),
+					},
 				},
 			},
-			"placement_group_id": {
-				Type:     schema.TypeInt,
-				Optional: true,
-			},
-			"delete_protection": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
-			},
-			"rebuild_protection": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
-			},
-			"shutdown_before_deletion": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
-			},
-			"primary_disk_size": {
-				Type:     schema.TypeInt,
-				Computed: true,
+		},
+		"timeouts": schema.SingleNestedBlock{
+			Attributes: map[string]schema.Attribute{
+				"create": schema.StringAttribute{
+					MarkdownDescription: "Time the creation of the Server may take, for example `30m`. Defaults to `90m`.",
+					Optional:            true,
+				},
 			},
 		},
 	}
 }
 
-func userDataHashSum(userData string) string {
-	sum := sha1.Sum([]byte(userData)) // nolint: gosec
-	return base64.StdEncoding.EncodeToString(sum[:])
+func (r *Resource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.Int64Attribute{
+				Description:       "ID of the Server.",
+				RequiredForImport: true,
+			},
+		},
+	}
 }
 
-func userDataDiffSuppress(k, oldValue, newValue string, d *schema.ResourceData) bool {
-	userData := d.Get(k).(string)
-	if newValue != "" && userData != "" {
-		if _, err := base64.StdEncoding.DecodeString(oldValue); err != nil {
-			return userDataHashSum(oldValue) == newValue
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data resourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, sshKey := range data.SSHKeys.Elements() {
+		if value, ok := sshKey.(types.String); ok && !value.IsUnknown() && !value.IsNull() && value.ValueString() == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ssh_keys").AtListIndex(i),
+				"Invalid ssh key passed",
+				"You need to pass a string with at least 1 character.",
+			)
+		}
+	}
+
+	resp.Diagnostics.Append(validateUserData(data.UserData, data.UserDataEncoding)...)
+	resp.Diagnostics.Append(validateWaitFor(ctx, data.WaitFor)...)
+
+	if !data.Timeouts.IsUnknown() && !data.Timeouts.IsNull() {
+		var timeouts resourceTimeoutsModel
+		resp.Diagnostics.Append(data.Timeouts.As(ctx, &timeouts, basetypes.ObjectAsOptions{})...)
+
+		if !timeouts.Create.IsUnknown() && !timeouts.Create.IsNull() {
+			if _, err := time.ParseDuration(timeouts.Create.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("timeouts").AtName("create"),
+					"Invalid timeout",
+					fmt.Sprintf("Timeout is not a valid duration: %s", err),
+				)
+			}
 		}
 	}
-	return strings.TrimSpace(oldValue) == strings.TrimSpace(newValue)
 }
 
-const ChangeDeprecatedServerTypeMessage = `Existing servers of that plan will ` +
-	`continue to work as before and no action is required on your part. ` +
-	`It is possible to migrate this Server to another Server Type by using ` +
-	`the "hcloud server change-type" command.`
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
 
-func resourceServerCreate(ctx context.Context, d *schema.ResourceData, m any) (diags diag.Diagnostics) {
-	c := m.(*hcloud.Client)
+	resourceutil.ModifyPlanLabelsAll(ctx, r.labels, req, resp)
+	resourceutil.ModifyPlanDefaultLocation(ctx, r.defaults, req, resp, "location", "datacenter")
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Get server type to select correct image (based on arch)
-	serverType, _, err := c.ServerType.Get(ctx, d.Get("server_type").(string))
-	if err != nil {
-		diags = append(diags, hcloudutil.ErrorToDiag(err)...)
+	var config, plan, state resourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	if serverType == nil {
-		diags = append(diags, diag.Errorf("server type %s not found", d.Get("server_type"))...)
+
+	resp.Diagnostics.Append(validateNetworks(ctx, config)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	imageNameOrID := d.Get("image").(string)
-	image, _, err := c.Image.GetForArchitecture(ctx, imageNameOrID, serverType.Architecture)
-	if err != nil {
-		diags = append(diags, hcloudutil.ErrorToDiag(err)...)
+	// The framework marks the computed attributes of nested blocks as unknown, as it
+	// is unable to correlate the set elements with the prior state. Restore the
+	// values of the unchanged elements, to only plan the actual changes.
+	var newDiags diag.Diagnostics
+	plan.PublicNet, newDiags = planPublicNet(ctx, config.PublicNet, state.PublicNet)
+	resp.Diagnostics.Append(newDiags...)
+	if !req.State.Raw.IsNull() {
+		plan.Network, newDiags = planNetworks(ctx, config.Network, state.Network)
+		resp.Diagnostics.Append(newDiags...)
+	}
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if image == nil {
-		diags = append(diags, diag.Errorf("image %s for architecture %s not found", imageNameOrID, serverType.Architecture)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("public_net"), plan.PublicNet)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("network"), plan.Network)...)
+
+	if req.State.Raw.IsNull() {
 		return
 	}
 
-	if message, unavailable := deprecationutil.ImageMessage(image); message != "" {
-		if unavailable {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Image Unavailable",
-				Detail:   message + ".",
-			})
-			return
-		}
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Warning,
-			Summary:  "Image Deprecated",
-			Detail:   message + ".",
+	// Computed attributes only change along with the attributes they depend on.
+	// Keep their prior value otherwise, to avoid noisy plans.
+	for attribute, dependencies := range map[string][]string{
+		"status":            {"server_type", "keep_disk", "iso", "rescue", "public_net", "placement_group_id"},
+		"ipv4_address":      {"public_net"},
+		"ipv6_address":      {"public_net"},
+		"ipv6_network":      {"public_net"},
+		"primary_disk_size": {"server_type", "keep_disk"},
+		"backup_window":     {"backups"},
+		"root_password":     {"image"},
+	} {
+		changed := slices.ContainsFunc(dependencies, func(dependency string) bool {
+			var planValue, stateValue attr.Value
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(dependency), &planValue)...)
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(dependency), &stateValue)...)
+			return planValue == nil || planValue.IsUnknown() || !sameValue(planValue, stateValue)
 		})
+		if changed {
+			continue
+		}
+
+		var stateValue attr.Value
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attribute), &stateValue)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attribute), stateValue)...)
 	}
 
-	opts := hcloud.ServerCreateOpts{
-		Name: d.Get("name").(string),
-		ServerType: &hcloud.ServerType{
-			Name: d.Get("server_type").(string),
-		},
-		Image:    image,
-		UserData: d.Get("user_data").(string),
+	// The traffic counters change continuously, their prior value is only kept when
+	// the Server is not updated.
+	trafficAttributes := []string{"outgoing_traffic", "ingoing_traffic", "included_traffic", "traffic_quota_percent"}
+	updated, diags := planUpdates(ctx, resp.Plan, req.State, trafficAttributes)
+	resp.Diagnostics.Append(diags...)
+	if !updated {
+		for _, attribute := range trafficAttributes {
+			var stateValue attr.Value
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(attribute), &stateValue)...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attribute), stateValue)...)
+		}
+	}
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data resourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if location, ok := d.GetOk("location"); ok {
-		opts.Location = &hcloud.Location{Name: location.(string)}
-	} else if datacenter, ok := d.GetOk("datacenter"); ok {
-		// Backward compatible datacenter argument.
-		// datacenter hel1-dc2 => location hel1
-		parts := strings.SplitN(datacenter.(string), "-", 2)
+	timeout, newDiags := data.createTimeout(ctx)
+	resp.Diagnostics.Append(newDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Get server type to select correct image (based on arch)
+	serverType, newDiags := getServerType(ctx, r.client, data.ServerType.ValueString())
+	resp.Diagnostics.Append(newDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	image, newDiags := getImage(ctx, r.client, data.Image.ValueString(), serverType.Architecture)
+	resp.Diagnostics.Append(newDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userData, err := encodeUserData(data.UserData.ValueString(), data.UserDataEncoding.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("user_data"), "Invalid user data", err.Error())
+		return
+	}
+
+	opts := hcloud.ServerCreateOpts{
+		Name:       data.Name.ValueString(),
+		ServerType: &hcloud.ServerType{Name: data.ServerType.ValueString()},
+		Image:      image,
+		UserData:   userData,
+	}
+
+	switch {
+	case data.Location.ValueString() != "":
+		opts.Location = &hcloud.Location{Name: data.Location.ValueString()}
+	case data.Datacenter.ValueString() != "":
+		// Backward compatible datacenter argument: datacenter hel1-dc2 => location hel1
+		parts := strings.SplitN(data.Datacenter.ValueString(), "-", 2)
 		if len(parts) != 2 {
-			diags = append(diags, diag.Errorf("Datacenter name is not valid, expected format $LOCATION-$DATACENTER, but got: %s", datacenter.(string))...)
+			resp.Diagnostics.AddAttributeError(
+				path.Root("datacenter"),
+				"Invalid datacenter name",
+				fmt.Sprintf("Datacenter name is not valid, expected format $LOCATION-$DATACENTER, but got: %s", data.Datacenter.ValueString()),
+			)
 			return
 		}
-
-		locationName := parts[0]
-		opts.Location = &hcloud.Location{Name: locationName}
+		opts.Location = &hcloud.Location{Name: parts[0]}
 	}
 	locationName := ""
 	if opts.Location != nil {
@@ -369,476 +635,1077 @@ func resourceServerCreate(ctx context.Context, d *schema.ResourceData, m any) (d
 	}
 
 	serverTypeDeprecationPrinted := false
-	if message, isUnavailable := deprecationutil.ServerTypeMessage(serverType, locationName); message != "" {
+	if d := serverTypeDeprecationDiagnostic(serverType, locationName); d != nil {
 		serverTypeDeprecationPrinted = true
-		deprecationDiag := diag.Diagnostic{
-			Severity: diag.Warning,
-			Summary:  message,
-			Detail:   ChangeDeprecatedServerTypeMessage,
-		}
-		if isUnavailable {
-			deprecationDiag.Severity = diag.Error
-			diags = append(diags, deprecationDiag)
+		resp.Diagnostics.Append(d)
+		if resp.Diagnostics.HasError() {
 			return
 		}
-
-		diags = append(diags, deprecationDiag)
 	}
 
-	opts.SSHKeys, err = getSSHkeys(ctx, c, d)
-	if err != nil {
-		diags = append(diags, hcloudutil.ErrorToDiag(err)...)
-		return
-	}
+	opts.SSHKeys, newDiags = r.getSSHKeys(ctx, data.SSHKeys)
+	resp.Diagnostics.Append(newDiags...)
 
-	if labels, ok := d.GetOk("labels"); ok {
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
+	resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, data.LabelsAll, &opts.Labels)...)
+
+	if !data.FirewallIDs.IsUnknown() {
+		var firewallIDs []int64
+		resp.Diagnostics.Append(data.FirewallIDs.ElementsAs(ctx, &firewallIDs, false)...)
+		for _, firewallID := range firewallIDs {
+			opts.Firewalls = append(opts.Firewalls, &hcloud.ServerCreateFirewall{Firewall: hcloud.Firewall{ID: firewallID}})
 		}
-		opts.Labels = tmpLabels
 	}
 
-	if firewallIDs, ok := d.GetOk("firewall_ids"); ok {
-		for _, firewallID := range firewallIDs.(*schema.Set).List() {
-			opts.Firewalls = append(opts.Firewalls, &hcloud.ServerCreateFirewall{Firewall: hcloud.Firewall{ID: util.CastInt64(firewallID)}})
+	if !data.VolumeIDs.IsNull() {
+		var volumeIDs []int64
+		resp.Diagnostics.Append(data.VolumeIDs.ElementsAs(ctx, &volumeIDs, false)...)
+		for _, volumeID := range volumeIDs {
+			opts.Volumes = append(opts.Volumes, &hcloud.Volume{ID: volumeID})
+		}
+		if len(opts.Volumes) > 0 {
+			opts.Automount = data.Automount.ValueBoolPointer()
 		}
 	}
 
-	if placementGroupID, ok := d.GetOk("placement_group_id"); ok {
-		placementGroup, err := getPlacementGroup(ctx, c, util.CastInt64(placementGroupID))
+	var publicNets []resourcePublicNetModel
+	resp.Diagnostics.Append(data.PublicNet.ElementsAs(ctx, &publicNets, false)...)
+
+	var networks []resourceNetworkModel
+	resp.Diagnostics.Append(data.Network.ElementsAs(ctx, &networks, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if placementGroupID := data.PlacementGroupID.ValueInt64(); placementGroupID != 0 {
+		placementGroup, err := getPlacementGroup(ctx, r.client, placementGroupID)
 		if err != nil {
-			diags = append(diags, hcloudutil.ErrorToDiag(err)...)
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
 			return
 		}
-
 		opts.PlacementGroup = placementGroup
 	}
 
-	if publicNet, ok := d.GetOk("public_net"); ok {
+	if len(publicNets) > 0 {
 		createPublicNet := hcloud.ServerCreatePublicNet{}
-		for _, publicNetBlock := range publicNet.(*schema.Set).List() {
-			publicNetEntry := publicNetBlock.(map[string]any)
-			if enableIPv4, err := ToPublicNetField[bool](publicNetEntry, "ipv4_enabled"); err == nil {
-				createPublicNet.EnableIPv4 = enableIPv4
-			}
-			if enableIPv6, err := ToPublicNetField[bool](publicNetEntry, "ipv6_enabled"); err == nil {
-				createPublicNet.EnableIPv6 = enableIPv6
-			}
-			if ipv4, err := ToPublicNetField[int](publicNetEntry, "ipv4"); err == nil && ipv4 != 0 {
+		for _, publicNet := range publicNets {
+			createPublicNet.EnableIPv4 = publicNet.IPv4Enabled.ValueBool()
+			createPublicNet.EnableIPv6 = publicNet.IPv6Enabled.ValueBool()
+			if ipv4 := publicNet.IPv4.ValueInt64(); ipv4 != 0 {
 				createPublicNet.EnableIPv4 = true
-				createPublicNet.IPv4 = &hcloud.PrimaryIP{ID: util.CastInt64(ipv4)}
+				createPublicNet.IPv4 = &hcloud.PrimaryIP{ID: ipv4}
 			}
-			if ipv6, err := ToPublicNetField[int](publicNetEntry, "ipv6"); err == nil && ipv6 != 0 {
+			if ipv6 := publicNet.IPv6.ValueInt64(); ipv6 != 0 {
 				createPublicNet.EnableIPv6 = true
-				createPublicNet.IPv6 = &hcloud.PrimaryIP{ID: util.CastInt64(ipv6)}
+				createPublicNet.IPv6 = &hcloud.PrimaryIP{ID: ipv6}
 			}
 		}
 		opts.PublicNet = &createPublicNet
-		// if the server has no public net, it has to be created without starting it
-		if err := onServerCreateWithoutPublicNet(&opts, d, func(opts *hcloud.ServerCreateOpts) error {
-			opts.StartAfterCreate = new(false)
-			return nil
-		}); err != nil {
-			diags = append(diags, err...)
-			return
-		}
 	}
-	res, _, err := c.Server.Create(ctx, opts)
+
+	// If the server has no public net, it has to be created without starting it, and
+	// powered on once attached to its networks.
+	createdWithoutPublicNet := len(networks) > 0 && opts.PublicNet != nil &&
+		!opts.PublicNet.EnableIPv4 && !opts.PublicNet.EnableIPv6
+	if createdWithoutPublicNet {
+		opts.StartAfterCreate = new(false)
+	}
+
+	result, _, err := r.client.Server.Create(ctx, opts)
 	if err != nil {
-		diags = append(diags, hcloudutil.ErrorToDiag(err)...)
+		resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
 		return
 	}
-	d.SetId(util.FormatID(res.Server.ID))
+
+	// Make sure to save the ID immediately so we can recover if the process stops after
+	// this call. Terraform marks the resource as "tainted", so it can be deleted and no
+	// surprise "duplicate resource" errors happen.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), types.Int64Value(result.Server.ID))...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, resourceIdentityModel{ID: types.Int64Value(result.Server.ID)})...)
+
+	data.RootPassword = nullIfZero(types.StringValue(result.RootPassword))
 
 	if !serverTypeDeprecationPrinted {
 		// We now know the server location and can check the server type deprecation again.
-		if message, _ := deprecationutil.ServerTypeMessage(res.Server.ServerType, res.Server.Location.Name); message != "" {
-			deprecationDiag := diag.Diagnostic{
-				Severity: diag.Warning,
-				Summary:  message,
-				Detail:   ChangeDeprecatedServerTypeMessage,
-			}
-			diags = append(diags, deprecationDiag)
+		if message, _ := deprecationutil.ServerTypeMessage(result.Server.ServerType, result.Server.Location.Name); message != "" {
+			resp.Diagnostics.AddWarning(message, ChangeDeprecatedServerTypeMessage)
+		}
+	}
+
+	resp.Diagnostics.Append(hcloudutil.SettleActions(ctx, &r.client.Action, append([]*hcloud.Action{result.Action}, result.NextActions...)...)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, network := range networks {
+		if err := inlineAttachServerToNetwork(ctx, r.client, result.Server, network); err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
+		}
+	}
+	if createdWithoutPublicNet {
+		if err := powerOnServer(ctx, r.client, result.Server); err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
+		}
+	}
+
+	if err := setBackups(ctx, r.client, result.Server, data.Backups.ValueBool()); err != nil {
+		resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		return
+	}
+
+	if iso := data.ISO.ValueString(); iso != "" {
+		if err := setISO(ctx, r.client, result.Server, iso); err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
+		}
+	}
+
+	if rescue := data.Rescue.ValueString(); rescue != "" {
+		if err := setRescue(ctx, r.client, result.Server, rescue, opts.SSHKeys); err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
 		}
 	}
 
-	if err = c.Action.WaitFor(ctx, res.Action); err != nil {
-		diags = append(diags, hcloudutil.ErrorToDiag(err)...)
+	if data.DeleteProtection.ValueBool() || data.RebuildProtection.ValueBool() {
+		if err := setProtection(ctx, r.client, result.Server, data.DeleteProtection.ValueBool(), data.RebuildProtection.ValueBool()); err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
+		}
+	}
+
+	// Fetch fresh data from the API
+	server, _, err := r.client.Server.GetByID(ctx, result.Server.ID)
+	if err != nil {
+		resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		return
+	}
+	if server == nil {
+		resp.Diagnostics.Append(hcloudutil.NotFoundDiagnostic("server", "id", result.Server.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(r.writeState(ctx, &data, data.Labels, server, &resp.State, resp.Identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(waitForReady(ctx, data.WaitFor, server)...)
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data resourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	server, _, err := r.client.Server.GetByID(ctx, data.ID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		return
+	}
+	if server == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Only the ID is known after an import, the remaining attributes are populated
+	// from the API or set to their defaults.
+	if data.Name.IsNull() {
+		data.KeepDisk = types.BoolValue(false)
+		data.AllowDeprecatedImages = types.BoolValue(false)
+		data.IgnoreRemoteFirewallIDs = types.BoolValue(false)
+		data.ShutdownBeforeDeletion = types.BoolValue(false)
+		data.SnapshotBeforeDeletion = types.BoolValue(false)
+		data.RebuildOnImageChange = types.BoolValue(false)
+		data.ShutdownTimeout = types.StringValue(hcloudutil.DefaultShutdownTimeout.String())
+		data.ShutdownFallback = types.StringValue(string(hcloudutil.ShutdownFallbackPoweroff))
+
+		var newDiags diag.Diagnostics
+		data.PublicNet, newDiags = types.SetValueFrom(ctx,
+			types.ObjectType{AttrTypes: resourcePublicNetModel{}.tfAttributesTypes()},
+			[]resourcePublicNetModel{{
+				IPv4Enabled: types.BoolValue(!server.PublicNet.IPv4.IsUnspecified()),
+				IPv6Enabled: types.BoolValue(server.PublicNet.IPv6.Network != nil),
+				IPv4:        types.Int64Null(),
+				IPv6:        types.Int64Null(),
+			}},
+		)
+		resp.Diagnostics.Append(newDiags...)
+
+		// Unknown networks are populated from the API.
+		data.Network = types.SetUnknown(types.ObjectType{AttrTypes: resourceNetworkModel{}.tfAttributesTypes()})
+	}
+
+	resp.Diagnostics.Append(r.writeState(ctx, &data, data.Labels, server, &resp.State, resp.Identity)...)
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, plan resourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	shutdownOpts, newDiags := plan.shutdownOpts(false)
+	resp.Diagnostics.Append(newDiags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	for _, nextAction := range res.NextActions {
-		if err = c.Action.WaitFor(ctx, nextAction); err != nil {
-			diags = append(diags, hcloudutil.ErrorToDiag(err)...)
+
+	server, _, err := r.client.Server.GetByID(ctx, data.ID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		return
+	}
+	if server == nil {
+		resp.Diagnostics.Append(hcloudutil.NotFoundDiagnostic("server", "id", data.ID.ValueInt64()))
+		return
+	}
+
+	// Update fields on resource
+	{
+		opts := hcloud.ServerUpdateOpts{}
+		changed := false
+
+		if !plan.Name.Equal(data.Name) {
+			opts.Name = plan.Name.ValueString()
+			changed = true
+		}
+
+		if !plan.LabelsAll.IsUnknown() && !plan.LabelsAll.Equal(data.LabelsAll) {
+			labels, newDiags := resourceutil.LabelsForUpdate(ctx, r.labels, plan.LabelsAll, func() (map[string]string, error) {
+				return server.Labels, nil
+			})
+			resp.Diagnostics.Append(newDiags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			opts.Labels = labels
+			changed = true
+		}
+
+		if changed {
+			_, _, err := r.client.Server.Update(ctx, server, opts)
+			if err != nil {
+				resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+				return
+			}
+		}
+	}
+
+	// The wait_for conditions are only checked again when the server restarted.
+	restarted := false
+
+	// Action: Change Type
+	if !plan.ServerType.Equal(data.ServerType) {
+		restarted = true
+		if server.Status == hcloud.ServerStatusRunning {
+			_, newDiags := hcloudutil.StopServer(ctx, r.client, server, shutdownOpts)
+			resp.Diagnostics.Append(newDiags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		action, _, err := r.client.Server.ChangeType(ctx, server, hcloud.ServerChangeTypeOpts{
+			ServerType:  &hcloud.ServerType{Name: plan.ServerType.ValueString()},
+			UpgradeDisk: !plan.KeepDisk.ValueBool(),
+		})
+		if err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
+		}
+		resp.Diagnostics.Append(hcloudutil.SettleActions(ctx, &r.client.Action, action)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
 
-	if nwSet, ok := d.GetOk("network"); ok {
-		for _, item := range nwSet.(*schema.Set).List() {
-			nwData := item.(map[string]any)
-			if err := inlineAttachServerToNetwork(ctx, c, res.Server, nwData); err != nil {
-				diags = append(diags, hcloudutil.ErrorToDiag(err)...)
+	// Action: Rebuild
+	if !plan.Image.Equal(data.Image) {
+		restarted = true
+		image, newDiags := getImage(ctx, r.client, plan.Image.ValueString(), server.ServerType.Architecture)
+		resp.Diagnostics.Append(newDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		opts := hcloud.ServerRebuildOpts{Image: image}
+		if !plan.UserData.IsNull() {
+			encoded, err := encodeUserData(plan.UserData.ValueString(), plan.UserDataEncoding.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("user_data"), "Invalid user data", err.Error())
 				return
 			}
+			opts.UserData = &encoded
+		}
+
+		result, _, err := r.client.Server.RebuildWithResult(ctx, server, opts)
+		if err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
+		}
+		resp.Diagnostics.Append(hcloudutil.SettleActions(ctx, &r.client.Action, result.Action)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		plan.RootPassword = nullIfZero(types.StringValue(result.RootPassword))
+	}
+
+	// Action: Backups
+	if !plan.Backups.IsUnknown() && !plan.Backups.Equal(data.Backups) {
+		if err := setBackups(ctx, r.client, server, plan.Backups.ValueBool()); err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
+		}
+	}
+
+	// Action: ISO
+	if !plan.ISO.Equal(data.ISO) {
+		if err := setISO(ctx, r.client, server, plan.ISO.ValueString()); err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
 		}
-		// if the server was created without public net, the server is now still offline and has to be powered on after
-		// network assignment
-		if err := onServerCreateWithoutPublicNet(&opts, d, func(_ *hcloud.ServerCreateOpts) error {
-			return powerOnServer(ctx, c, res.Server)
-		}); err != nil {
-			diags = append(diags, err...)
+	}
+
+	// Action: Rescue
+	if !plan.Rescue.Equal(data.Rescue) {
+		restarted = true
+		sshKeys, newDiags := r.getSSHKeys(ctx, plan.SSHKeys)
+		resp.Diagnostics.Append(newDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if err := setRescue(ctx, r.client, server, plan.Rescue.ValueString(), sshKeys); err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
 			return
 		}
 	}
 
-	backups := d.Get("backups").(bool)
-	if err := setBackups(ctx, c, res.Server, backups); err != nil {
-		diags = append(diags, hcloudutil.ErrorToDiag(err)...)
+	// Action: Networks, only managed when configured, to not conflict with the
+	// hcloud_server_network resource.
+	if len(plan.Network.Elements()) > 0 && !plan.Network.Equal(data.Network) {
+		var networks []resourceNetworkModel
+		resp.Diagnostics.Append(plan.Network.ElementsAs(ctx, &networks, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if err := updateServerInlineNetworkAttachments(ctx, r.client, networks, server); err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
+		}
+	}
+
+	// Action: Volumes, only managed when configured, to not conflict with the
+	// hcloud_volume_attachment resource.
+	if !plan.VolumeIDs.IsNull() && !plan.VolumeIDs.Equal(data.VolumeIDs) {
+		var volumeIDs []int64
+		resp.Diagnostics.Append(plan.VolumeIDs.ElementsAs(ctx, &volumeIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if err := updateServerInlineVolumeAttachments(ctx, r.client, volumeIDs, plan.Automount.ValueBoolPointer(), server); err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
+		}
+	}
+
+	// Action: Firewalls
+	if !plan.FirewallIDs.IsUnknown() && !plan.FirewallIDs.Equal(data.FirewallIDs) {
+		resp.Diagnostics.Append(r.updateFirewalls(ctx, server, data, plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Action: Public Net
+	if !plan.PublicNet.Equal(data.PublicNet) {
+		restarted = true
+		var oldPublicNets, newPublicNets []resourcePublicNetModel
+		resp.Diagnostics.Append(data.PublicNet.ElementsAs(ctx, &oldPublicNets, false)...)
+		resp.Diagnostics.Append(plan.PublicNet.ElementsAs(ctx, &newPublicNets, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(updatePublicNet(ctx, r.client, server, shutdownOpts, oldPublicNets, newPublicNets)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Action: Placement Group
+	if !plan.PlacementGroupID.Equal(data.PlacementGroupID) {
+		if err := setPlacementGroup(ctx, r.client, server, plan.PlacementGroupID.ValueInt64()); err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
+		}
+	}
+
+	// Action: Protection
+	if !plan.DeleteProtection.Equal(data.DeleteProtection) || !plan.RebuildProtection.Equal(data.RebuildProtection) {
+		if err := setProtection(ctx, r.client, server, plan.DeleteProtection.ValueBool(), plan.RebuildProtection.ValueBool()); err != nil {
+			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return
+		}
+	}
+
+	// Fetch fresh data from the API
+	server, _, err = r.client.Server.GetByID(ctx, server.ID)
+	if err != nil {
+		resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		return
+	}
+	if server == nil {
+		resp.Diagnostics.Append(hcloudutil.NotFoundDiagnostic("server", "id", data.ID.ValueInt64()))
+		return
+	}
+
+	resp.Diagnostics.Append(r.writeState(ctx, &plan, plan.Labels, server, &resp.State, resp.Identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if restarted {
+		resp.Diagnostics.Append(waitForReady(ctx, plan.WaitFor, server)...)
+	}
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data resourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if iso, ok := d.GetOk("iso"); ok {
-		if err := setISO(ctx, c, res.Server, iso.(string)); err != nil {
-			diags = append(diags, hcloudutil.ErrorToDiag(err)...)
+	server := &hcloud.Server{ID: data.ID.ValueInt64()}
+
+	if data.ShutdownBeforeDeletion.ValueBool() || data.SnapshotBeforeDeletion.ValueBool() {
+		shutdownOpts, newDiags := data.shutdownOpts(true)
+		resp.Diagnostics.Append(newDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		stopped, newDiags := hcloudutil.StopServer(ctx, r.client, server, shutdownOpts)
+		resp.Diagnostics.Append(newDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// The delete fallback leaves the server running, a snapshot of a running
+		// server might not be consistent.
+		if !stopped && data.SnapshotBeforeDeletion.ValueBool() {
+			resp.Diagnostics.AddError(
+				"Final snapshot not created",
+				fmt.Sprintf("Server %d is still running, the final snapshot is only created once the server is off.", server.ID),
+			)
 			return
 		}
 	}
 
-	if rescue, ok := d.GetOk("rescue"); ok {
-		if err := setRescue(ctx, c, res.Server, rescue.(string), opts.SSHKeys); err != nil {
-			diags = append(diags, hcloudutil.ErrorToDiag(err)...)
+	if data.SnapshotBeforeDeletion.ValueBool() {
+		resp.Diagnostics.Append(r.createFinalSnapshot(ctx, server, data.FinalSnapshot)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
 
-	deleteProtection := d.Get("delete_protection").(bool)
-	rebuildProtection := d.Get("rebuild_protection").(bool)
-	if deleteProtection || rebuildProtection {
-		if err := setProtection(ctx, c, res.Server, deleteProtection, rebuildProtection); err != nil {
-			diags = append(diags, hcloudutil.ErrorToDiag(err)...)
+	result, _, err := r.client.Server.DeleteWithResult(ctx, server)
+	if err != nil {
+		if hcloudutil.APIErrorIsNotFound(err) {
+			// Server was already deleted
 			return
 		}
+		resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		return
 	}
 
-	diags = append(diags, resourceServerRead(ctx, d, m)...)
+	resp.Diagnostics.Append(hcloudutil.SettleActions(ctx, &r.client.Action, result.Action)...)
+}
+
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity resourceIdentityModel
 
-	return
+	if req.ID != "" {
+		id, err := strconv.ParseInt(req.ID, 10, 64)
+		if err != nil {
+			resp.Diagnostics.Append(util.InvalidImportID("$SERVER_ID", req.ID))
+			return
+		}
+		identity.ID = types.Int64Value(id)
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ID)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
 }
 
-func resourceServerRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+// writeState populates the model with the server and writes it to the state and
+// identity.
+func (r *Resource) writeState(
+	ctx context.Context,
+	data *resourceModel,
+	configuredLabels types.Map,
+	server *hcloud.Server,
+	state interface {
+		Set(context.Context, any) diag.Diagnostics
+	},
+	identity interface {
+		Set(context.Context, any) diag.Diagnostics
+	},
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(data.FromAPI(ctx, server)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, server.Labels, &data.Labels, &data.LabelsAll)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(state.Set(ctx, data)...)
+	diags.Append(identity.Set(ctx, resourceIdentityModel{ID: data.ID})...)
+	return diags
+}
+
+// getServerType returns the server type with the given name or ID.
+func getServerType(ctx context.Context, client *hcloud.Client, serverTypeNameOrID string) (*hcloud.ServerType, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	serverType, _, err := client.ServerType.Get(ctx, serverTypeNameOrID)
+	if err != nil {
+		diags.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		return nil, diags
+	}
+	if serverType == nil {
+		diags.Append(hcloudutil.NotFoundDiagnostic("server type", "name", serverTypeNameOrID))
+		return nil, diags
+	}
+
+	return serverType, diags
+}
+
+// serverTypeDeprecationDiagnostic returns a warning when the server type is deprecated
+// in the location, or an error when it can no longer be ordered. It returns nil when
+// the server type is not deprecated.
+func serverTypeDeprecationDiagnostic(serverType *hcloud.ServerType, locationName string) diag.Diagnostic {
+	message, unavailable := deprecationutil.ServerTypeMessage(serverType, locationName)
+	switch {
+	case message == "":
+		return nil
+	case unavailable:
+		return diag.NewErrorDiagnostic(message, ChangeDeprecatedServerTypeMessage)
+	default:
+		return diag.NewWarningDiagnostic(message, ChangeDeprecatedServerTypeMessage)
+	}
+}
+
+// getImage returns the image matching the architecture of the server type, and warns
+// when the image is deprecated.
+func getImage(ctx context.Context, client *hcloud.Client, imageNameOrID string, architecture hcloud.Architecture) (*hcloud.Image, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	image, _, err := client.Image.GetForArchitecture(ctx, imageNameOrID, architecture)
+	if err != nil {
+		diags.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		return nil, diags
+	}
+	if image == nil {
+		diags.Append(hcloudutil.NotFoundDiagnostic("image", imageNameOrID, "architecture", architecture))
+		return nil, diags
+	}
+
+	if message, unavailable := deprecationutil.ImageMessage(image); message != "" {
+		if unavailable {
+			diags.AddError("Image Unavailable", message+".")
+			return nil, diags
+		}
+		diags.AddWarning("Image Deprecated", message+".")
+	}
+
+	return image, diags
+}
+
+func (r *Resource) getSSHKeys(ctx context.Context, value types.List) ([]*hcloud.SSHKey, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var sshKeyIDOrNames []string
+	diags.Append(value.ElementsAs(ctx, &sshKeyIDOrNames, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	sshKeys := make([]*hcloud.SSHKey, 0, len(sshKeyIDOrNames))
+	for _, sshKeyIDOrName := range sshKeyIDOrNames {
+		sshKey, _, err := r.client.SSHKey.Get(ctx, sshKeyIDOrName)
+		if err != nil {
+			diags.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return nil, diags
+		}
+		if sshKey == nil {
+			diags.Append(hcloudutil.NotFoundDiagnostic("ssh key", sshKeyIDOrName))
+			return nil, diags
+		}
+		sshKeys = append(sshKeys, sshKey)
+	}
+	return sshKeys, diags
+}
+
+// createFinalSnapshot creates a snapshot of the server before its deletion.
+func (r *Resource) createFinalSnapshot(ctx context.Context, server *hcloud.Server, value types.Object) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	opts := &hcloud.ServerCreateImageOpts{Type: hcloud.ImageTypeSnapshot}
+
+	if !value.IsNull() && !value.IsUnknown() {
+		var finalSnapshot resourceFinalSnapshotModel
+		diags.Append(value.As(ctx, &finalSnapshot, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return diags
+		}
+
+		if !finalSnapshot.Description.IsNull() {
+			opts.Description = new(finalSnapshot.Description.ValueString())
+		}
+		diags.Append(hcloudutil.TerraformLabelsToHCloud(ctx, finalSnapshot.Labels, &opts.Labels)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	opts.Labels = r.labels.All(opts.Labels)
+
+	result, _, err := r.client.Server.CreateImage(ctx, server, opts)
+	if err != nil {
+		if hcloudutil.APIErrorIsNotFound(err) {
+			// Server was already deleted
+			return diags
+		}
+		diags.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		return diags
+	}
+
+	diags.Append(hcloudutil.SettleActions(ctx, &r.client.Action, result.Action)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.AddWarning(
+		"Final snapshot created",
+		fmt.Sprintf("Snapshot %d of server %d was created before deleting it.", result.Image.ID, server.ID),
+	)
+	return diags
+}
+
+func (r *Resource) updateFirewalls(ctx context.Context, server *hcloud.Server, data, plan resourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var firewallIDs, currentFirewallIDs []int64
+	diags.Append(plan.FirewallIDs.ElementsAs(ctx, &firewallIDs, false)...)
+
+	if plan.IgnoreRemoteFirewallIDs.ValueBool() {
+		// Only reconcile the firewalls managed by this resource.
+		diags.Append(data.FirewallIDs.ElementsAs(ctx, &currentFirewallIDs, false)...)
+	} else {
+		for _, f := range server.PublicNet.Firewalls {
+			currentFirewallIDs = append(currentFirewallIDs, f.Firewall.ID)
+		}
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	serverResource := []hcloud.FirewallResource{{
+		Type:   hcloud.FirewallResourceTypeServer,
+		Server: &hcloud.FirewallResourceServer{ID: server.ID},
+	}}
+
+	for _, firewallID := range currentFirewallIDs {
+		if slices.Contains(firewallIDs, firewallID) {
+			continue
+		}
+		actions, _, err := r.client.Firewall.RemoveResources(ctx, &hcloud.Firewall{ID: firewallID}, serverResource)
+		if err != nil {
+			diags.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return diags
+		}
+		diags.Append(hcloudutil.SettleActions(ctx, &r.client.Action, actions...)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	for _, firewallID := range firewallIDs {
+		if slices.Contains(currentFirewallIDs, firewallID) {
+			continue
+		}
+		actions, _, err := r.client.Firewall.ApplyResources(ctx, &hcloud.Firewall{ID: firewallID}, serverResource)
+		if err != nil {
+			diags.Append(hcloudutil.APIErrorDiagnostics(err)...)
+			return diags
+		}
+		diags.Append(hcloudutil.SettleActions(ctx, &r.client.Action, actions...)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func (m *resourceModel) createTimeout(ctx context.Context) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if m.Timeouts.IsNull() || m.Timeouts.IsUnknown() {
+		return defaultCreateTimeout, diags
+	}
+
+	var timeouts resourceTimeoutsModel
+	diags.Append(m.Timeouts.As(ctx, &timeouts, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() || timeouts.Create.IsNull() || timeouts.Create.IsUnknown() {
+		return defaultCreateTimeout, diags
+	}
+
+	timeout, err := time.ParseDuration(timeouts.Create.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("timeouts").AtName("create"), "Invalid timeout", err.Error())
+	}
+	return timeout, diags
+}
+
+// shutdownOpts returns how the server is stopped before it is mutated or deleted.
+func (m *resourceModel) shutdownOpts(deleting bool) (hcloudutil.ShutdownOpts, diag.Diagnostics) {
+	opts, diags := resourceutil.ShutdownOptsFrom(m.ShutdownTimeout, m.ShutdownFallback)
+	opts.Deleting = deleting
+	return opts, diags
+}
+
+// sameValue reports whether both values are equal. Null and empty sets are equal, as
+// the framework reads empty set blocks from the state as null.
+func sameValue(a, b attr.Value) bool {
+	if a.Equal(b) {
+		return true
+	}
+	aSet, aOK := a.(types.Set)
+	bSet, bOK := b.(types.Set)
+	return aOK && bOK && !aSet.IsUnknown() && !bSet.IsUnknown() &&
+		len(aSet.Elements()) == 0 && len(bSet.Elements()) == 0
+}
+
+// planUpdates reports whether the plan changes any top level attribute or block of
+// the state, except for the ignored ones.
+func planUpdates(ctx context.Context, plan tfsdk.Plan, state tfsdk.State, ignored []string) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	names := slices.Collect(maps.Keys(plan.Schema.GetAttributes()))
+	names = slices.AppendSeq(names, maps.Keys(plan.Schema.GetBlocks()))
 
-	server, _, err := client.Server.Get(ctx, d.Id())
-	if err != nil {
-		if resourceServerIsNotFound(err, d) {
-			return nil
+	for _, name := range names {
+		if slices.Contains(ignored, name) {
+			continue
+		}
+		var planValue, stateValue attr.Value
+		diags.Append(plan.GetAttribute(ctx, path.Root(name), &planValue)...)
+		diags.Append(state.GetAttribute(ctx, path.Root(name), &stateValue)...)
+		if planValue == nil || stateValue == nil || !sameValue(planValue, stateValue) {
+			return true, diags
 		}
-		return hcloudutil.ErrorToDiag(err)
-	}
-	if server == nil {
-		d.SetId("")
-		return nil
 	}
-	setServerSchema(d, server, false)
-
-	d.SetConnInfo(map[string]string{
-		"type": "ssh",
-		"host": server.PublicNet.IPv4.IP.String(),
-	})
+	return false, diags
+}
 
-	return nil
+// imageRequiresReplace replaces the server when its image changes, unless it must be
+// rebuilt in place.
+func imageRequiresReplace(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var rebuild types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rebuild_on_image_change"), &rebuild)...)
+	resp.RequiresReplace = !rebuild.ValueBool()
 }
 
-func resourceServerUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	c := m.(*hcloud.Client)
+// validateNetworks validates the configured inline networks.
+func validateNetworks(ctx context.Context, config resourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	server, _, err := c.Server.Get(ctx, d.Id())
-	if err != nil {
-		return hcloudutil.ErrorToDiag(err)
-	}
-	if server == nil {
-		d.SetId("")
-		return nil
+	if config.Network.IsUnknown() {
+		return diags
 	}
 
-	d.Partial(true)
-	if d.HasChange("name") {
-		newName := d.Get("name")
-		_, _, err := c.Server.Update(ctx, server, hcloud.ServerUpdateOpts{
-			Name: newName.(string),
-		})
-		if err != nil {
-			if resourceServerIsNotFound(err, d) {
-				return nil
-			}
-			return hcloudutil.ErrorToDiag(err)
-		}
+	var networks []resourceNetworkModel
+	diags.Append(config.Network.ElementsAs(ctx, &networks, false)...)
+	if diags.HasError() {
+		return diags
 	}
-	if d.HasChange("labels") {
-		labels := d.Get("labels")
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
-		}
-		_, _, err := c.Server.Update(ctx, server, hcloud.ServerUpdateOpts{
-			Labels: tmpLabels,
-		})
-		if err != nil {
-			if resourceServerIsNotFound(err, d) {
-				return nil
-			}
-			return hcloudutil.ErrorToDiag(err)
+
+	uniqueNetworkIDs := map[int64]bool{}
+
+	for _, network := range networks {
+		// Validate that at least one of network_id or subnet_id is specified.
+		if network.NetworkID.IsNull() && network.SubnetID.IsNull() {
+			diags.AddAttributeError(path.Root("network"), "Invalid network", "must specify either network_id or subnet_id")
+			return diags
 		}
-	}
-	if d.HasChange("server_type") {
-		serverType := d.Get("server_type").(string)
-		keepDisk := d.Get("keep_disk").(bool)
 
-		if server.Status == hcloud.ServerStatusRunning {
-			action, _, err := c.Server.Poweroff(ctx, server)
+		networkID := network.NetworkID.ValueInt64()
+
+		// When subnet_id is specified, extract network_id and validate IP range
+		if subnetID := network.SubnetID.ValueString(); subnetID != "" {
+			subnetNetwork, subnetIPRange, err := ParseSubnetID(subnetID)
 			if err != nil {
-				return hcloudutil.ErrorToDiag(err)
+				continue
 			}
-			if err = c.Action.WaitFor(ctx, action); err != nil {
-				return hcloudutil.ErrorToDiag(err)
+
+			// If the user specified both network_id and subnet_id, they must match.
+			if networkID != 0 && subnetNetwork.ID != networkID {
+				diags.AddAttributeError(path.Root("network"), "Invalid network",
+					fmt.Sprintf("subnet_id (%s) does not belong to the specified network_id (%d)", subnetID, networkID))
+				return diags
+			}
+
+			networkID = subnetNetwork.ID
+
+			// Check if the server IP is within the subnet IP range
+			if ip := net.ParseIP(network.IP.ValueString()); ip != nil && !subnetIPRange.Contains(ip) {
+				diags.AddAttributeError(path.Root("network"), "Invalid network",
+					fmt.Sprintf("server IP (%s) is outside subnet IP range (%s)", ip.String(), subnetIPRange.String()))
+				return diags
 			}
 		}
 
-		action, _, err := c.Server.ChangeType(ctx, server, hcloud.ServerChangeTypeOpts{
-			ServerType:  &hcloud.ServerType{Name: serverType},
-			UpgradeDisk: !keepDisk,
-		})
-		if err != nil {
-			return hcloudutil.ErrorToDiag(err)
+		if networkID == 0 {
+			// ID is 0 if Network will be created in same apply, we are unable to reliably detect if the
+			// "to-be-created" networks are the same.
+			// See https://github.com/hetznercloud/terraform-provider-hcloud/issues/899
+			continue
 		}
-		if err = c.Action.WaitFor(ctx, action); err != nil {
-			return hcloudutil.ErrorToDiag(err)
+
+		if uniqueNetworkIDs[networkID] {
+			diags.AddAttributeError(path.Root("network"), "Invalid network",
+				fmt.Sprintf("server is only allowed to be attached to each network once: %d", networkID))
+			return diags
 		}
+		uniqueNetworkIDs[networkID] = true
 	}
 
-	if d.HasChange("backups") {
-		backups := d.Get("backups").(bool)
-		if err := setBackups(ctx, c, server, backups); err != nil {
-			return hcloudutil.ErrorToDiag(err)
-		}
+	return diags
+}
+
+// planPublicNet plans the public_net blocks. The configured Primary IPs are kept from
+// the state when the block did not change, and are null otherwise.
+func planPublicNet(ctx context.Context, config, state types.Set) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	elementType := types.ObjectType{AttrTypes: resourcePublicNetModel{}.tfAttributesTypes()}
+	if config.IsUnknown() {
+		return types.SetUnknown(elementType), diags
 	}
 
-	if d.HasChange("iso") {
-		iso := d.Get("iso").(string)
-		if err := setISO(ctx, c, server, iso); err != nil {
-			return hcloudutil.ErrorToDiag(err)
-		}
+	var configured, prior []resourcePublicNetModel
+	diags.Append(config.ElementsAs(ctx, &configured, false)...)
+	if !state.IsNull() && !state.IsUnknown() {
+		diags.Append(state.ElementsAs(ctx, &prior, false)...)
+	}
+	if diags.HasError() {
+		return config, diags
 	}
 
-	if d.HasChange("rescue") {
-		rescue := d.Get("rescue").(string)
-		sshKeys, err := getSSHkeys(ctx, c, d)
-		if err != nil {
-			return hcloudutil.ErrorToDiag(err)
+	elements := make([]resourcePublicNetModel, 0, len(configured))
+	for _, item := range configured {
+		// Apply the defaults
+		if item.IPv4Enabled.IsNull() {
+			item.IPv4Enabled = types.BoolValue(true)
 		}
-		if err := setRescue(ctx, c, server, rescue, sshKeys); err != nil {
-			return hcloudutil.ErrorToDiag(err)
+		if item.IPv6Enabled.IsNull() {
+			item.IPv6Enabled = types.BoolValue(true)
 		}
-	}
 
-	if d.HasChange("network") {
-		data := d.Get("network").(*schema.Set)
-		if err := updateServerInlineNetworkAttachments(ctx, c, data, server); err != nil {
-			return hcloudutil.ErrorToDiag(err)
+		match := slices.IndexFunc(prior, func(p resourcePublicNetModel) bool {
+			return item.IPv4Enabled.Equal(p.IPv4Enabled) && item.IPv6Enabled.Equal(p.IPv6Enabled) &&
+				(item.IPv4.IsNull() || item.IPv4.Equal(p.IPv4)) &&
+				(item.IPv6.IsNull() || item.IPv6.Equal(p.IPv6))
+		})
+		if match >= 0 {
+			item = prior[match]
+			prior = slices.Delete(prior, match, match+1)
 		}
-	}
-
-	if d.HasChange("firewall_ids") {
-		firewallIDs := d.Get("firewall_ids").(*schema.Set).List()
-		for _, f := range server.PublicNet.Firewalls {
-			found := false
-			for _, i := range firewallIDs {
-				fID := util.CastInt64(i)
-				if f.Firewall.ID == fID {
-					found = true
-
-					break
-				}
-			}
 
-			if !found {
-				actions, _, err := c.Firewall.RemoveResources(ctx,
-					&f.Firewall,
-					[]hcloud.FirewallResource{
-						{
-							Type:   hcloud.FirewallResourceTypeServer,
-							Server: &hcloud.FirewallResourceServer{ID: server.ID},
-						},
-					},
-				)
-				if err != nil {
-					return hcloudutil.ErrorToDiag(err)
-				}
-				err = c.Action.WaitFor(ctx, actions...)
-				if err != nil {
-					return hcloudutil.ErrorToDiag(err)
-				}
-			}
-		}
+		elements = append(elements, item)
+	}
 
-		for _, i := range firewallIDs {
-			fID := util.CastInt64(i)
-			found := false
-			for _, f := range server.PublicNet.Firewalls {
-				if f.Firewall.ID == fID {
-					found = true
+	result, newDiags := types.SetValueFrom(ctx, elementType, elements)
+	diags.Append(newDiags...)
+	return result, diags
+}
 
-					break
-				}
-			}
+// planNetworks plans the network blocks. The computed values are kept from the state
+// when the block did not change, and are unknown otherwise.
+func planNetworks(ctx context.Context, config, state types.Set) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
 
-			if !found {
-				actions, _, err := c.Firewall.ApplyResources(ctx,
-					&hcloud.Firewall{ID: fID},
-					[]hcloud.FirewallResource{
-						{
-							Type:   hcloud.FirewallResourceTypeServer,
-							Server: &hcloud.FirewallResourceServer{ID: server.ID},
-						},
-					},
-				)
-				if err != nil {
-					return hcloudutil.ErrorToDiag(err)
-				}
-				err = c.Action.WaitFor(ctx, actions...)
-				if err != nil {
-					return hcloudutil.ErrorToDiag(err)
-				}
-			}
-		}
+	elementType := types.ObjectType{AttrTypes: resourceNetworkModel{}.tfAttributesTypes()}
+	if config.IsUnknown() {
+		return types.SetUnknown(elementType), diags
 	}
 
-	if d.HasChange("public_net") {
-		o, n := d.GetChange("public_net")
-		if err := updatePublicNet(ctx, o, n, c, server); err != nil {
-			return err
-		}
+	var configured, prior []resourceNetworkModel
+	diags.Append(config.ElementsAs(ctx, &configured, false)...)
+	if !state.IsNull() && !state.IsUnknown() {
+		diags.Append(state.ElementsAs(ctx, &prior, false)...)
 	}
-
-	if d.HasChange("placement_group_id") {
-		placementGroupID := util.CastInt64(d.Get("placement_group_id"))
-		if err := setPlacementGroup(ctx, c, server, placementGroupID); err != nil {
-			return hcloudutil.ErrorToDiag(err)
-		}
+	if diags.HasError() {
+		return config, diags
 	}
 
-	if d.HasChange("delete_protection") || d.HasChange("rebuild_protection") {
-		deletionProtection := d.Get("delete_protection").(bool)
-		rebuild := d.Get("rebuild_protection").(bool)
-		if err := setProtection(ctx, c, server, deletionProtection, rebuild); err != nil {
-			return hcloudutil.ErrorToDiag(err)
+	elements := make([]resourceNetworkModel, 0, len(configured))
+	for _, item := range configured {
+		match := slices.IndexFunc(prior, func(p resourceNetworkModel) bool {
+			return (item.NetworkID.IsNull() || item.NetworkID.Equal(p.NetworkID)) &&
+				(item.SubnetID.IsNull() || item.SubnetID.Equal(p.SubnetID)) &&
+				(item.IP.IsNull() || item.IP.Equal(p.IP)) &&
+				(item.AliasIPs.IsNull() || item.AliasIPs.Equal(p.AliasIPs))
+		})
+		if match >= 0 {
+			item = prior[match]
+			prior = slices.Delete(prior, match, match+1)
+		} else {
+			if item.NetworkID.IsNull() {
+				item.NetworkID = types.Int64Unknown()
+			}
+			if item.SubnetID.IsNull() {
+				item.SubnetID = types.StringUnknown()
+			}
+			if item.IP.IsNull() {
+				item.IP = types.StringUnknown()
+			}
+			if item.AliasIPs.IsNull() {
+				item.AliasIPs = types.SetUnknown(types.StringType)
+			}
+			item.MACAddress = types.StringUnknown()
 		}
+		elements = append(elements, item)
 	}
 
-	d.Partial(false)
-	return resourceServerRead(ctx, d, m)
+	result, newDiags := types.SetValueFrom(ctx, elementType, elements)
+	diags.Append(newDiags...)
+	return result, diags
 }
 
-func updatePublicNet(ctx context.Context, o any, n any, c *hcloud.Client, server *hcloud.Server) diag.Diagnostics {
-	diffToRemove := o.(*schema.Set).Difference(n.(*schema.Set))
-	diffToAdd := n.(*schema.Set).Difference(o.(*schema.Set))
+func updatePublicNet(ctx context.Context, c *hcloud.Client, server *hcloud.Server, shutdownOpts hcloudutil.ShutdownOpts, o, n []resourcePublicNetModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diffToRemove := slices.DeleteFunc(slices.Clone(o), func(item resourcePublicNetModel) bool {
+		return slices.ContainsFunc(n, item.equal)
+	})
+	diffToAdd := slices.DeleteFunc(slices.Clone(n), func(item resourcePublicNetModel) bool {
+		return slices.ContainsFunc(o, item.equal)
+	})
 
 	var ipv4IDToRemove int64
 	var ipv6IDToRemove int64
 	ipv4EnabledInRemoveDiff := true
 	ipv6EnabledInRemoveDiff := true
 	// collect ip IDs which got removed
-	for _, d := range diffToRemove.List() {
-		fields := d.(map[string]any)
-		ipv4IDToRemove, ipv6IDToRemove = collectPrimaryIPIDs(fields)
-		if ipv4Enabled, err := ToPublicNetField[bool](fields, "ipv4_enabled"); err == nil {
-			ipv4EnabledInRemoveDiff = ipv4Enabled
-		}
-		if ipv6Enabled, err := ToPublicNetField[bool](fields, "ipv6_enabled"); err == nil {
-			ipv6EnabledInRemoveDiff = ipv6Enabled
-		}
+	for _, item := range diffToRemove {
+		ipv4IDToRemove, ipv6IDToRemove = item.IPv4.ValueInt64(), item.IPv6.ValueInt64()
+		ipv4EnabledInRemoveDiff = item.IPv4Enabled.ValueBool()
+		ipv6EnabledInRemoveDiff = item.IPv6Enabled.ValueBool()
 	}
 
-	poweroffAction, _, err := c.Server.Poweroff(ctx, &hcloud.Server{ID: server.ID})
-	if err != nil {
-		return hcloudutil.ErrorToDiag(err)
+	// Removing the block only unassigns the configured Primary IPs, there is nothing
+	// to do for a server without any.
+	if len(diffToAdd) == 0 && ipv4IDToRemove == 0 && ipv6IDToRemove == 0 {
+		return diags
 	}
 
-	if err = c.Action.WaitFor(ctx, poweroffAction); err != nil {
-		return hcloudutil.ErrorToDiag(err)
+	_, newDiags := hcloudutil.StopServer(ctx, c, &hcloud.Server{ID: server.ID}, shutdownOpts)
+	diags.Append(newDiags...)
+	if diags.HasError() {
+		return diags
 	}
 
 	// This block handles the case where the full `public_net` block was removed.
 	// In this case, we want to unassign any primary IPs that were explicitly assigned to the server previously,
 	// and generate new random primary ips to replace them.
-	if diffToAdd.Len() == 0 {
-		publicNetBlockHadExplicitIPv4IDConfigured := ipv4IDToRemove != 0
-		if server.PublicNet.IPv4.ID != 0 && publicNetBlockHadExplicitIPv4IDConfigured {
-			if server.PublicNet.IPv4.ID != ipv4IDToRemove {
-				return diag.Errorf("Assigned IPv4 changed between plan and apply, please check and generate a new plan")
-			}
-
-			if err := primaryip.UnassignPrimaryIP(ctx, c, server.PublicNet.IPv4.ID); err != nil {
-				return err
-			}
-			if err := primaryip.CreateRandomPrimaryIP(ctx, c, server, hcloud.PrimaryIPTypeIPv4); err != nil {
-				return err
+	if len(diffToAdd) == 0 {
+		for _, ip := range []struct {
+			serverIPID int64
+			idToRemove int64
+			ipType     hcloud.PrimaryIPType
+		}{
+			{server.PublicNet.IPv4.ID, ipv4IDToRemove, hcloud.PrimaryIPTypeIPv4},
+			{server.PublicNet.IPv6.ID, ipv6IDToRemove, hcloud.PrimaryIPTypeIPv6},
+		} {
+			// Only replace the primary ip if the public_net block had an explicit ID configured.
+			if ip.serverIPID == 0 || ip.idToRemove == 0 {
+				continue
 			}
-		}
-
-		publicNetBlockHadExplicitIPv6IDConfigured := ipv6IDToRemove != 0
-		if server.PublicNet.IPv6.ID != 0 && publicNetBlockHadExplicitIPv6IDConfigured {
-			if server.PublicNet.IPv6.ID != ipv6IDToRemove {
-				return diag.Errorf("Assigned IPv6 changed between plan and apply, please check and generate a new plan")
+			if ip.serverIPID != ip.idToRemove {
+				diags.AddError(
+					"Primary IP changed",
+					fmt.Sprintf("Assigned %s changed between plan and apply, please check and generate a new plan", ipTypeName(ip.ipType)),
+				)
+				return diags
 			}
 
-			if err := primaryip.UnassignPrimaryIP(ctx, c, server.PublicNet.IPv6.ID); err != nil {
-				return err
+			diags.Append(primaryip.UnassignPrimaryIP(ctx, c, ip.serverIPID)...)
+			if diags.HasError() {
+				return diags
 			}
-			if err := primaryip.CreateRandomPrimaryIP(ctx, c, server, hcloud.PrimaryIPTypeIPv6); err != nil {
-				return err
+			diags.Append(primaryip.CreateRandomPrimaryIP(ctx, c, server, ip.ipType)...)
+			if diags.HasError() {
+				return diags
 			}
 		}
 	}
 
 	// Check ip bool together with IDs to trigger the right actions
-	for _, d := range diffToAdd.List() {
-		fields := d.(map[string]any)
-		ipv4IDToAdd, ipv6IDToAdd := collectPrimaryIPIDs(fields)
-
-		if ipv4Enabled, err := ToPublicNetField[bool](fields, "ipv4_enabled"); err == nil {
-			if err := publicNetUpdateDecision(ctx,
-				c,
-				ipv4Enabled,
-				ipv4EnabledInRemoveDiff,
-				ipv4IDToAdd,
-				ipv4IDToRemove,
-				server,
-				server.PublicNet.IPv4.ID,
-				hcloud.PrimaryIPTypeIPv4); err != nil {
-				return err
-			}
-		}
-		if ipv6Enabled, err := ToPublicNetField[bool](fields, "ipv6_enabled"); err == nil {
-			if err := publicNetUpdateDecision(ctx,
-				c, ipv6Enabled,
-				ipv6EnabledInRemoveDiff,
-				ipv6IDToAdd,
-				ipv6IDToRemove,
-				server,
-				server.PublicNet.IPv6.ID,
-				hcloud.PrimaryIPTypeIPv6); err != nil {
-				return err
-			}
+	for _, item := range diffToAdd {
+		diags.Append(publicNetUpdateDecision(ctx, c,
+			item.IPv4Enabled.ValueBool(),
+			ipv4EnabledInRemoveDiff,
+			item.IPv4.ValueInt64(),
+			ipv4IDToRemove,
+			server,
+			server.PublicNet.IPv4.ID,
+			hcloud.PrimaryIPTypeIPv4,
+		)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		diags.Append(publicNetUpdateDecision(ctx, c,
+			item.IPv6Enabled.ValueBool(),
+			ipv6EnabledInRemoveDiff,
+			item.IPv6.ValueInt64(),
+			ipv6IDToRemove,
+			server,
+			server.PublicNet.IPv6.ID,
+			hcloud.PrimaryIPTypeIPv6,
+		)...)
+		if diags.HasError() {
+			return diags
 		}
 	}
 
 	if err := powerOnServer(ctx, c, server); err != nil {
-		return hcloudutil.ErrorToDiag(err)
+		diags.Append(hcloudutil.APIErrorDiagnostics(err)...)
 	}
 
-	return nil
+	return diags
 }
 
 func publicNetUpdateDecision(ctx context.Context,
@@ -850,44 +1717,49 @@ func publicNetUpdateDecision(ctx context.Context,
 	server *hcloud.Server,
 	serverIPID int64,
 	ipType hcloud.PrimaryIPType) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// powerOnOnError makes sure the server is powered on again, when an
+	// operation failed.
+	powerOnOnError := func(newDiags diag.Diagnostics) bool {
+		diags.Append(newDiags...)
+		if !diags.HasError() {
+			return false
+		}
+		if err := powerOnServer(ctx, c, server); err != nil {
+			diags.Append(hcloudutil.APIErrorDiagnostics(err)...)
+		}
+		return true
+	}
+
 	switch {
 	// if ip set true + ip id, remove all previous assigned ipv4 + assign new
 	case ipEnabled && ipID != 0:
 		if serverIPID != 0 {
 			// if primary ip is managed + unassigned before, this might throw an error
-			if err := primaryip.UnassignPrimaryIP(ctx, c, serverIPID); err != nil {
-				return err
+			diags.Append(primaryip.UnassignPrimaryIP(ctx, c, serverIPID)...)
+			if diags.HasError() {
+				return diags
 			}
 			if ipIDInRemoveDiff == 0 {
-				if err := primaryip.DeletePrimaryIP(ctx, c, &hcloud.PrimaryIP{ID: serverIPID}); err != nil {
-					if err := powerOnServer(ctx, c, server); err != nil {
-						return hcloudutil.ErrorToDiag(err)
-					}
-					return err
+				if powerOnOnError(primaryip.DeletePrimaryIP(ctx, c, &hcloud.PrimaryIP{ID: serverIPID})) {
+					return diags
 				}
 			}
 		}
-		if err := primaryip.AssignPrimaryIP(ctx, c, ipID, server.ID, "server"); err != nil {
-			if err := powerOnServer(ctx, c, server); err != nil {
-				return hcloudutil.ErrorToDiag(err)
-			}
-			return err
+		if powerOnOnError(primaryip.AssignPrimaryIP(ctx, c, ipID, server.ID, "server")) {
+			return diags
 		}
+
 	// if ip set from true -> false + no ip id, unassign + delete PrimaryIP
 	case !ipEnabled && ipID == 0:
 		if serverIPID != 0 {
-			if err := primaryip.UnassignPrimaryIP(ctx, c, serverIPID); err != nil {
-				if err := powerOnServer(ctx, c, server); err != nil {
-					return hcloudutil.ErrorToDiag(err)
-				}
-				return err
+			if powerOnOnError(primaryip.UnassignPrimaryIP(ctx, c, serverIPID)) {
+				return diags
 			}
 			if ipIDInRemoveDiff == 0 {
-				if err := primaryip.DeletePrimaryIP(ctx, c, &hcloud.PrimaryIP{ID: serverIPID}); err != nil {
-					if err := powerOnServer(ctx, c, server); err != nil {
-						return hcloudutil.ErrorToDiag(err)
-					}
-					return err
+				if powerOnOnError(primaryip.DeletePrimaryIP(ctx, c, &hcloud.PrimaryIP{ID: serverIPID})) {
+					return diags
 				}
 			}
 		}
@@ -896,102 +1768,34 @@ func publicNetUpdateDecision(ctx context.Context,
 	case ipEnabled && ipID == 0:
 		// unassign managed ip when id is removed
 		if ipEnabledInRemoveDiff && ipIDInRemoveDiff != 0 {
-			if err := primaryip.UnassignPrimaryIP(ctx, c, ipIDInRemoveDiff); err != nil {
-				if err := powerOnServer(ctx, c, server); err != nil {
-					return hcloudutil.ErrorToDiag(err)
-				}
-				return err
+			if powerOnOnError(primaryip.UnassignPrimaryIP(ctx, c, ipIDInRemoveDiff)) {
+				return diags
 			}
 		}
 		if !ipEnabledInRemoveDiff && ipIDInRemoveDiff == 0 ||
 			ipEnabledInRemoveDiff && ipIDInRemoveDiff != 0 {
-			if err := primaryip.CreateRandomPrimaryIP(ctx, c, server, ipType); err != nil {
-				if err := powerOnServer(ctx, c, server); err != nil {
-					return hcloudutil.ErrorToDiag(err)
-				}
-				return err
+			if powerOnOnError(primaryip.CreateRandomPrimaryIP(ctx, c, server, ipType)) {
+				return diags
 			}
 		}
 
 	// error on ip set from true -> false + ipv4 ID provided
 	case !ipEnabled && ipID != 0:
-		if err := powerOnServer(ctx, c, server); err != nil {
-			return hcloudutil.ErrorToDiag(err)
-		}
-		return hcloudutil.ErrorToDiag(
-			fmt.Errorf("this operation is not allowed: ipv4_enabled = false | ipv4 = %d", ipID))
-	}
-	return nil
-}
-
-func resourceServerDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
-
-	serverID, err := util.ParseID(d.Id())
-	if err != nil {
-		log.Printf("[WARN] invalid server id (%s), removing from state: %v", d.Id(), err)
-		d.SetId("")
-		return nil
-	}
-
-	var warnings diag.Diagnostics
-
-	if d.Get("shutdown_before_deletion").(bool) {
-		// Try shutting down the server
-		shutdownAction, _, err := client.Server.Shutdown(ctx, &hcloud.Server{ID: serverID})
-		if err != nil {
-			return hcloudutil.ErrorToDiag(err)
-		}
-
-		if err = client.Action.WaitFor(ctx, shutdownAction); err != nil {
-			return hcloudutil.ErrorToDiag(err)
-		}
-
-		// Give the server some time to shut down
-		err = control.Retry(control.DefaultRetries, func() error {
-			server, _, err := client.Server.GetByID(ctx, serverID)
-
-			// If it is not possible to get the server status, it is probably futile to retry
-			if err != nil {
-				return control.AbortRetry(err)
-			}
-
-			if server.Status != hcloud.ServerStatusOff {
-				return fmt.Errorf("server has not shut down yet")
-			}
-
-			// Server has shut down successfully
-			return nil
-		})
-		if err != nil {
-			// If shutting down does not work, add a warning and move on with deletion
-			warnings = append(warnings, diag.Diagnostic{
-				Severity: diag.Warning,
-				Summary:  fmt.Sprintf("Server id %d did not finish shutting down gracefully in time, deleting it anyways.", serverID),
-			})
-		}
-	}
-
-	result, _, err := client.Server.DeleteWithResult(ctx, &hcloud.Server{ID: serverID})
-	if err != nil {
-		return hcloudutil.ErrorToDiag(err)
-	}
-
-	err = client.Action.WaitFor(ctx, result.Action)
-	if err != nil {
-		return hcloudutil.ErrorToDiag(err)
-	}
-
-	return warnings
+		var newDiags diag.Diagnostics
+		newDiags.AddError(
+			"Invalid public_net configuration",
+			fmt.Sprintf("this operation is not allowed: %s_enabled = false | %s = %d", ipType, ipType, ipID),
+		)
+		powerOnOnError(newDiags)
+	}
+	return diags
 }
 
-func resourceServerIsNotFound(err error, d *schema.ResourceData) bool {
-	if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
-		log.Printf("[WARN] Server (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return true
+func ipTypeName(ipType hcloud.PrimaryIPType) string {
+	if ipType == hcloud.PrimaryIPTypeIPv6 {
+		return "IPv6"
 	}
-	return false
+	return "IPv4"
 }
 
 func setBackups(ctx context.Context, c *hcloud.Client, server *hcloud.Server, backups bool) error {
@@ -1001,7 +1805,7 @@ func setBackups(ctx context.Context, c *hcloud.Client, server *hcloud.Server, ba
 			return err
 		}
 
-		return c.Action.WaitFor(ctx, action)
+		return hcloudutil.WaitForActions(ctx, &c.Action, action)
 	}
 
 	if server.BackupWindow == "" && backups {
@@ -1009,7 +1813,7 @@ func setBackups(ctx context.Context, c *hcloud.Client, server *hcloud.Server, ba
 		if err != nil {
 			return err
 		}
-		return c.Action.WaitFor(ctx, action)
+		return hcloudutil.WaitForActions(ctx, &c.Action, action)
 	}
 
 	return nil
@@ -1023,7 +1827,7 @@ func setISO(ctx context.Context, c *hcloud.Client, server *hcloud.Server, isoIDO
 		if err != nil {
 			return err
 		}
-		if err = c.Action.WaitFor(ctx, action); err != nil {
+		if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 			return err
 		}
 	}
@@ -1049,7 +1853,7 @@ func setISO(ctx context.Context, c *hcloud.Client, server *hcloud.Server, isoIDO
 		if err != nil {
 			return err
 		}
-		if err = c.Action.WaitFor(ctx, action); err != nil {
+		if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 			return err
 		}
 	}
@@ -1059,7 +1863,7 @@ func setISO(ctx context.Context, c *hcloud.Client, server *hcloud.Server, isoIDO
 		if err != nil {
 			return err
 		}
-		if err = c.Action.WaitFor(ctx, action); err != nil {
+		if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 			return err
 		}
 	}
@@ -1076,13 +1880,13 @@ func setRescue(ctx context.Context, c *hcloud.Client, server *hcloud.Server, res
 		if err != nil {
 			return fmt.Errorf("%s: %w", op, err)
 		}
-		if err = c.Action.WaitFor(ctx, action); err != nil {
+		if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 			return fmt.Errorf("%s: %w", op, err)
 		}
 	}
 	if rescue != "" {
 		rescueChanged = true
-		err := control.Retry(control.DefaultRetries, func() error {
+		err := control.Retry(ctx, control.DefaultRetries, func() error {
 			res, _, err := c.Server.EnableRescue(ctx, server, hcloud.ServerEnableRescueOpts{
 				Type:    hcloud.ServerRescueType(rescue),
 				SSHKeys: sshKeys,
@@ -1090,19 +1894,19 @@ func setRescue(ctx context.Context, c *hcloud.Client, server *hcloud.Server, res
 			if err != nil {
 				return err
 			}
-			return c.Action.WaitFor(ctx, res.Action)
+			return hcloudutil.WaitForActions(ctx, &c.Action, res.Action)
 		})
 		if err != nil {
 			return fmt.Errorf("%s: %w", op, err)
 		}
 	}
 	if rescueChanged {
-		err := control.Retry(control.DefaultRetries*2, func() error {
+		err := control.Retry(ctx, control.DefaultRetries*2, func() error {
 			action, _, err := c.Server.Reset(ctx, server)
 			if err != nil {
 				return fmt.Errorf("%s: %w", op, err)
 			}
-			return c.Action.WaitFor(ctx, action)
+			return hcloudutil.WaitForActions(ctx, &c.Action, action)
 		})
 		if err != nil {
 			return fmt.Errorf("%s: %w", op, err)
@@ -1111,81 +1915,48 @@ func setRescue(ctx context.Context, c *hcloud.Client, server *hcloud.Server, res
 	return nil
 }
 
-func getSSHkeys(ctx context.Context, client *hcloud.Client, d *schema.ResourceData) (sshKeys []*hcloud.SSHKey, err error) {
-	for _, sshKeyValue := range d.Get("ssh_keys").([]any) {
-		sshKeyIDOrName := sshKeyValue.(string)
-		var sshKey *hcloud.SSHKey
-		sshKey, _, err = client.SSHKey.Get(ctx, sshKeyIDOrName)
-		if err != nil {
-			return
-		}
-		if sshKey == nil {
-			err = fmt.Errorf("SSH key not found: %s", sshKeyIDOrName)
-			return
-		}
-		sshKeys = append(sshKeys, sshKey)
-	}
-	return
-}
-
-func inlineAttachServerToNetwork(ctx context.Context, c *hcloud.Client, s *hcloud.Server, nwData map[string]any) error {
+func inlineAttachServerToNetwork(ctx context.Context, c *hcloud.Client, s *hcloud.Server, network resourceNetworkModel) error {
 	const op = "hcloud/inlineAttachServerToNetwork"
 
 	// Extract network from network_id or subnet_id
 	var nw *hcloud.Network
 	var ipRange *net.IPNet
 
-	if subnetID, ok := nwData["subnet_id"].(string); ok && subnetID != "" {
+	if subnetID := network.SubnetID.ValueString(); subnetID != "" {
 		var err error
 		nw, ipRange, err = ParseSubnetID(subnetID)
 		if err != nil {
 			return fmt.Errorf("%s: %w", op, err)
 		}
 	} else {
-		networkID := util.CastInt64(nwData["network_id"])
+		networkID := network.NetworkID.ValueInt64()
 		if networkID == 0 {
 			return fmt.Errorf("%s: either subnet_id or network_id must be set", op)
 		}
 		nw = &hcloud.Network{ID: networkID}
 	}
 
-	ip := net.ParseIP(nwData["ip"].(string))
+	ip := net.ParseIP(network.IP.ValueString())
 
-	aliasIPs := make([]net.IP, 0, nwData["alias_ips"].(*schema.Set).Len())
-	for _, v := range nwData["alias_ips"].(*schema.Set).List() {
-		aliasIP := net.ParseIP(v.(string))
-		aliasIPs = append(aliasIPs, aliasIP)
-	}
-	if err := attachServerToNetwork(ctx, c, s, nw, ip, aliasIPs, ipRange); err != nil {
+	if err := attachServerToNetwork(ctx, c, s, nw, ip, network.aliasIPs(), ipRange); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
 }
 
-func updateServerInlineNetworkAttachments(ctx context.Context, c *hcloud.Client, data *schema.Set, s *hcloud.Server) error {
+func updateServerInlineNetworkAttachments(ctx context.Context, c *hcloud.Client, networks []resourceNetworkModel, s *hcloud.Server) error {
 	const op = "hcloud/updateServerInlineNetworkAttachments"
 
-	log.Printf("[INFO] Updating inline network attachments for server %d", s.ID)
-
-	cfgNetworks := make(map[int64]map[string]any, data.Len())
-	for _, v := range data.List() {
-		nwData := v.(map[string]any)
-
-		var nwID int64
-		if subnetIDStr, ok := nwData["subnet_id"].(string); ok && subnetIDStr != "" {
-			if subnetNetwork, _, err := ParseSubnetID(subnetIDStr); err == nil {
-				nwID = subnetNetwork.ID
-			}
-		} else {
-			nwID = util.CastInt64(nwData["network_id"])
-		}
+	tflog.Info(ctx, "Updating inline network attachments", map[string]any{"server_id": s.ID})
 
-		cfgNetworks[nwID] = nwData
+	cfgNetworks := make(map[int64]resourceNetworkModel, len(networks))
+	for _, network := range networks {
+		cfgNetworks[network.networkID()] = network
 	}
 
 	for _, n := range s.PrivateNet {
-		nwData, ok := cfgNetworks[n.Network.ID]
+		network, ok := cfgNetworks[n.Network.ID]
 		if !ok {
 			// The server should no longer be a member of this network.
 			// Detach it.
@@ -1198,21 +1969,30 @@ func updateServerInlineNetworkAttachments(ctx context.Context, c *hcloud.Client,
 		// handle it right now.
 		delete(cfgNetworks, n.Network.ID)
 
-		if nwData["ip"].(string) != n.IP.String() {
+		ipChanged := !network.IP.IsUnknown() && network.IP.ValueString() != n.IP.String()
+		if subnetID := network.SubnetID.ValueString(); subnetID != "" {
+			if _, ipRange, err := ParseSubnetID(subnetID); err == nil && !ipRange.Contains(n.IP) {
+				ipChanged = true
+			}
+		}
+		if ipChanged {
 			// IP changed. Our API provides now way to change this. So we
 			// need to detach and re-attach. Alias IPs are updated, too. This
 			// saves us from the next step.
 			if err := detachServerFromNetwork(ctx, c, s, n.Network); err != nil {
 				return fmt.Errorf("%s: %w", op, err)
 			}
-			if err := inlineAttachServerToNetwork(ctx, c, s, nwData); err != nil {
+			if err := inlineAttachServerToNetwork(ctx, c, s, network); err != nil {
 				return fmt.Errorf("%s: %w", op, err)
 			}
 			continue
 		}
-		cfgAliasIPs := nwData["alias_ips"].(*schema.Set)
-		curAliasIPs := newIPSet(cfgAliasIPs.F, n.Aliases)
-		if !cfgAliasIPs.Equal(curAliasIPs) {
+
+		if network.AliasIPs.IsUnknown() {
+			continue
+		}
+		cfgAliasIPs := network.aliasIPs()
+		if !sameIPs(cfgAliasIPs, n.Aliases) {
 			if err := updateServerAliasIPs(ctx, c, s, n.Network, cfgAliasIPs); err != nil {
 				return fmt.Errorf("%s: %w", op, err)
 			}
@@ -1222,8 +2002,8 @@ func updateServerInlineNetworkAttachments(ctx context.Context, c *hcloud.Client,
 
 	// Whatever remains in cfgNetworks now is a newly added network. We attach
 	// the server to it.
-	for _, nwData := range cfgNetworks {
-		if err := inlineAttachServerToNetwork(ctx, c, s, nwData); err != nil {
+	for _, network := range cfgNetworks {
+		if err := inlineAttachServerToNetwork(ctx, c, s, network); err != nil {
 			return fmt.Errorf("%s: %w", op, err)
 		}
 	}
@@ -1231,145 +2011,50 @@ func updateServerInlineNetworkAttachments(ctx context.Context, c *hcloud.Client,
 	return nil
 }
 
-func newIPSet(f schema.SchemaSetFunc, ips []net.IP) *schema.Set {
-	ss := make([]any, len(ips))
-	for i, ip := range ips {
-		ss[i] = ip.String()
-	}
-	return schema.NewSet(f, ss)
-}
-
-func setServerSchema(d *schema.ResourceData, s *hcloud.Server, forceSetNetworkAttribute bool) {
-	util.SetSchemaFromAttributes(d, getServerAttributes(d, s, forceSetNetworkAttribute))
-}
+func updateServerInlineVolumeAttachments(ctx context.Context, c *hcloud.Client, volumeIDs []int64, automount *bool, s *hcloud.Server) error {
+	const op = "hcloud/updateServerInlineVolumeAttachments"
 
-func getServerAttributes(d *schema.ResourceData, s *hcloud.Server, forceSetNetworkAttribute bool) map[string]any {
-	firewallIDs := make([]int, len(s.PublicNet.Firewalls))
-	for i, firewall := range s.PublicNet.Firewalls {
-		firewallIDs[i] = util.CastInt(firewall.Firewall.ID)
-	}
-
-	res := map[string]any{
-		"id":                 s.ID,
-		"name":               s.Name,
-		"location":           s.Location.Name,
-		"status":             s.Status,
-		"server_type":        s.ServerType.Name,
-		"ipv6_network":       s.PublicNet.IPv6.Network.String(),
-		"backup_window":      s.BackupWindow,
-		"backups":            s.BackupWindow != "",
-		"labels":             s.Labels,
-		"delete_protection":  s.Protection.Delete,
-		"rebuild_protection": s.Protection.Rebuild,
-		"firewall_ids":       firewallIDs,
-		"primary_disk_size":  s.PrimaryDiskSize,
-	}
-	if s.PublicNet.IPv4.IsUnspecified() {
-		res["ipv4_address"] = nil
-	} else {
-		res["ipv4_address"] = s.PublicNet.IPv4.IP.String()
-	}
+	tflog.Info(ctx, "Updating inline volume attachments", map[string]any{"server_id": s.ID})
 
-	if len(s.PublicNet.IPv6.IP) == 0 {
-		// No IPv6 Primary IP assigned
-		res["ipv6_address"] = nil
-	} else {
-		// Set first IP in assigned subnet range
-		res["ipv6_address"] = s.PublicNet.IPv6.IP.String() + "1"
+	cfgVolumeIDs := make(map[int64]bool, len(volumeIDs))
+	for _, volumeID := range volumeIDs {
+		cfgVolumeIDs[volumeID] = true
 	}
 
-	if s.Image != nil {
-		if s.Image.Name != "" && util.FormatID(s.Image.ID) != d.Get("image") {
-			// Only use the image name if the image is official (Name != "")
-			// AND the user did not explicitly specify the image id
-			res["image"] = s.Image.Name
-		} else {
-			res["image"] = fmt.Sprintf("%d", s.Image.ID)
+	for _, v := range s.Volumes {
+		if !cfgVolumeIDs[v.ID] {
+			// The volume should no longer be attached to the server. Detach it.
+			if err := detachVolumeFromServer(ctx, c, v); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+			continue
 		}
+		// Remove the volume from the cfgVolumeIDs map, it is already attached.
+		delete(cfgVolumeIDs, v.ID)
 	}
 
-	// Only write the networks to the resource data if it already contains
-	// such an entry. This avoids setting the "network" property which is not
-	// marked as "computed" if the user uses the "server_network_subnet"
-	// resource. Setting the "network" property as computed is not possible
-	// because this would lead to loosing any updates.
-	//
-	// The easiest would be to use schema.ComputedWhen but this is marked
-	// as currently not working.
-	_, ok := d.GetOk("network")
-	if ok || forceSetNetworkAttribute {
-		res["network"] = networkToTerraformNetworks(d, s.PrivateNet)
-	}
-
-	if s.PlacementGroup != nil {
-		res["placement_group_id"] = util.CastInt(s.PlacementGroup.ID)
-	} else {
-		res["placement_group_id"] = nil
-	}
-
-	// Pass through datacenter name as long as it is returned from the API.
-	//
-	// If the attribute is not returned from the API, we never set the attribute,
-	// so whatever is in the state or user config is kept.
-	//
-	// See https://docs.hetzner.cloud/changelog#2025-12-16-phasing-out-datacenters
-	//nolint:staticcheck // Backwards-compatibility
-	if s.Datacenter != nil {
-		//nolint:staticcheck // Backwards-compatibility
-		res["datacenter"] = s.Datacenter.Name
+	// Whatever remains in cfgVolumeIDs now is a newly added volume. We attach it
+	// to the server.
+	for volumeID := range cfgVolumeIDs {
+		if err := attachVolumeToServer(ctx, c, s, &hcloud.Volume{ID: volumeID}, automount); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
 	}
 
-	return res
+	return nil
 }
 
-func networkToTerraformNetworks(d *schema.ResourceData, privateNetworks []hcloud.ServerPrivateNet) []map[string]any {
-	tfPrivateNetworks := make([]map[string]any, len(privateNetworks))
-	for i, privateNetwork := range privateNetworks {
-		tfPrivateNetwork := make(map[string]any)
-		tfPrivateNetwork["ip"] = privateNetwork.IP.String()
-		tfPrivateNetwork["mac_address"] = privateNetwork.MACAddress
-
-		// Check the user input to preserve the same structure in state
-		if nwSet, ok := d.GetOk("network"); ok {
-			for _, item := range nwSet.(*schema.Set).List() {
-				nwData := item.(map[string]any)
-				configSubnetID, hasSubnetID := nwData["subnet_id"].(string)
-
-				// Match API response to config entry by network_id or subnet_id
-				var matchesNetwork bool
-				var configNetworkID int64
-
-				if hasSubnetID && configSubnetID != "" {
-					if subnetNetwork, _, err := ParseSubnetID(configSubnetID); err == nil {
-						configNetworkID = subnetNetwork.ID
-						matchesNetwork = subnetNetwork.ID == privateNetwork.Network.ID
-					}
-				} else {
-					configNetworkID = util.CastInt64(nwData["network_id"])
-					matchesNetwork = configNetworkID == privateNetwork.Network.ID
-				}
-
-				if matchesNetwork {
-					// Write fields to state based on the user input
-					if configNetworkID != 0 {
-						tfPrivateNetwork["network_id"] = privateNetwork.Network.ID
-					}
-					if hasSubnetID && configSubnetID != "" {
-						tfPrivateNetwork["subnet_id"] = configSubnetID
-					}
-					break
-				}
-			}
-		}
-
-		aliasIPs := make([]string, len(privateNetwork.Aliases))
-		for in, ip := range privateNetwork.Aliases {
-			aliasIPs[in] = ip.String()
+// sameIPs reports whether both lists hold the same IPs, regardless of their order.
+func sameIPs(a, b []net.IP) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, ip := range a {
+		if !slices.ContainsFunc(b, ip.Equal) {
+			return false
 		}
-		tfPrivateNetwork["alias_ips"] = aliasIPs
-		tfPrivateNetworks[i] = tfPrivateNetwork
 	}
-	return tfPrivateNetworks
+	return true
 }
 
 func getPlacementGroup(ctx context.Context, c *hcloud.Client, id int64) (*hcloud.PlacementGroup, error) {
@@ -1399,7 +2084,7 @@ func setPlacementGroup(ctx context.Context, c *hcloud.Client, server *hcloud.Ser
 		if err != nil {
 			return err
 		}
-		if err = c.Action.WaitFor(ctx, action); err != nil {
+		if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 			return err
 		}
 	}
@@ -1414,7 +2099,7 @@ func setPlacementGroup(ctx context.Context, c *hcloud.Client, server *hcloud.Ser
 		if err != nil {
 			return err
 		}
-		if err = c.Action.WaitFor(ctx, action); err != nil {
+		if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 			return err
 		}
 	}
@@ -1433,141 +2118,16 @@ func setProtection(ctx context.Context, c *hcloud.Client, server *hcloud.Server,
 		return err
 	}
 
-	return c.Action.WaitFor(ctx, action)
-}
-
-func collectPrimaryIPIDs(primaryIPList map[string]any) (int64, int64) {
-	var IPv4ID int64
-	var IPv6ID int64
-	if id, err := ToPublicNetField[int](primaryIPList, "ipv4"); id != 0 && err == nil {
-		IPv4ID = util.CastInt64(id)
-	}
-	if id, err := ToPublicNetField[int](primaryIPList, "ipv6"); id != 0 && err == nil {
-		IPv6ID = util.CastInt64(id)
-	}
-	return IPv4ID, IPv6ID
-}
-
-func ToPublicNetField[V int | bool](field map[string]any, key string) (V, error) {
-	var op = "ToPublicNetField"
-
-	if value, ok := field[key]; ok {
-		return value.(V), nil
-	}
-
-	var zero V
-	return zero, fmt.Errorf("%s: field does not contain key: %s", op, key)
-}
-
-func onServerCreateWithoutPublicNet(opts *hcloud.ServerCreateOpts, d *schema.ResourceData, fn func(opts *hcloud.ServerCreateOpts) error) diag.Diagnostics {
-	if _, ok := d.GetOk("network"); ok && opts.PublicNet != nil {
-		if !opts.PublicNet.EnableIPv6 && !opts.PublicNet.EnableIPv4 {
-			if err := fn(opts); err != nil {
-				return hcloudutil.ErrorToDiag(err)
-			}
-		}
-		return nil
-	}
-	return nil
+	return hcloudutil.WaitForActions(ctx, &c.Action, action)
 }
 
 func powerOnServer(ctx context.Context, c *hcloud.Client, server *hcloud.Server) error {
-	err := control.Retry(control.DefaultRetries, func() error {
+	return control.Retry(ctx, control.DefaultRetries, func() error {
 		powerOn, _, err := c.Server.Poweron(ctx, server)
 		if err != nil {
 			return err
 		}
 
-		return c.Action.WaitFor(ctx, powerOn)
+		return hcloudutil.WaitForActions(ctx, &c.Action, powerOn)
 	})
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-func resourceServerCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
-	return validateUniqueNetworkIDs(d)
-}
-
-func validateUniqueNetworkIDs(d *schema.ResourceDiff) error {
-	// Validate that at least one of network_id or subnet_id is specified.
-	if rawNetworks := d.GetRawConfig().GetAttr("network"); rawNetworks.IsWhollyKnown() && !rawNetworks.IsNull() {
-		for it := rawNetworks.ElementIterator(); it.Next(); {
-			_, networkVal := it.Element()
-
-			hasNetworkID := !networkVal.GetAttr("network_id").IsNull()
-			hasSubnetID := !networkVal.GetAttr("subnet_id").IsNull()
-
-			if !hasNetworkID && !hasSubnetID {
-				return fmt.Errorf("must specify either network_id or subnet_id")
-			}
-		}
-	}
-
-	// Validate that every network set element uses unique network id
-	if n, ok := d.GetOkExists("network"); ok {
-		networks, ok := n.(*schema.Set)
-		if !ok {
-			return fmt.Errorf("network has unexpected type: %T", n)
-		}
-
-		uniqueNetworkIDs := map[int64]bool{}
-
-		for _, networkI := range networks.List() {
-			network, ok := networkI.(map[string]any)
-			if !ok {
-				return fmt.Errorf("network item has unexpected type: %T", networkI)
-			}
-
-			var networkID int64
-			configNetworkID := util.CastInt64(network["network_id"])
-			subnetIDStr, _ := network["subnet_id"].(string)
-
-			// When subnet_id is specified, extract network_id and validate IP range
-			if subnetIDStr != "" {
-				subnetNetwork, subnetIPRange, err := ParseSubnetID(subnetIDStr)
-				if err != nil {
-					continue
-				}
-
-				// If the user specified both network_id and subnet_id, they must match.
-				if configNetworkID != 0 && subnetNetwork.ID != configNetworkID {
-					return fmt.Errorf("subnet_id (%s) does not belong to the specified network_id (%d)", subnetIDStr, configNetworkID)
-				}
-
-				// Extract network_id from parsed subnet
-				networkID = subnetNetwork.ID
-
-				// Check if the server IP is within the subnet IP range
-				if ipStr, ok := network["ip"].(string); ok && ipStr != "" {
-					ip := net.ParseIP(ipStr)
-					if ip != nil && !subnetIPRange.Contains(ip) {
-						return fmt.Errorf("server IP (%s) is outside subnet IP range (%s)", ip.String(), subnetIPRange.String())
-					}
-				}
-			} else {
-				networkID = util.CastInt64(network["network_id"])
-			}
-
-			if networkID == 0 {
-				// ID is 0 if Network will be created in same apply, we are unable to reliably detect if the
-				// "to-be-created" networks are the same.
-				// See https://github.com/hetznercloud/terraform-provider-hcloud/issues/899
-
-				// We should revisit this after moving to the Plugin Framework,
-				// as it allows more introspection for the plan/changes made.
-				// See https://github.com/hetznercloud/terraform-provider-hcloud/issues/752
-				continue
-			}
-
-			if uniqueNetworkIDs[networkID] {
-				return fmt.Errorf("server is only allowed to be attached to each network once: %d", networkID)
-			}
-
-			uniqueNetworkIDs[networkID] = true
-		}
-	}
-
-	return nil
 }
@@ -251,7 +251,7 @@ func (r *NetworkResource) Create(ctx context.Context, req resource.CreateRequest
 	// Apply changes
 	var action *hcloud.Action
 
-	err := control.Retry(control.DefaultRetries, func() error {
+	err := control.Retry(ctx, control.DefaultRetries, func() error {
 		var innerErr error
 
 		action, _, innerErr = r.client.Server.AttachToNetwork(ctx, server, opts)
@@ -460,7 +460,7 @@ func (r *NetworkResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	var action *hcloud.Action
-	err = control.Retry(control.DefaultRetries, func() error {
+	err = control.Retry(ctx, control.DefaultRetries, func() error {
 		var innerErr error
 
 		action, _, innerErr = r.client.Server.DetachFromNetwork(ctx, server, opts)
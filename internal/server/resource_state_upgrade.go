@@ -0,0 +1,232 @@
+package server
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
+)
+
+// resourceModelV0 is the state of the resource implemented with the plugin SDK.
+type resourceModelV0 struct {
+	ID                      types.String `tfsdk:"id"`
+	Name                    types.String `tfsdk:"name"`
+	ServerType              types.String `tfsdk:"server_type"`
+	Image                   types.String `tfsdk:"image"`
+	Location                types.String `tfsdk:"location"`
+	Datacenter              types.String `tfsdk:"datacenter"`
+	UserData                types.String `tfsdk:"user_data"`
+	SSHKeys                 types.List   `tfsdk:"ssh_keys"`
+	KeepDisk                types.Bool   `tfsdk:"keep_disk"`
+	AllowDeprecatedImages   types.Bool   `tfsdk:"allow_deprecated_images"`
+	BackupWindow            types.String `tfsdk:"backup_window"`
+	Backups                 types.Bool   `tfsdk:"backups"`
+	IPv4Address             types.String `tfsdk:"ipv4_address"`
+	IPv6Address             types.String `tfsdk:"ipv6_address"`
+	IPv6Network             types.String `tfsdk:"ipv6_network"`
+	Status                  types.String `tfsdk:"status"`
+	ISO                     types.String `tfsdk:"iso"`
+	Rescue                  types.String `tfsdk:"rescue"`
+	Labels                  types.Map    `tfsdk:"labels"`
+	LabelsAll               types.Map    `tfsdk:"labels_all"`
+	PublicNet               types.Set    `tfsdk:"public_net"`
+	Network                 types.Set    `tfsdk:"network"`
+	IgnoreRemoteFirewallIDs types.Bool   `tfsdk:"ignore_remote_firewall_ids"`
+	FirewallIDs             types.Set    `tfsdk:"firewall_ids"`
+	PlacementGroupID        types.Int64  `tfsdk:"placement_group_id"`
+	DeleteProtection        types.Bool   `tfsdk:"delete_protection"`
+	RebuildProtection       types.Bool   `tfsdk:"rebuild_protection"`
+	ShutdownBeforeDeletion  types.Bool   `tfsdk:"shutdown_before_deletion"`
+	PrimaryDiskSize         types.Int64  `tfsdk:"primary_disk_size"`
+	Timeouts                types.Object `tfsdk:"timeouts"`
+}
+
+// schemaV0 is the schema of the resource implemented with the plugin SDK.
+func schemaV0() *schema.Schema {
+	optionalString := schema.StringAttribute{Optional: true, Computed: true}
+	optionalBool := schema.BoolAttribute{Optional: true, Computed: true}
+	optionalInt64 := schema.Int64Attribute{Optional: true, Computed: true}
+
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                         optionalString,
+			"name":                       optionalString,
+			"server_type":                optionalString,
+			"image":                      optionalString,
+			"location":                   optionalString,
+			"datacenter":                 optionalString,
+			"user_data":                  optionalString,
+			"ssh_keys":                   schema.ListAttribute{ElementType: types.StringType, Optional: true},
+			"keep_disk":                  optionalBool,
+			"allow_deprecated_images":    optionalBool,
+			"backup_window":              optionalString,
+			"backups":                    optionalBool,
+			"ipv4_address":               optionalString,
+			"ipv6_address":               optionalString,
+			"ipv6_network":               optionalString,
+			"status":                     optionalString,
+			"iso":                        optionalString,
+			"rescue":                     optionalString,
+			"labels":                     schema.MapAttribute{ElementType: types.StringType, Optional: true},
+			"labels_all":                 schema.MapAttribute{ElementType: types.StringType, Computed: true},
+			"ignore_remote_firewall_ids": optionalBool,
+			"firewall_ids":               schema.SetAttribute{ElementType: types.Int64Type, Optional: true, Computed: true},
+			"placement_group_id":         optionalInt64,
+			"delete_protection":          optionalBool,
+			"rebuild_protection":         optionalBool,
+			"shutdown_before_deletion":   optionalBool,
+			"primary_disk_size":          optionalInt64,
+		},
+		Blocks: map[string]schema.Block{
+			"public_net": schema.SetNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"ipv4_enabled": optionalBool,
+						"ipv6_enabled": optionalBool,
+						"ipv4":         optionalInt64,
+						"ipv6":         optionalInt64,
+					},
+				},
+			},
+			"network": schema.SetNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"network_id":  optionalInt64,
+						"subnet_id":   optionalString,
+						"ip":          optionalString,
+						"alias_ips":   schema.SetAttribute{ElementType: types.StringType, Optional: true, Computed: true},
+						"mac_address": optionalString,
+					},
+				},
+			},
+			"timeouts": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{Optional: true},
+				},
+			},
+		},
+	}
+}
+
+func (r *Resource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   schemaV0(),
+			StateUpgrader: upgradeStateV0,
+		},
+	}
+}
+
+// upgradeStateV0 upgrades the state of the resource implemented with the plugin SDK.
+// The SDK stores the zero value of unset attributes, those are converted to null
+// values to match the configuration.
+func upgradeStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior resourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := strconv.ParseInt(prior.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("id"), "Invalid ID", err.Error())
+		return
+	}
+
+	data := resourceModel{
+		ID:                      types.Int64Value(id),
+		Name:                    prior.Name,
+		ServerType:              prior.ServerType,
+		Image:                   prior.Image,
+		RebuildOnImageChange:    types.BoolValue(false),
+		RootPassword:            types.StringNull(),
+		Location:                prior.Location,
+		Datacenter:              prior.Datacenter,
+		UserData:                nullIfZero(prior.UserData),
+		UserDataEncoding:        types.StringNull(),
+		SSHKeys:                 prior.SSHKeys,
+		KeepDisk:                prior.KeepDisk,
+		AllowDeprecatedImages:   prior.AllowDeprecatedImages,
+		BackupWindow:            prior.BackupWindow,
+		Backups:                 prior.Backups,
+		IPv4Address:             prior.IPv4Address,
+		IPv6Address:             prior.IPv6Address,
+		IPv6Network:             prior.IPv6Network,
+		Status:                  prior.Status,
+		ISO:                     nullIfZero(prior.ISO),
+		Rescue:                  nullIfZero(prior.Rescue),
+		Labels:                  prior.Labels,
+		LabelsAll:               prior.LabelsAll,
+		IgnoreRemoteFirewallIDs: prior.IgnoreRemoteFirewallIDs,
+		FirewallIDs:             prior.FirewallIDs,
+		VolumeIDs:               types.SetNull(types.Int64Type),
+		Automount:               types.BoolNull(),
+		PlacementGroupID:        prior.PlacementGroupID,
+		DeleteProtection:        prior.DeleteProtection,
+		RebuildProtection:       prior.RebuildProtection,
+		ShutdownBeforeDeletion:  prior.ShutdownBeforeDeletion,
+		SnapshotBeforeDeletion:  types.BoolValue(false),
+		ShutdownTimeout:         types.StringValue(hcloudutil.DefaultShutdownTimeout.String()),
+		ShutdownFallback:        types.StringValue(string(hcloudutil.ShutdownFallbackPoweroff)),
+		FinalSnapshot:           types.ObjectNull(resourceFinalSnapshotModel{}.tfAttributesTypes()),
+		WaitFor:                 types.ObjectNull(resourceWaitForModel{}.tfAttributesTypes()),
+		PrimaryDiskSize:         prior.PrimaryDiskSize,
+		OutgoingTraffic:         types.Int64Null(),
+		IngoingTraffic:          types.Int64Null(),
+		IncludedTraffic:         types.Int64Null(),
+		TrafficQuotaPercent:     types.Float64Null(),
+		Timeouts:                prior.Timeouts,
+	}
+
+	if len(data.SSHKeys.Elements()) == 0 {
+		data.SSHKeys = types.ListNull(types.StringType)
+	}
+	if data.Labels.IsNull() {
+		data.Labels = types.MapValueMust(types.StringType, nil)
+	}
+	if data.PlacementGroupID.IsNull() {
+		data.PlacementGroupID = types.Int64Value(0)
+	}
+
+	var publicNets []resourcePublicNetModel
+	resp.Diagnostics.Append(prior.PublicNet.ElementsAs(ctx, &publicNets, false)...)
+	for i := range publicNets {
+		if publicNets[i].IPv4.ValueInt64() == 0 {
+			publicNets[i].IPv4 = types.Int64Null()
+		}
+		if publicNets[i].IPv6.ValueInt64() == 0 {
+			publicNets[i].IPv6 = types.Int64Null()
+		}
+	}
+	publicNetSet, newDiags := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: resourcePublicNetModel{}.tfAttributesTypes()}, publicNets)
+	resp.Diagnostics.Append(newDiags...)
+	data.PublicNet = publicNetSet
+
+	var networks []resourceNetworkModel
+	resp.Diagnostics.Append(prior.Network.ElementsAs(ctx, &networks, false)...)
+	for i := range networks {
+		networks[i].SubnetID = nullIfZero(networks[i].SubnetID)
+	}
+	networkSet, newDiags := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: resourceNetworkModel{}.tfAttributesTypes()}, networks)
+	resp.Diagnostics.Append(newDiags...)
+	data.Network = networkSet
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func nullIfZero(value types.String) types.String {
+	if value.ValueString() == "" {
+		return types.StringNull()
+	}
+	return value
+}
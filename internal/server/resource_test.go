@@ -1,8 +1,7 @@
 package server_test
 
 import (
-	"crypto/sha1" // nolint: gosec
-	"encoding/base64"
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
@@ -11,9 +10,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/firewall"
@@ -28,6 +27,7 @@ import (
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/testsupport"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/testtemplate"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/volume"
 )
 
 func TestAccServerResource(t *testing.T) {
@@ -75,6 +75,10 @@ func TestAccServerResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet(res1.TFID(), "ipv6_network"),
 					resource.TestCheckResourceAttr(res1.TFID(), "status", string(hcloud.ServerStatusRunning)),
 					resource.TestCheckResourceAttrSet(res1.TFID(), "primary_disk_size"),
+					resource.TestCheckResourceAttrSet(res1.TFID(), "outgoing_traffic"),
+					resource.TestCheckResourceAttrSet(res1.TFID(), "ingoing_traffic"),
+					resource.TestCheckResourceAttrSet(res1.TFID(), "included_traffic"),
+					resource.TestCheckResourceAttrSet(res1.TFID(), "traffic_quota_percent"),
 					resource.TestCheckResourceAttr(res1.TFID(), "placement_group_id", "0"),
 				),
 			},
@@ -259,6 +263,251 @@ func TestAccServerResource_Resize(t *testing.T) {
 	})
 }
 
+func TestAccServerResource_WaitFor(t *testing.T) {
+	teste2e.SkipWithFakeAPI(t, "wait_for connects to the server")
+
+	tmplMan := testtemplate.Manager{}
+
+	var hcServer hcloud.Server
+
+	resSSHKey := sshkey.NewRData(t, "server-wait-for")
+
+	res1 := &server.RData{
+		Name:    "server-wait-for",
+		Type:    teste2e.TestServerType,
+		Image:   teste2e.TestImage,
+		SSHKeys: []string{resSSHKey.TFID() + ".id"},
+	}
+	res1.SetRName("server-wait-for")
+	res1.Raw = `
+wait_for {
+  tcp_port = 22
+  timeout  = "5m"
+}
+`
+
+	// Change the server type, which restarts the server.
+	res2 := testtemplate.DeepCopy(t, res1)
+	res2.Type = teste2e.TestServerTypeUpgrade
+	res2.KeepDisk = true
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 teste2e.PreCheck(t),
+		ProtoV6ProviderFactories: testmux.ProtoV6ProviderFactories(),
+		CheckDestroy:             testsupport.CheckAPIResourceAllAbsent(server.ResourceType, server.GetAPIResource()),
+		Steps: []resource.TestStep{
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_ssh_key", resSSHKey,
+					"testdata/r/hcloud_server", res1,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testsupport.CheckResourceExists(res1.TFID(), server.ByID(t, &hcServer)),
+					resource.TestCheckResourceAttr(res1.TFID(), "wait_for.tcp_port", "22"),
+					resource.TestCheckResourceAttr(res1.TFID(), "wait_for.timeout", "5m"),
+				),
+			},
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_ssh_key", resSSHKey,
+					"testdata/r/hcloud_server", res2,
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(res2.TFID(), plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(res2.TFID(), "server_type", res2.Type),
+					resource.TestCheckResourceAttr(res2.TFID(), "wait_for.tcp_port", "22"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerResource_ShutdownTimeout(t *testing.T) {
+	tmplMan := testtemplate.Manager{}
+
+	var (
+		hcServer    hcloud.Server
+		hcPrimaryIP hcloud.PrimaryIP
+	)
+
+	resPrimaryIP := &primaryip.RData{
+		Name:     "server-shutdown-timeout",
+		Type:     "ipv6",
+		Location: teste2e.TestLocationName,
+		Raw: `
+shutdown_timeout  = "2m"
+shutdown_fallback = "fail"
+`,
+	}
+	resPrimaryIP.SetRName("server-shutdown-timeout")
+
+	res1 := &server.RData{
+		Name:             "server-shutdown-timeout",
+		Type:             teste2e.TestServerType,
+		Image:            teste2e.TestImage,
+		LocationName:     teste2e.TestLocationName,
+		ShutdownTimeout:  "2m",
+		ShutdownFallback: "fail",
+		PublicNet: map[string]any{
+			"ipv4_enabled": false,
+			"ipv6_enabled": true,
+			"ipv6":         resPrimaryIP.TFID() + ".id",
+		},
+	}
+	res1.SetRName("server-shutdown-timeout")
+
+	// Change the server type, which shuts down the server.
+	res2 := testtemplate.DeepCopy(t, res1)
+	res2.Type = teste2e.TestServerTypeUpgrade
+	res2.KeepDisk = true
+
+	// Delete the Primary IP while it is still assigned to the server, which shuts
+	// down the server to unassign it.
+	res3 := testtemplate.DeepCopy(t, res2)
+	res3.PublicNet = map[string]any{
+		"ipv4_enabled": false,
+		"ipv6_enabled": true,
+	}
+	res3.Raw = `
+lifecycle {
+  ignore_changes = [public_net]
+}
+`
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 teste2e.PreCheck(t),
+		ProtoV6ProviderFactories: testmux.ProtoV6ProviderFactories(),
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testsupport.CheckResourcesDestroyed(server.ResourceType, server.ByID(t, &hcServer)),
+			testsupport.CheckResourcesDestroyed(primaryip.ResourceType, primaryip.ByID(t, &hcPrimaryIP)),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_primary_ip", resPrimaryIP,
+					"testdata/r/hcloud_server", res1,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testsupport.CheckResourceExists(resPrimaryIP.TFID(), primaryip.ByID(t, &hcPrimaryIP)),
+					testsupport.CheckResourceExists(res1.TFID(), server.ByID(t, &hcServer)),
+					resource.TestCheckResourceAttr(res1.TFID(), "shutdown_timeout", "2m"),
+					resource.TestCheckResourceAttr(res1.TFID(), "shutdown_fallback", "fail"),
+					resource.TestCheckResourceAttr(resPrimaryIP.TFID(), "shutdown_timeout", "2m"),
+					resource.TestCheckResourceAttr(resPrimaryIP.TFID(), "shutdown_fallback", "fail"),
+				),
+			},
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_primary_ip", resPrimaryIP,
+					"testdata/r/hcloud_server", res2,
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(res2.TFID(), plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(res2.TFID(), "server_type", res2.Type),
+					resource.TestCheckResourceAttr(res2.TFID(), "status", "running"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(resPrimaryIP.TFID(), tfjsonpath.New("assignee_id"), testsupport.Int64ExactFromFunc(func() int64 { return hcServer.ID })),
+				},
+			},
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_server", res3,
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resPrimaryIP.TFID(), plancheck.ResourceActionDestroy),
+						plancheck.ExpectResourceAction(res3.TFID(), plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testsupport.CheckResourcesDestroyed(primaryip.ResourceType, primaryip.ByID(t, &hcPrimaryIP)),
+					func(_ *terraform.State) error {
+						client, err := testsupport.CreateClient()
+						if err != nil {
+							return err
+						}
+
+						result, _, err := client.Server.GetByID(context.Background(), hcServer.ID)
+						if err != nil {
+							return err
+						}
+						if result.Status != hcloud.ServerStatusRunning {
+							return fmt.Errorf("expected server to be powered on again, got status %s", result.Status)
+						}
+						if result.PublicNet.IPv6.ID == hcPrimaryIP.ID {
+							return fmt.Errorf("expected primary ip %d to be unassigned", hcPrimaryIP.ID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccServerResource_RebuildOnImageChange(t *testing.T) {
+	tmplMan := testtemplate.Manager{}
+
+	var hcServer, hcServerRebuilt hcloud.Server
+
+	res1 := &server.RData{
+		Name:                 "server-rebuild",
+		Type:                 teste2e.TestServerType,
+		Image:                teste2e.TestImage,
+		RebuildOnImageChange: true,
+	}
+	res1.SetRName("server-rebuild")
+
+	res2 := testtemplate.DeepCopy(t, res1)
+	res2.Image = teste2e.TestImageID
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 teste2e.PreCheck(t),
+		ProtoV6ProviderFactories: testmux.ProtoV6ProviderFactories(),
+		CheckDestroy:             testsupport.CheckAPIResourceAllAbsent(server.ResourceType, server.GetAPIResource()),
+		Steps: []resource.TestStep{
+			{
+				Config: tmplMan.Render(t, "testdata/r/hcloud_server", res1),
+				Check: resource.ComposeTestCheckFunc(
+					testsupport.CheckResourceExists(res1.TFID(), server.ByID(t, &hcServer)),
+					resource.TestCheckResourceAttr(res1.TFID(), "image", res1.Image),
+					resource.TestCheckResourceAttrSet(res1.TFID(), "root_password"),
+				),
+			},
+			{
+				// Changing the image rebuilds the Server in place.
+				Config: tmplMan.Render(t, "testdata/r/hcloud_server", res2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(res2.TFID(), plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue(res2.TFID(), tfjsonpath.New("root_password")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testsupport.CheckResourceExists(res2.TFID(), server.ByID(t, &hcServerRebuilt)),
+					resource.TestCheckResourceAttr(res2.TFID(), "image", res2.Image),
+					resource.TestCheckResourceAttrSet(res2.TFID(), "root_password"),
+					func(_ *terraform.State) error {
+						if hcServer.ID != hcServerRebuilt.ID {
+							return fmt.Errorf("server was replaced: %d != %d", hcServer.ID, hcServerRebuilt.ID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccServerResource_ChangeUserData(t *testing.T) {
 	tmplMan := testtemplate.Manager{}
 
@@ -294,7 +543,7 @@ func TestAccServerResource_ChangeUserData(t *testing.T) {
 					resource.TestCheckResourceAttr(res1.TFID(), "name", fmt.Sprintf("server-userdata--%d", tmplMan.RandInt)),
 					resource.TestCheckResourceAttr(res1.TFID(), "server_type", res1.Type),
 					resource.TestCheckResourceAttr(res1.TFID(), "image", res1.Image),
-					resource.TestCheckResourceAttr(res1.TFID(), "user_data", userDataHashSum(res1.UserData+"\n")),
+					resource.TestCheckResourceAttr(res1.TFID(), "user_data", res1.UserData+"\n"),
 				),
 			},
 			{
@@ -312,7 +561,7 @@ func TestAccServerResource_ChangeUserData(t *testing.T) {
 					resource.TestCheckResourceAttr(res2.TFID(), "name", fmt.Sprintf("server-userdata--%d", tmplMan.RandInt)),
 					resource.TestCheckResourceAttr(res2.TFID(), "server_type", res2.Type),
 					resource.TestCheckResourceAttr(res2.TFID(), "image", res2.Image),
-					resource.TestCheckResourceAttr(res2.TFID(), "user_data", userDataHashSum(res2.UserData+"\n")),
+					resource.TestCheckResourceAttr(res2.TFID(), "user_data", res2.UserData+"\n"),
 				),
 			},
 		},
@@ -1339,6 +1588,95 @@ func TestAccServerResource_Firewalls(t *testing.T) {
 	})
 }
 
+func TestAccServerResource_Volumes(t *testing.T) {
+	tmplMan := testtemplate.Manager{}
+
+	var hcServer hcloud.Server
+
+	resVolumeA := &volume.RData{
+		Name:         "server-volume-a",
+		Size:         10,
+		LocationName: teste2e.TestLocationName,
+	}
+	resVolumeA.SetRName("server-volume-a")
+
+	resVolumeB := &volume.RData{
+		Name:         "server-volume-b",
+		Size:         10,
+		LocationName: teste2e.TestLocationName,
+	}
+	resVolumeB.SetRName("server-volume-b")
+
+	res1 := &server.RData{
+		Name:         "server-volume",
+		Type:         teste2e.TestServerType,
+		Image:        teste2e.TestImage,
+		LocationName: teste2e.TestLocationName,
+		VolumeIDs:    []string{resVolumeA.TFID() + ".id"},
+		Automount:    true,
+	}
+	res1.SetRName("server-volume")
+
+	res2 := testtemplate.DeepCopy(t, res1)
+	res2.VolumeIDs = []string{resVolumeB.TFID() + ".id"}
+
+	res3 := testtemplate.DeepCopy(t, res2)
+	res3.VolumeIDs = []string{}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 teste2e.PreCheck(t),
+		ProtoV6ProviderFactories: testmux.ProtoV6ProviderFactories(),
+		CheckDestroy:             testsupport.CheckAPIResourceAllAbsent(server.ResourceType, server.GetAPIResource()),
+		Steps: []resource.TestStep{
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_volume", resVolumeA,
+					"testdata/r/hcloud_volume", resVolumeB,
+					"testdata/r/hcloud_server", res1,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testsupport.CheckResourceExists(res1.TFID(), server.ByID(t, &hcServer)),
+					resource.TestCheckResourceAttr(res1.TFID(), "volume_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(res1.TFID(), "volume_ids.*", resVolumeA.TFID(), "id"),
+				),
+			},
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_volume", resVolumeA,
+					"testdata/r/hcloud_volume", resVolumeB,
+					"testdata/r/hcloud_server", res2,
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(res2.TFID(), plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testsupport.CheckResourceExists(res2.TFID(), server.ByID(t, &hcServer)),
+					resource.TestCheckResourceAttr(res2.TFID(), "volume_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(res2.TFID(), "volume_ids.*", resVolumeB.TFID(), "id"),
+				),
+			},
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_volume", resVolumeA,
+					"testdata/r/hcloud_volume", resVolumeB,
+					"testdata/r/hcloud_server", res3,
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(res3.TFID(), plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testsupport.CheckResourceExists(res3.TFID(), server.ByID(t, &hcServer)),
+					resource.TestCheckResourceAttr(res3.TFID(), "volume_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccServerResource_PlacementGroup(t *testing.T) {
 	tmplMan := testtemplate.Manager{}
 
@@ -1501,6 +1839,76 @@ func TestAccServerResource_Protection(t *testing.T) {
 	})
 }
 
+func TestAccServerResource_SnapshotBeforeDeletion(t *testing.T) {
+	tmplMan := testtemplate.Manager{}
+
+	var hcServer hcloud.Server
+
+	res := &server.RData{
+		Name:                   "server-final-snapshot",
+		Type:                   teste2e.TestServerType,
+		Image:                  teste2e.TestImage,
+		SnapshotBeforeDeletion: true,
+	}
+	res.SetRName("server-final-snapshot")
+	res.Raw = fmt.Sprintf(`
+final_snapshot {
+  description = "final snapshot"
+  labels = {
+    "final-snapshot" = "%d"
+  }
+}
+`, tmplMan.RandInt)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 teste2e.PreCheck(t),
+		ProtoV6ProviderFactories: testmux.ProtoV6ProviderFactories(),
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testsupport.CheckAPIResourceAllAbsent(server.ResourceType, server.GetAPIResource()),
+			func(_ *terraform.State) error {
+				client, err := testsupport.CreateClient()
+				if err != nil {
+					return err
+				}
+
+				images, err := client.Image.AllWithOpts(context.Background(), hcloud.ImageListOpts{
+					ListOpts: hcloud.ListOpts{LabelSelector: fmt.Sprintf("final-snapshot=%d", tmplMan.RandInt)},
+					Type:     []hcloud.ImageType{hcloud.ImageTypeSnapshot},
+				})
+				if err != nil {
+					return err
+				}
+
+				for _, image := range images {
+					if _, err := client.Image.Delete(context.Background(), image); err != nil {
+						return err
+					}
+				}
+
+				if len(images) != 1 {
+					return fmt.Errorf("expected 1 final snapshot, got %d", len(images))
+				}
+				if images[0].Description != "final snapshot" {
+					return fmt.Errorf("unexpected final snapshot description: %s", images[0].Description)
+				}
+				return nil
+			},
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: tmplMan.Render(t,
+					"testdata/r/hcloud_server", res,
+				),
+				Check: resource.ComposeTestCheckFunc(
+					testsupport.CheckResourceExists(res.TFID(), server.ByID(t, &hcServer)),
+					resource.TestCheckResourceAttr(res.TFID(), "snapshot_before_deletion", "true"),
+					resource.TestCheckResourceAttr(res.TFID(), "final_snapshot.description", "final snapshot"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccServerResource_EmptySSHKey(t *testing.T) {
 	// Regression test for https://github.com/hetznercloud/terraform-provider-hcloud/issues/727
 
@@ -1887,34 +2295,3 @@ func TestAccServerResource_MixedNetworkAndSubnetID(t *testing.T) {
 		},
 	})
 }
-
-func userDataHashSum(userData string) string {
-	sum := sha1.Sum([]byte(userData)) // nolint: gosec
-	return base64.StdEncoding.EncodeToString(sum[:])
-}
-
-func TestToPublicNetField(t *testing.T) {
-	t.Run("int", func(t *testing.T) {
-		got, err := server.ToPublicNetField[int](map[string]any{"key": int(1)}, "key")
-		require.NoError(t, err)
-		require.Equal(t, int(1), got)
-	})
-
-	t.Run("bool", func(t *testing.T) {
-		got, err := server.ToPublicNetField[bool](map[string]any{"key": true}, "key")
-		require.NoError(t, err)
-		require.Equal(t, true, got)
-	})
-
-	t.Run("int not found", func(t *testing.T) {
-		got, err := server.ToPublicNetField[int](map[string]any{}, "key")
-		require.EqualError(t, err, "ToPublicNetField: field does not contain key: key")
-		require.Equal(t, int(0), got)
-	})
-
-	t.Run("bool not found", func(t *testing.T) {
-		got, err := server.ToPublicNetField[bool](map[string]any{}, "key")
-		require.EqualError(t, err, "ToPublicNetField: field does not contain key: key")
-		require.Equal(t, false, got)
-	})
-}
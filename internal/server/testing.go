@@ -63,6 +63,24 @@ func (d *DDataList) TFID() string {
 	return fmt.Sprintf("data.%s.%s", DataSourceListType, d.RName())
 }
 
+// DDataMetrics defines the fields for the "testdata/d/hcloud_server_metrics"
+// template.
+type DDataMetrics struct {
+	testtemplate.DataCommon
+
+	ServerID string
+	Type     string
+	Start    string
+	End      string
+	Duration string
+	Step     int
+}
+
+// TFID returns the data source identifier.
+func (d *DDataMetrics) TFID() string {
+	return fmt.Sprintf("data.%s.%s", MetricsDataSourceType, d.RName())
+}
+
 // RData defines the fields for the "testdata/r/hcloud_server" template.
 type RData struct {
 	testtemplate.DataCommon
@@ -82,12 +100,18 @@ type RData struct {
 	UserData               string
 	Networks               []RDataInlineNetwork
 	FirewallIDs            []string
+	VolumeIDs              []string
+	Automount              bool
 	DependsOn              []string
 	PlacementGroupID       string
 	DeleteProtection       bool
 	RebuildProtection      bool
 	AllowDeprecatedImages  bool
+	RebuildOnImageChange   bool
 	ShutdownBeforeDeletion bool
+	SnapshotBeforeDeletion bool
+	ShutdownTimeout        string
+	ShutdownFallback       string
 
 	Raw string
 }
@@ -130,8 +154,21 @@ func (d *RDataNetwork) TFID() string {
 type AData struct {
 	testtemplate.DataCommon
 
-	Type     string
-	ServerID string
+	Type           string
+	ServerID       string
+	ServerIDs      []string
+	LabelSelector  string
+	MaxParallel    int
+	BatchPause     string
+	Timeout        string
+	Fallback       string
+	Image          string
+	WaitForRunning bool
+	ImageType      string
+	Description    string
+	Labels         map[string]string
+	ServerType     string
+	UpgradeDisk    bool
 }
 
 // TFID returns the resource identifier.
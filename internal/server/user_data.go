@@ -0,0 +1,100 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha1" // nolint: gosec
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// userDataEncodingGzipBase64 compresses the user data with gzip and encodes it
+	// with base64.
+	userDataEncodingGzipBase64 = "gzip+base64"
+	// userDataMaxSize is the maximum size of the user data accepted by the API.
+	userDataMaxSize = 32 * 1024
+)
+
+func userDataHashSum(userData string) string {
+	sum := sha1.Sum([]byte(userData)) // nolint: gosec
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func isUserDataHashSum(value string) bool {
+	sum, err := base64.StdEncoding.DecodeString(value)
+	return err == nil && len(sum) == sha1.Size
+}
+
+// encodeUserData returns the user data in the encoding sent to the API. On Hetzner
+// Cloud, cloud-init decodes base64 user data and decompresses gzip payloads.
+func encodeUserData(userData, encoding string) (string, error) {
+	if encoding != userDataEncodingGzipBase64 {
+		return userData, nil
+	}
+
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write([]byte(userData)); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// validateUserData validates that the encoded user data does not exceed the size
+// accepted by the API.
+func validateUserData(userData, encoding types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if userData.IsNull() || userData.IsUnknown() || encoding.IsUnknown() {
+		return diags
+	}
+
+	payload, err := encodeUserData(userData.ValueString(), encoding.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("user_data"), "Invalid user data", err.Error())
+		return diags
+	}
+	if len(payload) <= userDataMaxSize {
+		return diags
+	}
+
+	detail := fmt.Sprintf("The user data is %d bytes, the maximum size accepted by the API is %d bytes.", len(payload), userDataMaxSize)
+	if encoding.IsNull() {
+		if compressed, err := encodeUserData(userData.ValueString(), userDataEncodingGzipBase64); err == nil && len(compressed) <= userDataMaxSize {
+			detail += fmt.Sprintf(" Compressed with user_data_encoding = %q, it would be %d bytes.", userDataEncodingGzipBase64, len(compressed))
+		}
+	}
+	diags.AddAttributeError(path.Root("user_data"), "User data too large", detail)
+	return diags
+}
+
+// userDataRequiresReplace replaces the server when its user data changes, unless the
+// state holds the hash of the configured user data, which was stored by the SDK
+// implementation of the resource, or the user data only differs by whitespace.
+func userDataRequiresReplace(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.StateValue.IsNull() || req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	stateValue, configValue := req.StateValue.ValueString(), req.ConfigValue.ValueString()
+	if isUserDataHashSum(stateValue) && stateValue == userDataHashSum(configValue) {
+		return
+	}
+	resp.RequiresReplace = strings.TrimSpace(stateValue) != strings.TrimSpace(configValue)
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeUserData(t *testing.T) {
+	userData := "#cloud-config\nruncmd:\n  - echo hello\n"
+
+	encoded, err := encodeUserData(userData, "")
+	require.NoError(t, err)
+	assert.Equal(t, userData, encoded)
+
+	encoded, err = encodeUserData(userData, userDataEncodingGzipBase64)
+	require.NoError(t, err)
+
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	decoded, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, userData, string(decoded))
+}
+
+func TestValidateUserData(t *testing.T) {
+	compressible := "#cloud-config\n" + strings.Repeat("# padding\n", 4000)
+
+	random := make([]byte, userDataMaxSize)
+	rand.New(rand.NewSource(1)).Read(random) // nolint: gosec
+	incompressible := base64.StdEncoding.EncodeToString(random)
+
+	testCases := []struct {
+		name       string
+		userData   types.String
+		encoding   types.String
+		wantDetail string
+	}{
+		{
+			name:     "null",
+			userData: types.StringNull(),
+			encoding: types.StringNull(),
+		},
+		{
+			name:     "small",
+			userData: types.StringValue("#cloud-config\n"),
+			encoding: types.StringNull(),
+		},
+		{
+			name:       "too large",
+			userData:   types.StringValue(compressible),
+			encoding:   types.StringNull(),
+			wantDetail: "The user data is 40014 bytes, the maximum size accepted by the API is 32768 bytes. Compressed with user_data_encoding = \"gzip+base64\", it would be",
+		},
+		{
+			name:     "compressed",
+			userData: types.StringValue(compressible),
+			encoding: types.StringValue(userDataEncodingGzipBase64),
+		},
+		{
+			name:       "too large compressed",
+			userData:   types.StringValue(incompressible),
+			encoding:   types.StringValue(userDataEncodingGzipBase64),
+			wantDetail: "the maximum size accepted by the API is 32768 bytes.",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateUserData(tt.userData, tt.encoding)
+			if tt.wantDetail == "" {
+				assert.False(t, diags.HasError(), diags)
+				return
+			}
+			require.Len(t, diags, 1)
+			assert.Equal(t, "User data too large", diags[0].Summary())
+			assert.Contains(t, diags[0].Detail(), tt.wantDetail)
+		})
+	}
+}
+
+func TestUserDataRequiresReplace(t *testing.T) {
+	userData := "#cloud-config\n"
+
+	testCases := []struct {
+		name   string
+		state  types.String
+		config types.String
+		want   bool
+	}{
+		{
+			name:   "changed",
+			state:  types.StringValue(userData),
+			config: types.StringValue("#cloud-config\nruncmd: []\n"),
+			want:   true,
+		},
+		{
+			name:   "whitespace",
+			state:  types.StringValue(userData),
+			config: types.StringValue("  " + userData + "\n"),
+			want:   false,
+		},
+		{
+			name:   "legacy hash",
+			state:  types.StringValue(userDataHashSum(userData)),
+			config: types.StringValue(userData),
+			want:   false,
+		},
+		{
+			name:   "other legacy hash",
+			state:  types.StringValue(userDataHashSum("#cloud-config\nruncmd: []\n")),
+			config: types.StringValue(userData),
+			want:   true,
+		},
+		{
+			name:   "removed",
+			state:  types.StringValue(userData),
+			config: types.StringNull(),
+			want:   true,
+		},
+		{
+			name:   "unknown",
+			state:  types.StringValue(userData),
+			config: types.StringUnknown(),
+			want:   true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req := planmodifier.StringRequest{StateValue: tt.state, ConfigValue: tt.config, PlanValue: tt.config}
+			resp := &stringplanmodifier.RequiresReplaceIfFuncResponse{}
+			userDataRequiresReplace(t.Context(), req, resp)
+			assert.Equal(t, tt.want, resp.RequiresReplace)
+		})
+	}
+}
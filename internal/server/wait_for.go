@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+const (
+	waitForAddressIPv4    = "ipv4"
+	waitForAddressIPv6    = "ipv6"
+	waitForAddressPrivate = "private"
+
+	defaultWaitForTimeout = 5 * time.Minute
+	// waitForInterval is the interval between two readiness probes, it also bounds
+	// the duration of a single probe.
+	waitForInterval = 5 * time.Second
+)
+
+// probeHTTPClient sends the requests of the readiness probes. Like the TCP
+// connections, the requests are sent directly to the server: the proxy and TLS
+// settings used to reach the API do not apply.
+var probeHTTPClient = newProbeHTTPClient()
+
+func newProbeHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	return &http.Client{Transport: transport}
+}
+
+type resourceWaitForModel struct {
+	TCPPort types.Int64  `tfsdk:"tcp_port"`
+	HTTPURL types.String `tfsdk:"http_url"`
+	Timeout types.String `tfsdk:"timeout"`
+	Address types.String `tfsdk:"address"`
+}
+
+func (m resourceWaitForModel) tfAttributesTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"tcp_port": types.Int64Type,
+		"http_url": types.StringType,
+		"timeout":  types.StringType,
+		"address":  types.StringType,
+	}
+}
+
+// readinessProbe describes the conditions a server must meet to be considered ready.
+type readinessProbe struct {
+	// Address is the IP the TCP connections are opened to.
+	Address net.IP
+	// TCPPort is the port that must accept TCP connections, 0 to skip the check.
+	TCPPort int
+	// HTTPURL is the URL that must respond with a 2xx status, empty to skip the check.
+	HTTPURL string
+	// Timeout is the time the server is given to become ready.
+	Timeout time.Duration
+	// Interval is the time between two attempts.
+	Interval time.Duration
+	// HTTPClient sends the requests to the HTTPURL.
+	HTTPClient *http.Client
+}
+
+// validateWaitFor validates the configured wait_for block.
+func validateWaitFor(ctx context.Context, value types.Object) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() {
+		return diags
+	}
+
+	var waitFor resourceWaitForModel
+	diags.Append(value.As(ctx, &waitFor, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if waitFor.TCPPort.IsNull() && waitFor.HTTPURL.IsNull() {
+		diags.AddAttributeError(
+			path.Root("wait_for"),
+			"Invalid wait_for block",
+			"At least one of tcp_port or http_url must be set.",
+		)
+	}
+
+	return diags
+}
+
+// readinessProbeFor returns the probe configured by the wait_for block for the
+// server. It returns nil if no wait_for block is configured.
+func readinessProbeFor(ctx context.Context, value types.Object, server *hcloud.Server) (*readinessProbe, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() {
+		return nil, diags
+	}
+
+	var waitFor resourceWaitForModel
+	diags.Append(value.As(ctx, &waitFor, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	probe := &readinessProbe{
+		TCPPort:  int(waitFor.TCPPort.ValueInt64()),
+		HTTPURL:  waitFor.HTTPURL.ValueString(),
+		Timeout:  defaultWaitForTimeout,
+		Interval: waitForInterval,
+	}
+
+	if timeout := waitFor.Timeout.ValueString(); timeout != "" {
+		var err error
+		probe.Timeout, err = time.ParseDuration(timeout)
+		if err != nil {
+			diags.AddAttributeError(path.Root("wait_for").AtName("timeout"), "Invalid timeout", err.Error())
+			return nil, diags
+		}
+	}
+
+	address := waitFor.Address.ValueString()
+	switch address {
+	case waitForAddressIPv6:
+		if server.PublicNet.IPv6.Network != nil {
+			// The server uses the first IP of its IPv6 network.
+			probe.Address = append(net.IP{}, server.PublicNet.IPv6.Network.IP...)
+			probe.Address[len(probe.Address)-1] |= 1
+		}
+	case waitForAddressPrivate:
+		if len(server.PrivateNet) > 0 {
+			probe.Address = server.PrivateNet[0].IP
+		}
+	default:
+		address = waitForAddressIPv4
+		if !server.PublicNet.IPv4.IsUnspecified() {
+			probe.Address = server.PublicNet.IPv4.IP
+		}
+	}
+
+	if probe.TCPPort != 0 && probe.Address == nil {
+		diags.AddAttributeError(
+			path.Root("wait_for").AtName("address"),
+			"Address not available",
+			fmt.Sprintf("Server %d has no %s address to wait for.", server.ID, address),
+		)
+		return nil, diags
+	}
+
+	return probe, diags
+}
+
+// waitForReady waits until the server meets the conditions of the wait_for block.
+// Stopped servers are not waited for.
+func waitForReady(ctx context.Context, value types.Object, server *hcloud.Server) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if server.Status == hcloud.ServerStatusOff {
+		return diags
+	}
+
+	probe, newDiags := readinessProbeFor(ctx, value, server)
+	diags.Append(newDiags...)
+	if probe == nil || diags.HasError() {
+		return diags
+	}
+	probe.HTTPClient = probeHTTPClient
+
+	if err := probe.Wait(ctx); err != nil {
+		diags.AddAttributeError(
+			path.Root("wait_for"),
+			"Server not ready",
+			fmt.Sprintf("Server %d did not meet the wait_for conditions: %s", server.ID, err),
+		)
+	}
+	return diags
+}
+
+// Wait blocks until the server passes all checks of the probe, or the timeout
+// expires.
+func (p *readinessProbe) Wait(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	for {
+		err := p.check(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("server not ready after %s: %w", p.Timeout, err)
+			}
+			return ctx.Err()
+		case <-time.After(p.Interval):
+		}
+	}
+}
+
+func (p *readinessProbe) check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.Interval)
+	defer cancel()
+
+	if p.TCPPort != 0 {
+		address := net.JoinHostPort(p.Address.String(), strconv.Itoa(p.TCPPort))
+
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		_ = conn.Close()
+	}
+
+	if p.HTTPURL != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.HTTPURL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := p.HTTPClient.Do(req)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("%s responded with status %s", p.HTTPURL, resp.Status)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func TestReadinessProbe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	// The handler responds with 503 to the first unhealthy requests, and with 200
+	// afterwards.
+	var hits, unhealthy atomic.Int32
+	httpServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		if unhealthy.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	httpServer.Listener = listener
+	httpServer.Start()
+	t.Cleanup(httpServer.Close)
+
+	addr := listener.Addr().(*net.TCPAddr)
+
+	t.Run("tcp", func(t *testing.T) {
+		probe := &readinessProbe{Address: addr.IP, TCPPort: addr.Port, Timeout: time.Second, Interval: 10 * time.Millisecond}
+		assert.NoError(t, probe.Wait(t.Context()))
+	})
+
+	t.Run("tcp closed port", func(t *testing.T) {
+		closed, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		port := closed.Addr().(*net.TCPAddr).Port
+		require.NoError(t, closed.Close())
+
+		probe := &readinessProbe{Address: addr.IP, TCPPort: port, Timeout: 50 * time.Millisecond, Interval: 10 * time.Millisecond}
+		assert.ErrorContains(t, probe.Wait(t.Context()), "server not ready after 50ms")
+	})
+
+	t.Run("http", func(t *testing.T) {
+		probe := &readinessProbe{HTTPURL: httpServer.URL, Timeout: 10 * time.Second, Interval: 100 * time.Millisecond, HTTPClient: httpServer.Client()}
+
+		unhealthy.Store(1)
+		assert.ErrorContains(t, probe.check(t.Context()), "503 Service Unavailable")
+
+		// The probe must retry until the handler responds with 200.
+		hits.Store(0)
+		unhealthy.Store(2)
+		assert.NoError(t, probe.Wait(t.Context()))
+		assert.Equal(t, int32(3), hits.Load())
+	})
+}
+
+func TestProbeHTTPClient(t *testing.T) {
+	// Like the TCP connections, the requests are not sent through a proxy.
+	transport, ok := probeHTTPClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Nil(t, transport.Proxy)
+}
+
+func TestReadinessProbeFor(t *testing.T) {
+	server := &hcloud.Server{
+		ID: 1,
+		PublicNet: hcloud.ServerPublicNet{
+			IPv4: hcloud.ServerPublicNetIPv4{IP: net.ParseIP("198.51.100.1")},
+			IPv6: hcloud.ServerPublicNetIPv6{IP: net.ParseIP("2001:db8::"), Network: &net.IPNet{IP: net.ParseIP("2001:db8::"), Mask: net.CIDRMask(64, 128)}},
+		},
+		PrivateNet: []hcloud.ServerPrivateNet{{IP: net.ParseIP("10.0.1.2")}},
+	}
+
+	waitFor := func(address string) types.Object {
+		value := types.StringNull()
+		if address != "" {
+			value = types.StringValue(address)
+		}
+		return types.ObjectValueMust(resourceWaitForModel{}.tfAttributesTypes(), map[string]attr.Value{
+			"tcp_port": types.Int64Value(22),
+			"http_url": types.StringNull(),
+			"timeout":  types.StringValue("1m"),
+			"address":  value,
+		})
+	}
+
+	for address, want := range map[string]string{
+		"":        "198.51.100.1",
+		"ipv4":    "198.51.100.1",
+		"ipv6":    "2001:db8::1",
+		"private": "10.0.1.2",
+	} {
+		probe, diags := readinessProbeFor(t.Context(), waitFor(address), server)
+		require.False(t, diags.HasError(), diags)
+		assert.Equal(t, want, probe.Address.String())
+		assert.Equal(t, 22, probe.TCPPort)
+		assert.Equal(t, time.Minute, probe.Timeout)
+	}
+
+	probe, diags := readinessProbeFor(t.Context(), waitFor("private"), &hcloud.Server{ID: 1})
+	assert.Nil(t, probe)
+	assert.True(t, diags.HasError())
+
+	probe, diags = readinessProbeFor(t.Context(), types.ObjectNull(resourceWaitForModel{}.tfAttributesTypes()), server)
+	assert.Nil(t, probe)
+	assert.False(t, diags.HasError())
+}
@@ -26,6 +26,7 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: hcloudutil.CustomizeDiffLabelsAll,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(90 * time.Minute),
 		},
@@ -43,12 +44,13 @@ func Resource() *schema.Resource {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
+			"labels_all": hcloudutil.LabelsAllSchema(),
 		},
 	}
 }
 
 func resourceSnapshotCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	serverID := util.CastInt64(d.Get("server_id"))
 	opts := hcloud.ServerCreateImageOpts{
@@ -56,13 +58,7 @@ func resourceSnapshotCreate(ctx context.Context, d *schema.ResourceData, m any)
 		Description: new(d.Get("description").(string)),
 	}
 
-	if labels, ok := d.GetOk("labels"); ok {
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
-		}
-		opts.Labels = tmpLabels
-	}
+	opts.Labels = hcloudutil.LabelsFromResourceData(d, m)
 
 	res, _, err := client.Server.CreateImage(ctx, &hcloud.Server{ID: serverID}, &opts)
 	if err != nil {
@@ -70,7 +66,7 @@ func resourceSnapshotCreate(ctx context.Context, d *schema.ResourceData, m any)
 	}
 
 	d.SetId(util.FormatID(res.Image.ID))
-	if err = client.Action.WaitFor(ctx, res.Action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &client.Action, res.Action); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 
@@ -78,7 +74,7 @@ func resourceSnapshotCreate(ctx context.Context, d *schema.ResourceData, m any)
 }
 
 func resourceSnapshotRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	id, err := util.ParseID(d.Id())
 	if err != nil {
@@ -102,12 +98,16 @@ func resourceSnapshotRead(ctx context.Context, d *schema.ResourceData, m any) di
 		return nil
 	}
 
+	labels := d.Get("labels")
 	setSnapshotSchema(d, snapshot)
+	if err := hcloudutil.SetResourceDataLabels(d, m, labels, snapshot.Labels); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
 func resourceSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	id, err := util.ParseID(d.Id())
 	if err != nil {
@@ -132,14 +132,19 @@ func resourceSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m any)
 		}
 	}
 
-	if d.HasChange("labels") {
-		labels := d.Get("labels")
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
+	if d.HasChanges("labels", "labels_all") {
+		labels, err := hcloudutil.UpdateLabelsFromResourceData(d, m, func() (map[string]string, error) {
+			in, _, err := client.Image.GetByID(ctx, image.ID)
+			if err != nil || in == nil {
+				return nil, err
+			}
+			return in.Labels, nil
+		})
+		if err != nil {
+			return hcloudutil.ErrorToDiag(err)
 		}
-		_, _, err := client.Image.Update(ctx, image, hcloud.ImageUpdateOpts{
-			Labels: tmpLabels,
+		_, _, err = client.Image.Update(ctx, image, hcloud.ImageUpdateOpts{
+			Labels: labels,
 		})
 		if err != nil {
 			if resourceSnapshotIsNotFound(err, d) {
@@ -154,7 +159,7 @@ func resourceSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m any)
 }
 
 func resourceSnapshotDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	imageID, err := util.ParseID(d.Id())
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/resourceutil"
 )
 
@@ -16,9 +17,10 @@ type resourceData struct {
 	Fingerprint types.String `tfsdk:"fingerprint"`
 	PublicKey   types.String `tfsdk:"public_key"`
 	Labels      types.Map    `tfsdk:"labels"`
+	LabelsAll   types.Map    `tfsdk:"labels_all"`
 }
 
-func populateResourceData(ctx context.Context, data *resourceData, in *hcloud.SSHKey) diag.Diagnostics {
+func populateResourceData(ctx context.Context, labels hcloudutil.LabelsConfig, data *resourceData, in *hcloud.SSHKey) diag.Diagnostics {
 	var diags diag.Diagnostics
 	var newDiags diag.Diagnostics
 
@@ -27,7 +29,7 @@ func populateResourceData(ctx context.Context, data *resourceData, in *hcloud.SS
 	data.Fingerprint = types.StringValue(in.Fingerprint)
 	data.PublicKey = types.StringValue(in.PublicKey)
 
-	data.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, in.Labels)
+	data.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, labels, in.Labels)
 	diags.Append(newDiags...)
 
 	return diags
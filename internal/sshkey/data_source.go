@@ -30,11 +30,11 @@ type resourceDataWithSelector struct {
 	WithSelector types.String `tfsdk:"with_selector"`
 }
 
-func populateResourceDataWithSelector(ctx context.Context, data *resourceDataWithSelector, in *hcloud.SSHKey) diag.Diagnostics {
+func populateResourceDataWithSelector(ctx context.Context, labels hcloudutil.LabelsConfig, data *resourceDataWithSelector, in *hcloud.SSHKey) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	var resourceDataWithoutSelector resourceData
-	diags.Append(populateResourceData(ctx, &resourceDataWithoutSelector, in)...)
+	diags.Append(populateResourceData(ctx, labels, &resourceDataWithoutSelector, in)...)
 
 	data.ID = types.Int64Value(in.ID)
 	data.Name = resourceDataWithoutSelector.Name
@@ -77,6 +77,7 @@ var _ datasource.DataSourceWithConfigValidators = (*dataSource)(nil)
 
 type dataSource struct {
 	client *hcloud.Client
+	labels hcloudutil.LabelsConfig
 }
 
 func NewDataSource() datasource.DataSource {
@@ -92,13 +93,14 @@ func (d *dataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, r
 // provider-defined DataSource type. It is separately executed for each
 // ReadDataSource RPC.
 func (d *dataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	var newDiags diag.Diagnostics
-
-	d.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
 	resp.Diagnostics.Append(newDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	d.client = providerData.Client
+	d.labels = providerData.Labels
 }
 
 // Schema should return the schema for this data source.
@@ -208,7 +210,7 @@ func (d *dataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 		}
 	}
 
-	resp.Diagnostics.Append(populateResourceDataWithSelector(ctx, &data, result)...)
+	resp.Diagnostics.Append(populateResourceDataWithSelector(ctx, d.labels, &data, result)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
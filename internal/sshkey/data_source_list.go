@@ -24,6 +24,7 @@ var _ datasource.DataSourceWithConfigure = (*dataSourceList)(nil)
 
 type dataSourceList struct {
 	client *hcloud.Client
+	labels hcloudutil.LabelsConfig
 }
 
 func NewDataSourceList() datasource.DataSource {
@@ -39,13 +40,14 @@ func (d *dataSourceList) Metadata(_ context.Context, _ datasource.MetadataReques
 // provider-defined DataSource type. It is separately executed for each
 // ReadDataSource RPC.
 func (d *dataSourceList) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	var newDiags diag.Diagnostics
-
-	d.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
 	resp.Diagnostics.Append(newDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	d.client = providerData.Client
+	d.labels = providerData.Labels
 }
 
 // Schema should return the schema for this data source.
@@ -80,7 +82,7 @@ type resourceDataList struct {
 	WithSelector types.String `tfsdk:"with_selector"`
 }
 
-func populateResourceDataList(ctx context.Context, data *resourceDataList, in []*hcloud.SSHKey) diag.Diagnostics {
+func populateResourceDataList(ctx context.Context, labels hcloudutil.LabelsConfig, data *resourceDataList, in []*hcloud.SSHKey) diag.Diagnostics {
 	var diags diag.Diagnostics
 	var newDiags diag.Diagnostics
 
@@ -102,7 +104,7 @@ func populateResourceDataList(ctx context.Context, data *resourceDataList, in []
 		data.Fingerprint = types.StringValue(in.Fingerprint)
 		data.PublicKey = types.StringValue(in.PublicKey)
 
-		data.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, in.Labels)
+		data.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, labels, in.Labels)
 		diags.Append(newDiags...)
 
 		return diags
@@ -157,7 +159,7 @@ func (d *dataSourceList) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	resp.Diagnostics.Append(populateResourceDataList(ctx, &data, result)...)
+	resp.Diagnostics.Append(populateResourceDataList(ctx, d.labels, &data, result)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
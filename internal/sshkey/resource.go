@@ -3,7 +3,6 @@ package sshkey
 import (
 	"context"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -21,9 +20,11 @@ const ResourceType = "hcloud_ssh_key"
 var _ resource.Resource = (*resourceImpl)(nil)
 var _ resource.ResourceWithConfigure = (*resourceImpl)(nil)
 var _ resource.ResourceWithImportState = (*resourceImpl)(nil)
+var _ resource.ResourceWithModifyPlan = (*resourceImpl)(nil)
 
 type resourceImpl struct {
 	client *hcloud.Client
+	labels hcloudutil.LabelsConfig
 }
 
 func NewResource() resource.Resource {
@@ -39,13 +40,14 @@ func (r *resourceImpl) Metadata(_ context.Context, _ resource.MetadataRequest, r
 // provider-defined DataSource type. It is separately executed for each
 // ReadDataSource RPC.
 func (r *resourceImpl) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	var newDiags diag.Diagnostics
-
-	r.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
 	resp.Diagnostics.Append(newDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	r.client = providerData.Client
+	r.labels = providerData.Labels
 }
 
 func (r *resourceImpl) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -79,10 +81,15 @@ Provides a Hetzner Cloud SSH Key resource to manage SSH Keys for server access.
 				stringplanmodifier.UseStateForUnknown(),
 			},
 		},
-		"labels": resourceutil.LabelsSchema(),
+		"labels":     resourceutil.LabelsSchema(),
+		"labels_all": resourceutil.LabelsAllSchema(),
 	}
 }
 
+func (r *resourceImpl) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resourceutil.ModifyPlanLabelsAll(ctx, r.labels, req, resp)
+}
+
 func (r *resourceImpl) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data resourceData
 
@@ -96,7 +103,7 @@ func (r *resourceImpl) Create(ctx context.Context, req resource.CreateRequest, r
 		PublicKey: data.PublicKey.ValueString(),
 	}
 
-	resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, data.Labels, &opts.Labels)...)
+	resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, data.LabelsAll, &opts.Labels)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -108,7 +115,13 @@ func (r *resourceImpl) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	resp.Diagnostics.Append(populateResourceData(ctx, &data, in)...)
+	configuredLabels := data.Labels
+	resp.Diagnostics.Append(populateResourceData(ctx, r.labels, &data, in)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -141,7 +154,13 @@ func (r *resourceImpl) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	resp.Diagnostics.Append(populateResourceData(ctx, &data, in)...)
+	configuredLabels := data.Labels
+	resp.Diagnostics.Append(populateResourceData(ctx, r.labels, &data, in)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -158,22 +177,29 @@ func (r *resourceImpl) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	id, newDiags := resourceutil.ParseID(data.ID)
+	resp.Diagnostics.Append(newDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	opts := hcloud.SSHKeyUpdateOpts{}
 
 	if !plan.Name.Equal(data.Name) {
 		opts.Name = plan.Name.ValueString()
 	}
 
-	if !plan.Labels.Equal(data.Labels) {
-		resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, plan.Labels, &opts.Labels)...)
-	}
-
-	if resp.Diagnostics.HasError() {
-		return
+	if !plan.LabelsAll.Equal(data.LabelsAll) {
+		opts.Labels, newDiags = resourceutil.LabelsForUpdate(ctx, r.labels, plan.LabelsAll, func() (map[string]string, error) {
+			in, _, err := r.client.SSHKey.GetByID(ctx, id)
+			if err != nil || in == nil {
+				return nil, err
+			}
+			return in.Labels, nil
+		})
+		resp.Diagnostics.Append(newDiags...)
 	}
 
-	id, newDiags := resourceutil.ParseID(data.ID)
-	resp.Diagnostics.Append(newDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -184,7 +210,13 @@ func (r *resourceImpl) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	resp.Diagnostics.Append(populateResourceData(ctx, &data, in)...)
+	configuredLabels := plan.Labels
+	resp.Diagnostics.Append(populateResourceData(ctx, r.labels, &data, in)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -22,6 +22,7 @@ var _ datasource.DataSourceWithConfigure = (*DataSourceList)(nil)
 
 type DataSourceList struct {
 	client *hcloud.Client
+	labels hcloudutil.LabelsConfig
 }
 
 func NewDataSourceList() datasource.DataSource {
@@ -37,13 +38,14 @@ func (d *DataSourceList) Metadata(_ context.Context, _ datasource.MetadataReques
 // provider-defined DataSource type. It is separately executed for each
 // ReadDataSource RPC.
 func (d *DataSourceList) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	var newDiags diag.Diagnostics
-
-	d.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
 	resp.Diagnostics.Append(newDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	d.client = providerData.Client
+	d.labels = providerData.Labels
 }
 
 // Schema should return the schema for this data source.
@@ -72,6 +74,8 @@ type dataSourceListModel struct {
 	StorageBoxes types.List `tfsdk:"storage_boxes"`
 
 	WithSelector types.String `tfsdk:"with_selector"`
+
+	labelsConfig hcloudutil.LabelsConfig
 }
 
 var _ util.ModelFromAPI[[]*hcloud.StorageBox] = &dataSourceListModel{}
@@ -84,6 +88,7 @@ func (m *dataSourceListModel) FromAPI(ctx context.Context, in []*hcloud.StorageB
 
 	for _, item := range in {
 		var value commonModel
+		value.labelsConfig = m.labelsConfig
 		diags.Append(value.FromAPI(ctx, item)...)
 
 		tfItem, newDiags := value.ToTerraform(ctx)
@@ -123,6 +128,7 @@ func (d *DataSourceList) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
+	data.labelsConfig = d.labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, result)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -11,6 +11,7 @@ import (
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/resourceutil"
 )
 
@@ -28,6 +29,9 @@ type commonModel struct {
 	SnapshotPlan     types.Object `tfsdk:"snapshot_plan"`
 
 	// Omitted for Resource: status, stats, created
+
+	// labelsConfig filters the labels ignored by the provider.
+	labelsConfig hcloudutil.LabelsConfig
 }
 
 var _ util.ModelFromAPI[*hcloud.StorageBox] = &commonModel{}
@@ -74,7 +78,7 @@ func (m *commonModel) FromAPI(ctx context.Context, hc *hcloud.StorageBox) diag.D
 	m.Server = types.StringValue(hc.Server)
 	m.System = types.StringValue(hc.System)
 
-	m.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, hc.Labels)
+	m.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, m.labelsConfig, hc.Labels)
 	diags.Append(newDiags...)
 
 	m.DeleteProtection = types.BoolValue(hc.Protection.Delete)
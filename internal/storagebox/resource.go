@@ -30,10 +30,13 @@ const ResourceType = "hcloud_storage_box"
 
 var _ resource.Resource = (*Resource)(nil)
 var _ resource.ResourceWithConfigure = (*Resource)(nil)
+var _ resource.ResourceWithModifyPlan = (*Resource)(nil)
 var _ resource.ResourceWithImportState = (*Resource)(nil)
 
 type Resource struct {
-	client *hcloud.Client
+	client   *hcloud.Client
+	labels   hcloudutil.LabelsConfig
+	defaults hcloudutil.DefaultsConfig
 }
 
 func NewResource() resource.Resource {
@@ -49,13 +52,15 @@ func (r *Resource) Metadata(_ context.Context, _ resource.MetadataRequest, resp
 // provider-defined Resource type. It is separately executed for each
 // ReadResource RPC.
 func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	var newDiags diag.Diagnostics
-
-	r.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
 	resp.Diagnostics.Append(newDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	r.client = providerData.Client
+	r.labels = providerData.Labels
+	r.defaults = providerData.Defaults
 }
 
 // Schema should return the schema for this resource.
@@ -99,10 +104,12 @@ See the [Storage Box API documentation](https://docs.hetzner.cloud/reference/het
 			Required:            true,
 		},
 		"location": schema.StringAttribute{
-			MarkdownDescription: "Name of the Location.",
-			Required:            true,
+			MarkdownDescription: "Name of the Location. Defaults to the provider `default_location`.",
+			Optional:            true,
+			Computed:            true,
 			PlanModifiers: []planmodifier.String{
 				stringplanmodifier.RequiresReplace(),
+				stringplanmodifier.UseStateForUnknown(),
 			},
 		},
 		"password": schema.StringAttribute{
@@ -110,7 +117,8 @@ See the [Storage Box API documentation](https://docs.hetzner.cloud/reference/het
 			Required:            true,
 			Sensitive:           true,
 		},
-		"labels": resourceutil.LabelsSchema(),
+		"labels":     resourceutil.LabelsSchema(),
+		"labels_all": resourceutil.LabelsAllSchema(),
 		"ssh_keys": schema.SetAttribute{
 			MarkdownDescription: "SSH public keys in OpenSSH format to inject into the Storage Box. It is not possible to update the SSH Keys through the API, so changing this attribute forces a replace of the Storage Box.",
 			ElementType:         types.StringType,
@@ -208,11 +216,26 @@ See the [Storage Box API documentation](https://docs.hetzner.cloud/reference/het
 	}
 }
 
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resourceutil.ModifyPlanLabelsAll(ctx, r.labels, req, resp)
+	resourceutil.ModifyPlanDefaultLocation(ctx, r.defaults, req, resp, "location", "")
+
+	// The location is required, unless the provider default location is configured.
+	if req.State.Raw.IsNull() && !req.Plan.Raw.IsNull() && r.defaults.Location == "" && !resourceutil.IsConfigured(req.Config.Raw, "location") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("location"),
+			"Missing location",
+			"The location attribute is required when the provider default_location attribute is not configured.",
+		)
+	}
+}
+
 type resourceModel struct {
 	commonModel
 
-	Password types.String `tfsdk:"password"`
-	SSHKeys  types.Set    `tfsdk:"ssh_keys"`
+	Password  types.String `tfsdk:"password"`
+	SSHKeys   types.Set    `tfsdk:"ssh_keys"`
+	LabelsAll types.Map    `tfsdk:"labels_all"`
 }
 
 var _ util.ModelFromAPI[*hcloud.StorageBox] = &resourceModel{} // reuse commonModel, as the fields from resourceModel are not readable anyway
@@ -222,8 +245,9 @@ func (m *resourceModel) tfAttributesTypes() map[string]attr.Type {
 	return merge.Maps(
 		(&commonModel{}).tfAttributesTypes(),
 		map[string]attr.Type{
-			"password": types.StringType,
-			"ssh_keys": types.SetType{ElemType: types.StringType},
+			"password":   types.StringType,
+			"ssh_keys":   types.SetType{ElemType: types.StringType},
+			"labels_all": types.MapType{ElemType: types.StringType},
 		},
 	)
 }
@@ -247,7 +271,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		Password:       data.Password.ValueString(),
 	}
 
-	resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, data.Labels, &opts.Labels)...)
+	resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, data.LabelsAll, &opts.Labels)...)
 
 	if !data.SSHKeys.IsUnknown() && !data.SSHKeys.IsNull() {
 		sshKeys := make([]string, 0, len(data.SSHKeys.Elements()))
@@ -345,11 +369,17 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	configuredLabels := data.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -372,11 +402,17 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	configuredLabels := data.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -523,8 +559,16 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		opts.Name = plan.Name.ValueString()
 	}
 
-	if !plan.Labels.IsUnknown() && !plan.Labels.Equal(data.Labels) {
-		resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, plan.Labels, &opts.Labels)...)
+	var newDiags diag.Diagnostics
+	if !plan.LabelsAll.IsUnknown() && !plan.LabelsAll.Equal(data.LabelsAll) {
+		opts.Labels, newDiags = resourceutil.LabelsForUpdate(ctx, r.labels, plan.LabelsAll, func() (map[string]string, error) {
+			in, _, err := r.client.StorageBox.GetByID(ctx, storageBox.ID)
+			if err != nil || in == nil {
+				return nil, err
+			}
+			return in.Labels, nil
+		})
+		resp.Diagnostics.Append(newDiags...)
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -540,11 +584,17 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	}
 
 	// Write data to state
+	configuredLabels := plan.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// At this point the change password action was successful.
 	// We have to update the value saved in the state, this does not happen in `data.FromAPI()`.
 	if !plan.Password.IsUnknown() && !plan.Password.Equal(data.Password) {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/resourceutil"
 )
 
@@ -22,6 +23,9 @@ type model struct {
 	StorageBoxID types.Int64  `tfsdk:"storage_box_id"`
 
 	// Omitted for Resource: stats, created
+
+	// labelsConfig filters the labels ignored by the provider.
+	labelsConfig hcloudutil.LabelsConfig
 }
 
 var _ util.ModelFromAPI[*hcloud.StorageBoxSnapshot] = &model{}
@@ -48,7 +52,7 @@ func (m *model) FromAPI(ctx context.Context, hc *hcloud.StorageBoxSnapshot) diag
 	m.IsAutomatic = types.BoolValue(hc.IsAutomatic)
 	m.StorageBoxID = types.Int64Value(hc.StorageBox.ID)
 
-	m.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, hc.Labels)
+	m.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, m.labelsConfig, hc.Labels)
 	diags.Append(newDiags...)
 
 	return diags
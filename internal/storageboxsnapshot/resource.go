@@ -28,10 +28,12 @@ const ResourceType = "hcloud_storage_box_snapshot"
 
 var _ resource.Resource = (*Resource)(nil)
 var _ resource.ResourceWithConfigure = (*Resource)(nil)
+var _ resource.ResourceWithModifyPlan = (*Resource)(nil)
 var _ resource.ResourceWithImportState = (*Resource)(nil)
 
 type Resource struct {
 	client *hcloud.Client
+	labels hcloudutil.LabelsConfig
 }
 
 func NewResource() resource.Resource {
@@ -47,13 +49,14 @@ func (r *Resource) Metadata(_ context.Context, _ resource.MetadataRequest, resp
 // provider-defined Resource type. It is separately executed for each
 // ReadResource RPC.
 func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	var newDiags diag.Diagnostics
-
-	r.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
 	resp.Diagnostics.Append(newDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	r.client = providerData.Client
+	r.labels = providerData.Labels
 }
 
 // Schema should return the schema for this resource.
@@ -100,12 +103,23 @@ See the [Storage Box Snapshots API documentation](https://docs.hetzner.cloud/ref
 				boolplanmodifier.UseStateForUnknown(),
 			},
 		},
-		"labels": resourceutil.LabelsSchema(),
+		"labels":     resourceutil.LabelsSchema(),
+		"labels_all": resourceutil.LabelsAllSchema(),
 	}
 }
 
+type resourceModel struct {
+	model
+
+	LabelsAll types.Map `tfsdk:"labels_all"`
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resourceutil.ModifyPlanLabelsAll(ctx, r.labels, req, resp)
+}
+
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data model
+	var data resourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -120,7 +134,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		Description: data.Description.ValueString(),
 	}
 
-	resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, data.Labels, &opts.Labels)...)
+	resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, data.LabelsAll, &opts.Labels)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -129,7 +143,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	// Create in API
 	// For a single storage box, only a single snapshot can be created simultaneously, all others fail with `locked` error.
 	var result hcloud.StorageBoxSnapshotCreateResult
-	err := control.Retry(2*control.DefaultRetries, func() error {
+	err := control.Retry(ctx, 2*control.DefaultRetries, func() error {
 		var err error
 
 		result, _, err = r.client.StorageBox.CreateSnapshot(ctx, storageBox, opts)
@@ -167,16 +181,22 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	configuredLabels := data.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data model
+	var data resourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -196,16 +216,22 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	configuredLabels := data.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data, plan model
+	var data, plan resourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -224,8 +250,16 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		opts.Description = plan.Description.ValueStringPointer()
 	}
 
-	if !data.Labels.Equal(plan.Labels) {
-		resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, plan.Labels, &opts.Labels)...)
+	var newDiags diag.Diagnostics
+	if !data.LabelsAll.Equal(plan.LabelsAll) {
+		opts.Labels, newDiags = resourceutil.LabelsForUpdate(ctx, r.labels, plan.LabelsAll, func() (map[string]string, error) {
+			in, _, err := r.client.StorageBox.GetSnapshotByID(ctx, snapshot.StorageBox, snapshot.ID)
+			if err != nil || in == nil {
+				return nil, err
+			}
+			return in.Labels, nil
+		})
+		resp.Diagnostics.Append(newDiags...)
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -239,16 +273,22 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	}
 
 	// Write data to state
+	configuredLabels := plan.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data model
+	var data resourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
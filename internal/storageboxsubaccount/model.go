@@ -10,6 +10,7 @@ import (
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/resourceutil"
 )
 
@@ -25,6 +26,9 @@ type model struct {
 	StorageBoxID   types.Int64  `tfsdk:"storage_box_id"`
 
 	// Omitted for Resource: created
+
+	// labelsConfig filters the labels ignored by the provider.
+	labelsConfig hcloudutil.LabelsConfig
 }
 
 var _ util.ModelFromAPI[*hcloud.StorageBoxSubaccount] = &model{}
@@ -60,7 +64,7 @@ func (m *model) FromAPI(ctx context.Context, hc *hcloud.StorageBoxSubaccount) di
 	m.Server = types.StringValue(hc.Server)
 	m.StorageBoxID = types.Int64Value(hc.StorageBox.ID)
 
-	m.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, hc.Labels)
+	m.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, m.labelsConfig, hc.Labels)
 	diags.Append(newDiags...)
 
 	{
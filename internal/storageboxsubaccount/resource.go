@@ -31,10 +31,12 @@ const ResourceType = "hcloud_storage_box_subaccount"
 
 var _ resource.Resource = (*Resource)(nil)
 var _ resource.ResourceWithConfigure = (*Resource)(nil)
+var _ resource.ResourceWithModifyPlan = (*Resource)(nil)
 var _ resource.ResourceWithImportState = (*Resource)(nil)
 
 type Resource struct {
 	client *hcloud.Client
+	labels hcloudutil.LabelsConfig
 }
 
 func NewResource() resource.Resource {
@@ -50,13 +52,14 @@ func (r *Resource) Metadata(_ context.Context, _ resource.MetadataRequest, resp
 // provider-defined Resource type. It is separately executed for each
 // ReadResource RPC.
 func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	var newDiags diag.Diagnostics
-
-	r.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
 	resp.Diagnostics.Append(newDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	r.client = providerData.Client
+	r.labels = providerData.Labels
 }
 
 // Schema should return the schema for this resource.
@@ -164,14 +167,20 @@ See the [Storage Box Subaccounts API documentation](https://docs.hetzner.cloud/r
 				},
 			},
 		},
-		"labels": resourceutil.LabelsSchema(),
+		"labels":     resourceutil.LabelsSchema(),
+		"labels_all": resourceutil.LabelsAllSchema(),
 	}
 }
 
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resourceutil.ModifyPlanLabelsAll(ctx, r.labels, req, resp)
+}
+
 type resourceModel struct {
 	model
 
-	Password types.String `tfsdk:"password"`
+	Password  types.String `tfsdk:"password"`
+	LabelsAll types.Map    `tfsdk:"labels_all"`
 }
 
 var _ util.ModelFromAPI[*hcloud.StorageBoxSubaccount] = &resourceModel{} // reuse model, as the fields from resourceModel are not readable anyway
@@ -181,7 +190,8 @@ func (m *resourceModel) tfAttributesTypes() map[string]attr.Type {
 	return merge.Maps(
 		(&model{}).tfAttributesTypes(),
 		map[string]attr.Type{
-			"password": types.StringType,
+			"password":   types.StringType,
+			"labels_all": types.MapType{ElemType: types.StringType},
 		},
 	)
 }
@@ -209,7 +219,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		Description:   data.Description.ValueString(),
 	}
 
-	resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, data.Labels, &opts.Labels)...)
+	resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, data.LabelsAll, &opts.Labels)...)
 
 	if !data.AccessSettings.IsUnknown() && !data.AccessSettings.IsNull() {
 		m := modelAccessSettings{}
@@ -231,7 +241,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	// Create in API
 	// For a single storage box, only a single subaccount can be created simultaneously, all others fail with `locked` error.
 	var result hcloud.StorageBoxSubaccountCreateResult
-	err := control.Retry(2*control.DefaultRetries, func() error {
+	err := control.Retry(ctx, 2*control.DefaultRetries, func() error {
 		var err error
 
 		result, _, err = r.client.StorageBox.CreateSubaccount(ctx, storageBox, opts)
@@ -269,11 +279,17 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	configuredLabels := data.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -298,11 +314,17 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	configuredLabels := data.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -392,8 +414,16 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		opts.Description = plan.Description.ValueStringPointer()
 	}
 
-	if !data.Labels.Equal(plan.Labels) {
-		resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, plan.Labels, &opts.Labels)...)
+	var newDiags diag.Diagnostics
+	if !data.LabelsAll.Equal(plan.LabelsAll) {
+		opts.Labels, newDiags = resourceutil.LabelsForUpdate(ctx, r.labels, plan.LabelsAll, func() (map[string]string, error) {
+			in, _, err := r.client.StorageBox.GetSubaccountByID(ctx, subaccount.StorageBox, subaccount.ID)
+			if err != nil || in == nil {
+				return nil, err
+			}
+			return in.Labels, nil
+		})
+		resp.Diagnostics.Append(newDiags...)
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -409,11 +439,17 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	}
 
 	// Write data to state
+	configuredLabels := plan.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// At this point the change password action was successful.
 	// We have to update the value saved in the state, this does not happen in `data.FromAPI()`.
 	if !plan.Password.IsUnknown() && !plan.Password.Equal(data.Password) {
@@ -4,12 +4,16 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/joho/godotenv"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/testsupport"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/testsupport/fakeapi"
 )
 
 var (
@@ -53,6 +57,12 @@ func PreCheck(t *testing.T) func() {
 		t.Fatalf("Could not load .env file: %v", err)
 	}
 
+	if UsesFakeAPI() {
+		if err := startFakeAPI(); err != nil {
+			t.Fatalf("Could not start fake API: %v", err)
+		}
+	}
+
 	return func() {
 		if v := os.Getenv("HCLOUD_TOKEN"); v == "" {
 			t.Fatal("HCLOUD_TOKEN must be set for acceptance tests")
@@ -60,6 +70,47 @@ func PreCheck(t *testing.T) func() {
 	}
 }
 
+// UsesFakeAPI reports whether the acceptance tests run against the in-memory fake
+// of the APIs, enabled with TEST_FAKE_API.
+func UsesFakeAPI() bool {
+	useFakeAPI, _ := strconv.ParseBool(os.Getenv("TEST_FAKE_API"))
+	return useFakeAPI
+}
+
+// SkipWithFakeAPI skips the test when it runs against the fake of the APIs, because
+// it relies on something the fake cannot provide, e.g. connecting to a server.
+func SkipWithFakeAPI(t *testing.T, reason string) {
+	t.Helper()
+
+	if err := loadEnvFile(filepath.Join(testsupport.ProjectRoot(t), ".env")); err != nil {
+		t.Fatalf("Could not load .env file: %v", err)
+	}
+	if UsesFakeAPI() {
+		t.Skipf("Skipping with TEST_FAKE_API: %s", reason)
+	}
+}
+
+var startFakeAPI = sync.OnceValue(func() error {
+	// The fake API is shared by all tests of the package and lives until the
+	// test binary exits.
+	server := fakeapi.New()
+
+	env := map[string]string{
+		"HCLOUD_ENDPOINT":  server.Endpoint(),
+		"HETZNER_ENDPOINT": server.HetznerEndpoint(),
+	}
+	if os.Getenv("HCLOUD_TOKEN") == "" {
+		// The fake accepts any token, but the provider requires a well-formed one.
+		env["HCLOUD_TOKEN"] = strings.Repeat("0", 64)
+	}
+	for key, value := range env {
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+})
+
 func loadEnvFile(filename string) error {
 	if _, err := os.Stat(filename); errors.Is(err, os.ErrNotExist) {
 		return nil
@@ -23,6 +23,9 @@ func CreateClient() (*hcloud.Client, error) {
 	if value := os.Getenv("HCLOUD_ENDPOINT"); value != "" {
 		opts = append(opts, hcloud.WithEndpoint(value))
 	}
+	if value := os.Getenv("HETZNER_ENDPOINT"); value != "" {
+		opts = append(opts, hcloud.WithHetznerEndpoint(value))
+	}
 
 	return hcloud.NewClient(opts...), nil
 }
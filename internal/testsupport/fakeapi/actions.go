@@ -0,0 +1,87 @@
+package fakeapi
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+// resourceRef references a resource affected by an action.
+func resourceRef(typ string, id int64) schema.ActionResourceReference {
+	return schema.ActionResourceReference{Type: typ, ID: id}
+}
+
+// newAction records a running action. The action progresses every time it is
+// read, see [WithActionProgressStep].
+func (s *Server) newAction(command string, resources ...schema.ActionResourceReference) schema.Action {
+	action := &schema.Action{
+		ID:        s.nextID(),
+		Command:   command,
+		Status:    "running",
+		Progress:  0,
+		Started:   now(),
+		Resources: resources,
+	}
+	if action.Resources == nil {
+		action.Resources = []schema.ActionResourceReference{}
+	}
+	s.actions[action.ID] = action
+	return *action
+}
+
+// advanceAction moves a running action forward and returns its new state.
+func (s *Server) advanceAction(action *schema.Action) schema.Action {
+	if action.Status != "running" {
+		return *action
+	}
+
+	action.Progress = min(action.Progress+s.progressStep, 100)
+	if action.Progress == 100 {
+		action.Status = "success"
+		action.Finished = ptr(now())
+
+		if actionErr, ok := s.failActions[action.Command]; ok {
+			delete(s.failActions, action.Command)
+			action.Status = "error"
+			action.Error = &actionErr
+		}
+	}
+	return *action
+}
+
+func (s *Server) registerActions() {
+	for _, handle := range []func(string, handlerFunc){s.handle, s.handleHetzner} {
+		handle("GET /actions", s.listActions)
+		handle("GET /actions/{id}", s.getAction)
+	}
+}
+
+func (s *Server) listActions(r *http.Request) (any, error) {
+	query := r.URL.Query()
+	ids := query["id"]
+	statuses := query["status"]
+
+	result := make([]schema.Action, 0)
+	for _, action := range sortedByID(s.actions) {
+		if len(ids) > 0 && !slices.Contains(ids, strconv.FormatInt(action.ID, 10)) {
+			continue
+		}
+		if len(statuses) > 0 && !slices.Contains(statuses, action.Status) {
+			continue
+		}
+		result = append(result, s.advanceAction(action))
+	}
+
+	page, meta := paginate(r, result)
+	return map[string]any{"actions": page, "meta": meta}, nil
+}
+
+func (s *Server) getAction(r *http.Request) (any, error) {
+	action, err := lookup(r, s.actions, "action")
+	if err != nil {
+		return nil, err
+	}
+	return schema.ActionGetResponse{Action: s.advanceAction(action)}, nil
+}
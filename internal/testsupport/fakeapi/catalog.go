@@ -0,0 +1,295 @@
+package fakeapi
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+// seedCatalog fills the read-only collections (locations, server types,
+// system images, ...) with the values used by the provider test suite.
+func (s *Server) seedCatalog() {
+	s.locations = map[int64]*schema.Location{
+		1: {ID: 1, Name: "fsn1", Description: "Falkenstein DC Park 1", Country: "DE", City: "Falkenstein", Latitude: 50.47612, Longitude: 12.370071, NetworkZone: "eu-central"},
+		2: {ID: 2, Name: "nbg1", Description: "Nuremberg DC Park 1", Country: "DE", City: "Nuremberg", Latitude: 49.452102, Longitude: 11.076665, NetworkZone: "eu-central"},
+		3: {ID: 3, Name: "hel1", Description: "Helsinki DC Park 1", Country: "FI", City: "Helsinki", Latitude: 60.169855, Longitude: 24.938379, NetworkZone: "eu-central"},
+		4: {ID: 4, Name: "ash", Description: "Ashburn, VA", Country: "US", City: "Ashburn, VA", Latitude: 39.045821, Longitude: -77.487073, NetworkZone: "us-east"},
+		5: {ID: 5, Name: "hil", Description: "Hillsboro, OR", Country: "US", City: "Hillsboro, OR", Latitude: 45.54222, Longitude: -122.95194, NetworkZone: "us-west"},
+		6: {ID: 6, Name: "sin", Description: "Singapore", Country: "SG", City: "Singapore", Latitude: 1.283333, Longitude: 103.833333, NetworkZone: "ap-southeast"},
+	}
+
+	s.serverTypes = make(map[int64]*schema.ServerType)
+	for _, st := range []schema.ServerType{
+		{ID: 101, Name: "cpx12", Cores: 1, Memory: 2, Disk: 40, CPUType: "shared", Architecture: "x86", Category: "regular_purpose"},
+		{ID: 102, Name: "cpx22", Cores: 2, Memory: 4, Disk: 80, CPUType: "shared", Architecture: "x86", Category: "regular_purpose"},
+		{ID: 103, Name: "cpx32", Cores: 4, Memory: 8, Disk: 160, CPUType: "shared", Architecture: "x86", Category: "regular_purpose"},
+		{ID: 104, Name: "cx23", Cores: 2, Memory: 4, Disk: 40, CPUType: "shared", Architecture: "x86", Category: "cost_optimized"},
+		{ID: 105, Name: "cax11", Cores: 2, Memory: 4, Disk: 40, CPUType: "shared", Architecture: "arm", Category: "cost_optimized"},
+		{ID: 106, Name: "ccx13", Cores: 2, Memory: 8, Disk: 80, CPUType: "dedicated", Architecture: "x86", Category: "general_purpose"},
+	} {
+		st.Description = st.Name
+		st.StorageType = "local"
+		st.IncludedTraffic = 21990232555520
+		for _, location := range sortedByID(s.locations) {
+			st.Prices = append(st.Prices, schema.PricingServerTypePrice{
+				Location:          location.Name,
+				PriceHourly:       schema.Price{Net: "0.0100000000", Gross: "0.0119000000"},
+				PriceMonthly:      schema.Price{Net: "5.0000000000", Gross: "5.9500000000"},
+				IncludedTraffic:   uint64(st.IncludedTraffic),
+				PricePerTBTraffic: schema.Price{Net: "1.0000000000", Gross: "1.1900000000"},
+			})
+			st.Locations = append(st.Locations, schema.ServerTypeLocation{
+				ID:          location.ID,
+				Name:        location.Name,
+				Recommended: true,
+				Available:   true,
+			})
+		}
+		s.serverTypes[st.ID] = &st
+	}
+
+	s.datacenters = make(map[int64]*schema.Datacenter)
+	for id, name := range map[int64]string{1: "fsn1-dc14", 2: "nbg1-dc3", 3: "hel1-dc2", 4: "ash-dc1", 5: "hil-dc1", 6: "sin-dc1"} {
+		var serverTypeIDs []int64
+		for _, st := range sortedByID(s.serverTypes) {
+			serverTypeIDs = append(serverTypeIDs, st.ID)
+		}
+		s.datacenters[id] = &schema.Datacenter{
+			ID:          id,
+			Name:        name,
+			Description: s.locations[id].Description,
+			Location:    *s.locations[id],
+			ServerTypes: &schema.DatacenterServerTypes{
+				Supported:             serverTypeIDs,
+				Available:             serverTypeIDs,
+				AvailableForMigration: serverTypeIDs,
+			},
+		}
+	}
+
+	s.loadBalancerTypes = make(map[int64]*schema.LoadBalancerType)
+	for i, name := range []string{"lb11", "lb21", "lb31"} {
+		id := int64(i + 1)
+		lbt := &schema.LoadBalancerType{
+			ID:                      id,
+			Name:                    name,
+			Description:             name,
+			MaxConnections:          10000 * (i + 1),
+			MaxServices:             5 * (i + 1),
+			MaxTargets:              25 * (i + 1),
+			MaxAssignedCertificates: 10 * (i + 1),
+		}
+		for _, location := range sortedByID(s.locations) {
+			lbt.Prices = append(lbt.Prices, schema.PricingLoadBalancerTypePrice{
+				Location:     location.Name,
+				PriceHourly:  schema.Price{Net: "0.0100000000", Gross: "0.0119000000"},
+				PriceMonthly: schema.Price{Net: "5.0000000000", Gross: "5.9500000000"},
+			})
+		}
+		s.loadBalancerTypes[id] = lbt
+	}
+
+	s.storageBoxTypes = make(map[int64]*schema.StorageBoxType)
+	for i, name := range []string{"bx11", "bx21", "bx31"} {
+		id := int64(i + 1)
+		s.storageBoxTypes[id] = &schema.StorageBoxType{
+			ID:                     id,
+			Name:                   name,
+			Description:            name,
+			SnapshotLimit:          ptr(10 * (i + 1)),
+			AutomaticSnapshotLimit: ptr(10 * (i + 1)),
+			SubaccountsLimit:       100 * (i + 1),
+			Size:                   1099511627776 << i,
+		}
+	}
+
+	s.isos = map[int64]*schema.ISO{
+		1: {ID: 1, Name: "FreeBSD-14.2-RELEASE-amd64-dvd1.iso", Description: "FreeBSD 14.2 x86", Type: "public", Architecture: ptr("x86")},
+	}
+
+	created := time.Date(2024, 4, 25, 12, 0, 0, 0, time.UTC)
+	s.images = make(map[int64]*schema.Image)
+	for _, image := range []struct {
+		id           int64
+		name         string
+		flavor       string
+		version      string
+		architecture string
+	}{
+		{161547269, "ubuntu-24.04", "ubuntu", "24.04", "x86"},
+		{161547270, "ubuntu-24.04", "ubuntu", "24.04", "arm"},
+		{161547271, "ubuntu-22.04", "ubuntu", "22.04", "x86"},
+		{161547272, "ubuntu-22.04", "ubuntu", "22.04", "arm"},
+		{161547273, "debian-12", "debian", "12", "x86"},
+		{161547274, "debian-12", "debian", "12", "arm"},
+	} {
+		s.images[image.id] = &schema.Image{
+			ID:           image.id,
+			Status:       "available",
+			Type:         "system",
+			Name:         ptr(image.name),
+			Description:  image.name,
+			DiskSize:     5,
+			Created:      ptr(created),
+			OSFlavor:     image.flavor,
+			OSVersion:    ptr(image.version),
+			Architecture: image.architecture,
+			RapidDeploy:  true,
+			Labels:       map[string]string{},
+		}
+	}
+	// Make sure IDs generated for new resources never collide with the seeded ones.
+	s.lastID = 200000000
+}
+
+func (s *Server) registerCatalog() {
+	s.handle("GET /locations", func(r *http.Request) (any, error) {
+		return list(r, "locations", s.locations, listFields[schema.Location]{
+			name: func(l *schema.Location) string { return l.Name },
+		}, nil)
+	})
+	s.handle("GET /locations/{id}", func(r *http.Request) (any, error) {
+		location, err := lookup(r, s.locations, "location")
+		if err != nil {
+			return nil, err
+		}
+		return schema.LocationGetResponse{Location: *location}, nil
+	})
+
+	s.handle("GET /datacenters", func(r *http.Request) (any, error) {
+		return list(r, "datacenters", s.datacenters, listFields[schema.Datacenter]{
+			name: func(d *schema.Datacenter) string { return d.Name },
+		}, nil)
+	})
+	s.handle("GET /datacenters/{id}", func(r *http.Request) (any, error) {
+		datacenter, err := lookup(r, s.datacenters, "datacenter")
+		if err != nil {
+			return nil, err
+		}
+		return schema.DatacenterGetResponse{Datacenter: *datacenter}, nil
+	})
+
+	s.handle("GET /server_types", func(r *http.Request) (any, error) {
+		return list(r, "server_types", s.serverTypes, listFields[schema.ServerType]{
+			name: func(st *schema.ServerType) string { return st.Name },
+		}, nil)
+	})
+	s.handle("GET /server_types/{id}", func(r *http.Request) (any, error) {
+		serverType, err := lookup(r, s.serverTypes, "server_type")
+		if err != nil {
+			return nil, err
+		}
+		return schema.ServerTypeGetResponse{ServerType: *serverType}, nil
+	})
+
+	s.handle("GET /load_balancer_types", func(r *http.Request) (any, error) {
+		return list(r, "load_balancer_types", s.loadBalancerTypes, listFields[schema.LoadBalancerType]{
+			name: func(lbt *schema.LoadBalancerType) string { return lbt.Name },
+		}, nil)
+	})
+	s.handle("GET /load_balancer_types/{id}", func(r *http.Request) (any, error) {
+		lbType, err := lookup(r, s.loadBalancerTypes, "load_balancer_type")
+		if err != nil {
+			return nil, err
+		}
+		return schema.LoadBalancerTypeGetResponse{LoadBalancerType: *lbType}, nil
+	})
+
+	s.handle("GET /isos", func(r *http.Request) (any, error) {
+		return list(r, "isos", s.isos, listFields[schema.ISO]{
+			name: func(iso *schema.ISO) string { return iso.Name },
+			filters: map[string]func(*schema.ISO) string{
+				"architecture": func(iso *schema.ISO) string { return *iso.Architecture },
+			},
+		}, nil)
+	})
+	s.handle("GET /isos/{id}", func(r *http.Request) (any, error) {
+		iso, err := lookup(r, s.isos, "iso")
+		if err != nil {
+			return nil, err
+		}
+		return schema.ISOGetResponse{ISO: *iso}, nil
+	})
+
+	s.handleHetzner("GET /storage_box_types", func(r *http.Request) (any, error) {
+		return list(r, "storage_box_types", s.storageBoxTypes, listFields[schema.StorageBoxType]{
+			name: func(sbt *schema.StorageBoxType) string { return sbt.Name },
+		}, nil)
+	})
+	s.handleHetzner("GET /storage_box_types/{id}", func(r *http.Request) (any, error) {
+		sbType, err := lookup(r, s.storageBoxTypes, "storage_box_type")
+		if err != nil {
+			return nil, err
+		}
+		return schema.StorageBoxTypeGetResponse{StorageBoxType: *sbType}, nil
+	})
+}
+
+// findLocation returns the location with the given ID or name.
+func (s *Server) findLocation(idOrName string) (*schema.Location, bool) {
+	for _, location := range s.locations {
+		if location.Name == idOrName || strconv.FormatInt(location.ID, 10) == idOrName {
+			return location, true
+		}
+	}
+	return nil, false
+}
+
+// findDatacenter returns the datacenter with the given ID or name.
+func (s *Server) findDatacenter(idOrName string) (*schema.Datacenter, bool) {
+	for _, datacenter := range s.datacenters {
+		if datacenter.Name == idOrName || strconv.FormatInt(datacenter.ID, 10) == idOrName {
+			return datacenter, true
+		}
+	}
+	return nil, false
+}
+
+// datacenterIn returns the datacenter of a location.
+func (s *Server) datacenterIn(location schema.Location) *schema.Datacenter {
+	for _, datacenter := range s.datacenters {
+		if datacenter.Location.ID == location.ID {
+			return datacenter
+		}
+	}
+	return nil
+}
+
+// resolveLocation resolves the location from either a location or a
+// datacenter name, both optional.
+func (s *Server) resolveLocation(location, datacenter string) (*schema.Location, error) {
+	switch {
+	case location != "":
+		l, ok := s.findLocation(location)
+		if !ok {
+			return nil, errInvalidInput("location %q not found", location)
+		}
+		return l, nil
+	case datacenter != "":
+		d, ok := s.findDatacenter(datacenter)
+		if !ok {
+			return nil, errInvalidInput("datacenter %q not found", datacenter)
+		}
+		return s.locations[d.Location.ID], nil
+	default:
+		return nil, nil
+	}
+}
+
+func findByIDOrName[T any](items map[int64]*T, value schema.IDOrName, fields func(*T) (int64, string)) (*T, bool) {
+	for _, item := range sortedByID(items) {
+		id, name := fields(item)
+		if (value.ID != 0 && id == value.ID) || (value.Name != "" && name == value.Name) {
+			return item, true
+		}
+	}
+	return nil, false
+}
+
+func serverTypeFields(st *schema.ServerType) (int64, string) { return st.ID, st.Name }
+
+func loadBalancerTypeFields(lbt *schema.LoadBalancerType) (int64, string) { return lbt.ID, lbt.Name }
+
+func storageBoxTypeFields(sbt *schema.StorageBoxType) (int64, string) { return sbt.ID, sbt.Name }
@@ -0,0 +1,213 @@
+package fakeapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+func certificateFields(c *schema.Certificate) (int64, string) { return c.ID, c.Name }
+
+// certificateView returns the certificate with the Load Balancers using it filled in.
+func (s *Server) certificateView(certificate *schema.Certificate) schema.Certificate {
+	view := *certificate
+
+	view.UsedBy = []schema.CertificateUsedByRef{}
+	for _, lb := range sortedByID(s.loadBalancers) {
+		for _, service := range lb.Services {
+			if service.HTTP != nil && slices.Contains(service.HTTP.Certificates, certificate.ID) {
+				view.UsedBy = append(view.UsedBy, schema.CertificateUsedByRef{ID: lb.ID, Type: "load_balancer"})
+				break
+			}
+		}
+	}
+	return view
+}
+
+// setCertificate fills the certificate attributes from the PEM encoded
+// certificate.
+func setCertificate(certificate *schema.Certificate, certPEM string) error {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return errInvalidInput("invalid certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return errInvalidInput("invalid certificate: %v", err)
+	}
+
+	sum := sha256.Sum256(cert.Raw)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+
+	certificate.Certificate = certPEM
+	certificate.Fingerprint = strings.Join(parts, ":")
+	certificate.NotValidBefore = cert.NotBefore
+	certificate.NotValidAfter = cert.NotAfter
+	certificate.DomainNames = slices.Clone(cert.DNSNames)
+	if len(certificate.DomainNames) == 0 && cert.Subject.CommonName != "" {
+		certificate.DomainNames = []string{cert.Subject.CommonName}
+	}
+	return nil
+}
+
+// issueCertificate returns a self-signed PEM encoded certificate for the domain
+// names, standing in for a certificate issued by Let's Encrypt.
+func issueCertificate(domainNames []string) (string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: domainNames[0]},
+		DNSNames:     domainNames,
+		NotBefore:    now(),
+		NotAfter:     now().AddDate(0, 3, 0),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), nil
+}
+
+func (s *Server) registerCertificates() {
+	s.handle("GET /certificates", func(r *http.Request) (any, error) {
+		return list(r, "certificates", s.certificates, listFields[schema.Certificate]{
+			id:     func(c *schema.Certificate) int64 { return c.ID },
+			name:   func(c *schema.Certificate) string { return c.Name },
+			labels: func(c *schema.Certificate) map[string]string { return c.Labels },
+			filters: map[string]func(*schema.Certificate) string{
+				"type": func(c *schema.Certificate) string { return c.Type },
+			},
+		}, s.certificateView)
+	})
+
+	s.handle("GET /certificates/{id}", func(r *http.Request) (any, error) {
+		certificate, err := lookup(r, s.certificates, "certificate")
+		if err != nil {
+			return nil, err
+		}
+		return schema.CertificateGetResponse{Certificate: s.certificateView(certificate)}, nil
+	})
+
+	s.handle("POST /certificates", func(r *http.Request) (any, error) {
+		req, err := decode[schema.CertificateCreateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name == "" {
+			return nil, errInvalidInput("name is required")
+		}
+		if nameTaken(s.certificates, 0, req.Name, certificateFields) {
+			return nil, errUniqueness("name")
+		}
+		if req.Type == "" {
+			req.Type = "uploaded"
+		}
+
+		certificate := &schema.Certificate{
+			ID:      s.nextID(),
+			Name:    req.Name,
+			Type:    req.Type,
+			Labels:  copyLabels(req.Labels),
+			Created: now(),
+		}
+
+		resp := schema.CertificateCreateResponse{}
+		switch req.Type {
+		case "uploaded":
+			if block, _ := pem.Decode([]byte(req.PrivateKey)); block == nil {
+				return nil, errInvalidInput("invalid private_key")
+			}
+			if err := setCertificate(certificate, req.Certificate); err != nil {
+				return nil, err
+			}
+
+		case "managed":
+			if len(req.DomainNames) == 0 {
+				return nil, errInvalidInput("domain_names is required")
+			}
+			// The certificate is issued right away, the issuance action only
+			// reports its progress.
+			certPEM, err := issueCertificate(req.DomainNames)
+			if err != nil {
+				return nil, err
+			}
+			if err := setCertificate(certificate, certPEM); err != nil {
+				return nil, err
+			}
+			certificate.Status = &schema.CertificateStatusRef{Issuance: "completed", Renewal: "unavailable"}
+			resp.Action = ptr(s.newAction("create_certificate", resourceRef("certificate", certificate.ID)))
+
+		default:
+			return nil, errInvalidInput("type must be one of uploaded, managed")
+		}
+
+		s.certificates[certificate.ID] = certificate
+		resp.Certificate = s.certificateView(certificate)
+		return resp, nil
+	})
+
+	s.handle("PUT /certificates/{id}", func(r *http.Request) (any, error) {
+		certificate, err := lookup(r, s.certificates, "certificate")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.CertificateUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name != nil {
+			if nameTaken(s.certificates, certificate.ID, *req.Name, certificateFields) {
+				return nil, errUniqueness("name")
+			}
+			certificate.Name = *req.Name
+		}
+		if req.Labels != nil {
+			certificate.Labels = copyLabels(req.Labels)
+		}
+		return schema.CertificateUpdateResponse{Certificate: s.certificateView(certificate)}, nil
+	})
+
+	s.handle("DELETE /certificates/{id}", func(r *http.Request) (any, error) {
+		certificate, err := lookup(r, s.certificates, "certificate")
+		if err != nil {
+			return nil, err
+		}
+		if len(s.certificateView(certificate).UsedBy) > 0 {
+			return nil, errConflict("resource_in_use", "certificate is used by a load balancer")
+		}
+		delete(s.certificates, certificate.ID)
+		return nil, nil
+	})
+
+	s.handle("POST /certificates/{id}/actions/retry", func(r *http.Request) (any, error) {
+		certificate, err := lookup(r, s.certificates, "certificate")
+		if err != nil {
+			return nil, err
+		}
+		if certificate.Type != "managed" {
+			return nil, errInvalidInput("only managed certificates can be retried")
+		}
+		return schema.CertificateIssuanceRetryResponse{
+			Action: s.newAction("issue_certificate", resourceRef("certificate", certificate.ID)),
+		}, nil
+	})
+}
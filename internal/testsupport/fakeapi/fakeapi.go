@@ -0,0 +1,339 @@
+// Package fakeapi implements an in-memory stand-in for the Hetzner Cloud and
+// Hetzner APIs.
+//
+// The fake keeps all resources in memory and answers requests with the same
+// schema as the real APIs, so the provider and the hcloud-go client can be
+// exercised without network access or a real project. It is not a complete
+// reimplementation of the APIs: it covers the endpoints used by the provider
+// test suite, and validates only the most common invalid inputs.
+package fakeapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+const (
+	cloudPrefix   = "/v1"
+	hetznerPrefix = "/hetzner/v1"
+)
+
+// Option configures a [Server].
+type Option func(*Server)
+
+// WithToken only accepts requests authenticated with the given bearer token.
+// By default, any non-empty token is accepted.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithActionProgressStep sets by how many percent the progress of a running
+// action advances every time it is read. Defaults to 50, so an action
+// completes after two reads.
+func WithActionProgressStep(step int) Option {
+	return func(s *Server) {
+		s.progressStep = step
+	}
+}
+
+// WithShutdownIgnored makes servers ignore graceful shutdown requests, like a
+// guest operating system without ACPI support. The shutdown action succeeds, but
+// the servers keep running.
+func WithShutdownIgnored() Option {
+	return func(s *Server) {
+		s.ignoreShutdown = true
+	}
+}
+
+// Server is an in-memory fake of the Hetzner Cloud and Hetzner APIs.
+type Server struct {
+	mu sync.Mutex
+
+	httpServer *httptest.Server
+	mux        *http.ServeMux
+
+	token          string
+	progressStep   int
+	ignoreShutdown bool
+	failActions    map[string]schema.ActionError
+
+	lastID   int64
+	lastIPv4 int
+	lastIPv6 int
+
+	locations         map[int64]*schema.Location
+	datacenters       map[int64]*schema.Datacenter
+	serverTypes       map[int64]*schema.ServerType
+	loadBalancerTypes map[int64]*schema.LoadBalancerType
+	storageBoxTypes   map[int64]*schema.StorageBoxType
+	isos              map[int64]*schema.ISO
+	images            map[int64]*schema.Image
+
+	actions               map[int64]*schema.Action
+	sshKeys               map[int64]*schema.SSHKey
+	servers               map[int64]*schema.Server
+	networks              map[int64]*schema.Network
+	volumes               map[int64]*schema.Volume
+	firewalls             map[int64]*schema.Firewall
+	loadBalancers         map[int64]*schema.LoadBalancer
+	primaryIPs            map[int64]*schema.PrimaryIP
+	floatingIPs           map[int64]*schema.FloatingIP
+	placementGroups       map[int64]*schema.PlacementGroup
+	certificates          map[int64]*schema.Certificate
+	zones                 map[int64]*schema.Zone
+	rrsets                map[int64]*schema.ZoneRRSet
+	storageBoxes          map[int64]*schema.StorageBox
+	storageBoxSnapshots   map[int64]*schema.StorageBoxSnapshot
+	storageBoxSubaccounts map[int64]*schema.StorageBoxSubaccount
+}
+
+// New starts a new fake API server listening on a random local port. The
+// server must be stopped with [Server.Close].
+func New(opts ...Option) *Server {
+	s := &Server{
+		progressStep: 50,
+		failActions:  make(map[string]schema.ActionError),
+
+		actions:               make(map[int64]*schema.Action),
+		sshKeys:               make(map[int64]*schema.SSHKey),
+		servers:               make(map[int64]*schema.Server),
+		networks:              make(map[int64]*schema.Network),
+		volumes:               make(map[int64]*schema.Volume),
+		firewalls:             make(map[int64]*schema.Firewall),
+		loadBalancers:         make(map[int64]*schema.LoadBalancer),
+		primaryIPs:            make(map[int64]*schema.PrimaryIP),
+		floatingIPs:           make(map[int64]*schema.FloatingIP),
+		placementGroups:       make(map[int64]*schema.PlacementGroup),
+		certificates:          make(map[int64]*schema.Certificate),
+		zones:                 make(map[int64]*schema.Zone),
+		rrsets:                make(map[int64]*schema.ZoneRRSet),
+		storageBoxes:          make(map[int64]*schema.StorageBox),
+		storageBoxSnapshots:   make(map[int64]*schema.StorageBoxSnapshot),
+		storageBoxSubaccounts: make(map[int64]*schema.StorageBoxSubaccount),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.progressStep <= 0 || s.progressStep > 100 {
+		s.progressStep = 100
+	}
+
+	s.seedCatalog()
+
+	s.mux = http.NewServeMux()
+	s.registerCatalog()
+	s.registerActions()
+	s.registerSSHKeys()
+	s.registerImages()
+	s.registerServers()
+	s.registerNetworks()
+	s.registerVolumes()
+	s.registerFirewalls()
+	s.registerLoadBalancers()
+	s.registerPrimaryIPs()
+	s.registerFloatingIPs()
+	s.registerPlacementGroups()
+	s.registerCertificates()
+	s.registerZones()
+	s.registerStorageBoxes()
+	s.registerMetrics()
+
+	s.httpServer = httptest.NewServer(s)
+	return s
+}
+
+// Endpoint returns the URL of the fake Hetzner Cloud API, to be used as
+// HCLOUD_ENDPOINT.
+func (s *Server) Endpoint() string {
+	return s.httpServer.URL + cloudPrefix
+}
+
+// HetznerEndpoint returns the URL of the fake Hetzner API, to be used as
+// HETZNER_ENDPOINT.
+func (s *Server) HetznerEndpoint() string {
+	return s.httpServer.URL + hetznerPrefix
+}
+
+// Close stops the server.
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// FailNextAction makes the next action with the given command end with the
+// given error instead of succeeding.
+func (s *Server) FailNextAction(command, code, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failActions[command] = schema.ActionError{Code: code, Message: message}
+}
+
+// ServeHTTP implements [http.Handler].
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || auth == "" || (s.token != "" && auth != s.token) {
+		writeError(w, &apiError{status: http.StatusUnauthorized, code: "unauthorized", message: "unable to authenticate"})
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// handlerFunc handles a request and returns the response body. A nil body is
+// answered with 204 No Content.
+type handlerFunc func(r *http.Request) (any, error)
+
+// handle registers the handler for the pattern "<METHOD> <path>" on the Cloud
+// API, serializing all calls to the handlers.
+func (s *Server) handle(pattern string, fn handlerFunc) {
+	s.handlePrefix(cloudPrefix, pattern, fn)
+}
+
+// handleHetzner is like [Server.handle] for the Hetzner API.
+func (s *Server) handleHetzner(pattern string, fn handlerFunc) {
+	s.handlePrefix(hetznerPrefix, pattern, fn)
+}
+
+func (s *Server) handlePrefix(prefix, pattern string, fn handlerFunc) {
+	method, path, _ := strings.Cut(pattern, " ")
+	s.mux.HandleFunc(method+" "+prefix+path, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		body, err := fn(r)
+		s.mu.Unlock()
+
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if body == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, http.StatusOK, body)
+	})
+}
+
+func (s *Server) nextID() int64 {
+	s.lastID++
+	return s.lastID
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	apiErr := &apiError{}
+	if !errors.As(err, &apiErr) {
+		apiErr = &apiError{status: http.StatusInternalServerError, code: "server_error", message: err.Error()}
+	}
+	writeJSON(w, apiErr.status, schema.ErrorResponse{Error: schema.Error{Code: apiErr.code, Message: apiErr.message}})
+}
+
+// apiError is an error returned to the client in the API error format.
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s (%s)", e.message, e.code)
+}
+
+func errNotFound(kind string) error {
+	return &apiError{status: http.StatusNotFound, code: "not_found", message: kind + " not found"}
+}
+
+func errInvalidInput(format string, args ...any) error {
+	return &apiError{status: http.StatusBadRequest, code: "invalid_input", message: fmt.Sprintf(format, args...)}
+}
+
+func errUniqueness(field string) error {
+	return &apiError{status: http.StatusConflict, code: "uniqueness_error", message: field + " is already used"}
+}
+
+func errProtected(kind string) error {
+	return &apiError{status: http.StatusLocked, code: "protected", message: kind + " is protected"}
+}
+
+func errConflict(code, message string) error {
+	return &apiError{status: http.StatusConflict, code: code, message: message}
+}
+
+// decode reads the JSON request body into a value of type T.
+func decode[T any](r *http.Request) (T, error) {
+	var body T
+	if r.Body == nil || r.ContentLength == 0 {
+		return body, nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return body, errInvalidInput("invalid request body: %v", err)
+	}
+	return body, nil
+}
+
+// pathID parses the path value name as a resource ID.
+func pathID(r *http.Request, name string) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue(name), 10, 64)
+	if err != nil {
+		return 0, errInvalidInput("invalid %s: %q", name, r.PathValue(name))
+	}
+	return id, nil
+}
+
+// lookup returns the item with the ID in the path value "id".
+func lookup[T any](r *http.Request, items map[int64]*T, kind string) (*T, error) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	item, ok := items[id]
+	if !ok {
+		return nil, errNotFound(kind)
+	}
+	return item, nil
+}
+
+// copyLabels returns a copy of the labels, never nil.
+func copyLabels(labels *map[string]string) map[string]string {
+	result := make(map[string]string)
+	if labels != nil {
+		for key, value := range *labels {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// nameTaken reports whether another item than id already uses the name.
+func nameTaken[T any](items map[int64]*T, id int64, name string, fields func(*T) (int64, string)) bool {
+	for _, item := range items {
+		itemID, itemName := fields(item)
+		if itemID != id && itemName == name {
+			return true
+		}
+	}
+	return false
+}
+
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
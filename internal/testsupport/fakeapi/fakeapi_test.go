@@ -0,0 +1,532 @@
+package fakeapi_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/testsupport"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/testsupport/fakeapi"
+)
+
+func newClient(t *testing.T, opts ...fakeapi.Option) (*fakeapi.Server, *hcloud.Client) {
+	t.Helper()
+
+	server := fakeapi.New(opts...)
+	t.Cleanup(server.Close)
+
+	client := hcloud.NewClient(
+		hcloud.WithEndpoint(server.Endpoint()),
+		hcloud.WithHetznerEndpoint(server.HetznerEndpoint()),
+		hcloud.WithToken("token"),
+		hcloud.WithPollOpts(hcloud.PollOpts{BackoffFunc: hcloud.ConstantBackoff(time.Millisecond)}),
+	)
+	return server, client
+}
+
+func TestAuthentication(t *testing.T) {
+	_, client := newClient(t, fakeapi.WithToken("other"))
+
+	_, _, err := client.Location.GetByName(t.Context(), "fsn1")
+	assert.True(t, hcloud.IsError(err, hcloud.ErrorCodeUnauthorized))
+}
+
+func TestActionProgress(t *testing.T) {
+	server, client := newClient(t, fakeapi.WithActionProgressStep(25))
+	ctx := t.Context()
+
+	result, _, err := client.Zone.Create(ctx, hcloud.ZoneCreateOpts{Name: "example.com", Mode: hcloud.ZoneModePrimary})
+	require.NoError(t, err)
+	assert.Equal(t, hcloud.ActionStatusRunning, result.Action.Status)
+
+	var progress []int
+	for range 4 {
+		action, _, err := client.Action.GetByID(ctx, result.Action.ID)
+		require.NoError(t, err)
+		progress = append(progress, action.Progress)
+	}
+	assert.Equal(t, []int{25, 50, 75, 100}, progress)
+
+	server.FailNextAction("change_ttl", "failed", "change failed")
+	action, _, err := client.Zone.ChangeTTL(ctx, result.Zone, hcloud.ZoneChangeTTLOpts{TTL: 60})
+	require.NoError(t, err)
+	err = client.Action.WaitFor(ctx, action)
+	assert.ErrorContains(t, err, "change failed")
+}
+
+func TestPagination(t *testing.T) {
+	_, client := newClient(t)
+	ctx := t.Context()
+
+	for i := range 30 {
+		_, _, err := client.SSHKey.Create(ctx, hcloud.SSHKeyCreateOpts{
+			Name:      fmt.Sprintf("key-%d", i),
+			PublicKey: "ssh-ed25519 " + base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(i))),
+			Labels:    map[string]string{"index": fmt.Sprint(i % 2)},
+		})
+		require.NoError(t, err)
+	}
+
+	page, resp, err := client.SSHKey.List(ctx, hcloud.SSHKeyListOpts{})
+	require.NoError(t, err)
+	assert.Len(t, page, 25)
+	assert.Equal(t, 2, resp.Meta.Pagination.NextPage)
+
+	all, err := client.SSHKey.All(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 30)
+
+	evens, err := client.SSHKey.AllWithOpts(ctx, hcloud.SSHKeyListOpts{ListOpts: hcloud.ListOpts{LabelSelector: "index=0"}})
+	require.NoError(t, err)
+	assert.Len(t, evens, 15)
+
+	_, _, err = client.SSHKey.List(ctx, hcloud.SSHKeyListOpts{ListOpts: hcloud.ListOpts{LabelSelector: "a in (b"}})
+	assert.True(t, hcloud.IsError(err, hcloud.ErrorCodeInvalidInput))
+}
+
+func TestServer(t *testing.T) {
+	_, client := newClient(t)
+	ctx := t.Context()
+
+	network, _, err := client.Network.Create(ctx, hcloud.NetworkCreateOpts{
+		Name:    "network",
+		IPRange: mustParseCIDR(t, "10.0.0.0/16"),
+		Subnets: []hcloud.NetworkSubnet{{
+			Type:        hcloud.NetworkSubnetTypeCloud,
+			IPRange:     mustParseCIDR(t, "10.0.1.0/24"),
+			NetworkZone: hcloud.NetworkZoneEUCentral,
+		}},
+	})
+	require.NoError(t, err)
+
+	result, _, err := client.Server.Create(ctx, hcloud.ServerCreateOpts{
+		Name:       "server",
+		ServerType: &hcloud.ServerType{Name: "cpx22"},
+		Image:      &hcloud.Image{Name: "ubuntu-24.04"},
+		Location:   &hcloud.Location{Name: "hel1"},
+		Networks:   []*hcloud.Network{network},
+		Labels:     map[string]string{"key": "value"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, append([]*hcloud.Action{result.Action}, result.NextActions...)...))
+	assert.NotEmpty(t, result.RootPassword)
+
+	server, _, err := client.Server.GetByID(ctx, result.Server.ID)
+	require.NoError(t, err)
+	assert.Equal(t, hcloud.ServerStatusRunning, server.Status)
+	assert.Equal(t, "hel1-dc2", server.Datacenter.Name)
+	assert.NotNil(t, server.PublicNet.IPv4.IP)
+	require.Len(t, server.PrivateNet, 1)
+	assert.Equal(t, "10.0.1.1", server.PrivateNet[0].IP.String())
+
+	_, _, err = client.Server.Create(ctx, hcloud.ServerCreateOpts{
+		Name:       "server",
+		ServerType: &hcloud.ServerType{Name: "cpx22"},
+		Image:      &hcloud.Image{Name: "ubuntu-24.04"},
+	})
+	assert.True(t, hcloud.IsError(err, hcloud.ErrorCodeUniquenessError))
+
+	action, _, err := client.Server.ChangeType(ctx, server, hcloud.ServerChangeTypeOpts{ServerType: &hcloud.ServerType{Name: "cpx32"}})
+	assert.True(t, hcloud.IsError(err, hcloud.ErrorCodeServerNotStopped))
+	assert.Nil(t, action)
+
+	action, _, err = client.Server.Poweroff(ctx, server)
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, action))
+
+	action, _, err = client.Server.ChangeType(ctx, server, hcloud.ServerChangeTypeOpts{ServerType: &hcloud.ServerType{Name: "cpx32"}})
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, action))
+
+	server, _, err = client.Server.GetByID(ctx, server.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "cpx32", server.ServerType.Name)
+	assert.Equal(t, hcloud.ServerStatusOff, server.Status)
+
+	network, _, err = client.Network.GetByID(ctx, network.ID)
+	require.NoError(t, err)
+	assert.Len(t, network.Servers, 1)
+
+	deleteResult, _, err := client.Server.DeleteWithResult(ctx, server)
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, deleteResult.Action))
+
+	server, _, err = client.Server.GetByID(ctx, server.ID)
+	require.NoError(t, err)
+	assert.Nil(t, server)
+
+	primaryIPs, err := client.PrimaryIP.All(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, primaryIPs, "auto deleted primary IPs must be deleted with the server")
+}
+
+func TestServerMetrics(t *testing.T) {
+	_, client := newClient(t)
+	ctx := t.Context()
+
+	result, _, err := client.Server.Create(ctx, hcloud.ServerCreateOpts{
+		Name:       "server",
+		ServerType: &hcloud.ServerType{Name: "cpx22"},
+		Image:      &hcloud.Image{Name: "ubuntu-24.04"},
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	metrics, _, err := client.Server.GetMetrics(ctx, result.Server, hcloud.ServerGetMetricsOpts{
+		Types: []hcloud.ServerMetricType{hcloud.ServerMetricCPU, hcloud.ServerMetricDisk},
+		Start: start,
+		End:   start.Add(10 * time.Minute),
+		Step:  60,
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 60, metrics.Step, 0)
+	assert.Len(t, metrics.TimeSeries, 5)
+	require.Len(t, metrics.TimeSeries["cpu"], 11)
+	assert.Equal(t, hcloud.ServerMetricsValue{Timestamp: float64(start.Unix()) + 60, Value: "1"}, metrics.TimeSeries["cpu"][1])
+}
+
+func TestVolumeAndFirewall(t *testing.T) {
+	_, client := newClient(t)
+	ctx := t.Context()
+
+	result, _, err := client.Server.Create(ctx, hcloud.ServerCreateOpts{
+		Name:       "server",
+		ServerType: &hcloud.ServerType{Name: "cpx22"},
+		Image:      &hcloud.Image{Name: "ubuntu-24.04"},
+		Labels:     map[string]string{"role": "web"},
+	})
+	require.NoError(t, err)
+
+	volume, _, err := client.Volume.Create(ctx, hcloud.VolumeCreateOpts{Name: "volume", Size: 10, Server: result.Server})
+	require.NoError(t, err)
+	assert.Equal(t, result.Server.ID, volume.Volume.Server.ID)
+
+	_, err = client.Volume.Delete(ctx, volume.Volume)
+	assert.True(t, hcloud.IsError(err, "volume_attached"))
+
+	firewall, _, err := client.Firewall.Create(ctx, hcloud.FirewallCreateOpts{
+		Name: "firewall",
+		ApplyTo: []hcloud.FirewallResource{{
+			Type:          hcloud.FirewallResourceTypeLabelSelector,
+			LabelSelector: &hcloud.FirewallResourceLabelSelector{Selector: "role=web"},
+		}},
+	})
+	require.NoError(t, err)
+
+	fw, _, err := client.Firewall.GetByID(ctx, firewall.Firewall.ID)
+	require.NoError(t, err)
+	require.Len(t, fw.AppliedTo, 1)
+	assert.Len(t, fw.AppliedTo[0].AppliedToResources, 1)
+
+	server, _, err := client.Server.GetByID(ctx, result.Server.ID)
+	require.NoError(t, err)
+	assert.Len(t, server.Volumes, 1)
+	assert.Len(t, server.PublicNet.Firewalls, 1)
+
+	_, err = client.Firewall.Delete(ctx, fw)
+	assert.True(t, hcloud.IsError(err, hcloud.ErrorCodeResourceInUse))
+}
+
+func TestLoadBalancer(t *testing.T) {
+	_, client := newClient(t)
+	ctx := t.Context()
+
+	result, _, err := client.LoadBalancer.Create(ctx, hcloud.LoadBalancerCreateOpts{
+		Name:             "lb",
+		LoadBalancerType: &hcloud.LoadBalancerType{Name: "lb11"},
+		Location:         &hcloud.Location{Name: "nbg1"},
+		Algorithm:        &hcloud.LoadBalancerAlgorithm{Type: hcloud.LoadBalancerAlgorithmTypeRoundRobin},
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, result.Action))
+
+	action, _, err := client.LoadBalancer.AddService(ctx, result.LoadBalancer, hcloud.LoadBalancerAddServiceOpts{
+		Protocol: hcloud.LoadBalancerServiceProtocolHTTP,
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, action))
+
+	_, _, err = client.LoadBalancer.AddService(ctx, result.LoadBalancer, hcloud.LoadBalancerAddServiceOpts{
+		Protocol: hcloud.LoadBalancerServiceProtocolHTTP,
+	})
+	assert.True(t, hcloud.IsError(err, hcloud.ErrorCodeSourcePortAlreadyUsed))
+
+	lb, _, err := client.LoadBalancer.GetByName(ctx, "lb")
+	require.NoError(t, err)
+	require.Len(t, lb.Services, 1)
+	assert.Equal(t, 80, lb.Services[0].DestinationPort)
+	assert.Equal(t, "/", lb.Services[0].HealthCheck.HTTP.Path)
+}
+
+func TestLoadBalancerMetrics(t *testing.T) {
+	_, client := newClient(t)
+	ctx := t.Context()
+
+	result, _, err := client.LoadBalancer.Create(ctx, hcloud.LoadBalancerCreateOpts{
+		Name:             "lb",
+		LoadBalancerType: &hcloud.LoadBalancerType{Name: "lb11"},
+		Location:         &hcloud.Location{Name: "nbg1"},
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	metrics, _, err := client.LoadBalancer.GetMetrics(ctx, result.LoadBalancer, hcloud.LoadBalancerGetMetricsOpts{
+		Types: []hcloud.LoadBalancerMetricType{hcloud.LoadBalancerMetricOpenConnections, hcloud.LoadBalancerMetricBandwidth},
+		Start: start,
+		End:   start.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 36, metrics.Step, 0)
+	assert.Len(t, metrics.TimeSeries, 3)
+	require.Len(t, metrics.TimeSeries["bandwidth.out"], 101)
+	assert.Equal(t, hcloud.LoadBalancerMetricsValue{Timestamp: float64(start.Unix()) + 36, Value: "1"}, metrics.TimeSeries["bandwidth.out"][1])
+}
+
+func TestPrimaryIP(t *testing.T) {
+	_, client := newClient(t)
+	ctx := t.Context()
+
+	result, _, err := client.PrimaryIP.Create(ctx, hcloud.PrimaryIPCreateOpts{
+		Name:         "ip",
+		Type:         hcloud.PrimaryIPTypeIPv4,
+		AssigneeType: "server",
+		Location:     "fsn1",
+	})
+	require.NoError(t, err)
+
+	server, _, err := client.Server.Create(ctx, hcloud.ServerCreateOpts{
+		Name:       "server",
+		ServerType: &hcloud.ServerType{Name: "cpx22"},
+		Image:      &hcloud.Image{Name: "ubuntu-24.04"},
+		Location:   &hcloud.Location{Name: "fsn1"},
+		PublicNet:  &hcloud.ServerCreatePublicNet{EnableIPv4: true, IPv4: result.PrimaryIP},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, result.PrimaryIP.IP.String(), server.Server.PublicNet.IPv4.IP.String())
+
+	_, _, err = client.PrimaryIP.Unassign(ctx, result.PrimaryIP.ID)
+	assert.True(t, hcloud.IsError(err, hcloud.ErrorCodeServerNotStopped))
+}
+
+func TestFloatingIP(t *testing.T) {
+	_, client := newClient(t)
+	ctx := t.Context()
+
+	server, _, err := client.Server.Create(ctx, hcloud.ServerCreateOpts{
+		Name:       "server",
+		ServerType: &hcloud.ServerType{Name: "cpx22"},
+		Image:      &hcloud.Image{Name: "ubuntu-24.04"},
+		Location:   &hcloud.Location{Name: "fsn1"},
+	})
+	require.NoError(t, err)
+
+	result, _, err := client.FloatingIP.Create(ctx, hcloud.FloatingIPCreateOpts{
+		Name:         hcloud.Ptr("ip"),
+		Type:         hcloud.FloatingIPTypeIPv6,
+		HomeLocation: &hcloud.Location{Name: "fsn1"},
+	})
+	require.NoError(t, err)
+	floatingIP := result.FloatingIP
+
+	action, _, err := client.FloatingIP.Assign(ctx, floatingIP, server.Server)
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, action))
+
+	s, _, err := client.Server.GetByID(ctx, server.Server.ID)
+	require.NoError(t, err)
+	require.Len(t, s.PublicNet.FloatingIPs, 1)
+	assert.Equal(t, floatingIP.ID, s.PublicNet.FloatingIPs[0].ID)
+
+	ip := floatingIP.Network.IP.String() + "1"
+	action, _, err = client.FloatingIP.ChangeDNSPtr(ctx, floatingIP, ip, hcloud.Ptr("example.com"))
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, action))
+
+	floatingIP, _, err = client.FloatingIP.GetByID(ctx, floatingIP.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", floatingIP.DNSPtr[ip])
+
+	_, _, err = client.FloatingIP.ChangeDNSPtr(ctx, floatingIP, "2001:db8::1", nil)
+	assert.True(t, hcloud.IsError(err, hcloud.ErrorCodeInvalidInput))
+}
+
+func TestPlacementGroup(t *testing.T) {
+	_, client := newClient(t)
+	ctx := t.Context()
+
+	result, _, err := client.PlacementGroup.Create(ctx, hcloud.PlacementGroupCreateOpts{
+		Name: "group",
+		Type: hcloud.PlacementGroupTypeSpread,
+	})
+	require.NoError(t, err)
+
+	server, _, err := client.Server.Create(ctx, hcloud.ServerCreateOpts{
+		Name:           "server",
+		ServerType:     &hcloud.ServerType{Name: "cpx22"},
+		Image:          &hcloud.Image{Name: "ubuntu-24.04"},
+		Location:       &hcloud.Location{Name: "fsn1"},
+		PlacementGroup: result.PlacementGroup,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, server.Server.PlacementGroup)
+	assert.Equal(t, result.PlacementGroup.ID, server.Server.PlacementGroup.ID)
+
+	placementGroup, _, err := client.PlacementGroup.GetByID(ctx, result.PlacementGroup.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{server.Server.ID}, placementGroup.Servers)
+
+	_, _, err = client.Server.RemoveFromPlacementGroup(ctx, server.Server)
+	assert.True(t, hcloud.IsError(err, hcloud.ErrorCodeServerNotStopped))
+}
+
+func TestCertificate(t *testing.T) {
+	_, client := newClient(t)
+	ctx := t.Context()
+
+	cert, key, err := testsupport.RandTLSCert("example.com")
+	require.NoError(t, err)
+
+	uploaded, _, err := client.Certificate.Create(ctx, hcloud.CertificateCreateOpts{
+		Name:        "uploaded",
+		Type:        hcloud.CertificateTypeUploaded,
+		Certificate: cert,
+		PrivateKey:  key,
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, `^([0-9a-f]{2}:){31}[0-9a-f]{2}$`, uploaded.Fingerprint)
+
+	_, _, err = client.Certificate.Create(ctx, hcloud.CertificateCreateOpts{
+		Name:        "uploaded",
+		Type:        hcloud.CertificateTypeUploaded,
+		Certificate: cert,
+		PrivateKey:  key,
+	})
+	assert.True(t, hcloud.IsError(err, hcloud.ErrorCodeUniquenessError))
+
+	result, _, err := client.Certificate.CreateCertificate(ctx, hcloud.CertificateCreateOpts{
+		Name:        "managed",
+		Type:        hcloud.CertificateTypeManaged,
+		DomainNames: []string{"example.com", "www.example.com"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, result.Action))
+
+	managed, _, err := client.Certificate.GetByID(ctx, result.Certificate.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com", "www.example.com"}, managed.DomainNames)
+	assert.Equal(t, hcloud.CertificateStatusTypeCompleted, managed.Status.Issuance)
+}
+
+func TestZone(t *testing.T) {
+	_, client := newClient(t)
+	ctx := t.Context()
+
+	result, _, err := client.Zone.Create(ctx, hcloud.ZoneCreateOpts{
+		Name: "example.com",
+		Mode: hcloud.ZoneModePrimary,
+		RRSets: []hcloud.ZoneCreateOptsRRSet{{
+			Name:    "www",
+			Type:    hcloud.ZoneRRSetTypeA,
+			Records: []hcloud.ZoneRRSetRecord{{Value: "198.51.100.1"}},
+		}},
+	})
+	require.NoError(t, err)
+	zone := result.Zone
+	assert.Equal(t, 5, zone.RecordCount) // SOA, 3x NS and A
+
+	rrset, _, err := client.Zone.GetRRSetByID(ctx, zone, "www/A")
+	require.NoError(t, err)
+	require.NotNil(t, rrset)
+
+	action, _, err := client.Zone.AddRRSetRecords(ctx, rrset, hcloud.ZoneRRSetAddRecordsOpts{
+		Records: []hcloud.ZoneRRSetRecord{{Value: "198.51.100.2", Comment: "second"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, action))
+
+	exported, _, err := client.Zone.ExportZonefile(ctx, zone)
+	require.NoError(t, err)
+	assert.Contains(t, exported.Zonefile, "www IN A 198.51.100.2 ; second\n")
+
+	action, _, err = client.Zone.ChangeRRSetProtection(ctx, rrset, hcloud.ZoneRRSetChangeProtectionOpts{Change: hcloud.Ptr(true)})
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, action))
+
+	_, _, err = client.Zone.DeleteRRSet(ctx, rrset)
+	assert.True(t, hcloud.IsError(err, hcloud.ErrorCodeProtected))
+
+	secondary, _, err := client.Zone.Create(ctx, hcloud.ZoneCreateOpts{
+		Name:               "example.org",
+		Mode:               hcloud.ZoneModeSecondary,
+		PrimaryNameservers: []hcloud.ZoneCreateOptsPrimaryNameserver{{Address: "203.0.113.1"}},
+	})
+	require.NoError(t, err)
+
+	_, _, err = client.Zone.CreateRRSet(ctx, secondary.Zone, hcloud.ZoneRRSetCreateOpts{
+		Name:    "www",
+		Type:    hcloud.ZoneRRSetTypeA,
+		Records: []hcloud.ZoneRRSetRecord{{Value: "198.51.100.1"}},
+	})
+	assert.True(t, hcloud.IsError(err, hcloud.ErrorCodeDNSZoneIsSecondaryZone))
+}
+
+func TestStorageBox(t *testing.T) {
+	_, client := newClient(t)
+	ctx := t.Context()
+
+	result, _, err := client.StorageBox.Create(ctx, hcloud.StorageBoxCreateOpts{
+		Name:           "storage-box",
+		StorageBoxType: &hcloud.StorageBoxType{Name: "bx11"},
+		Location:       &hcloud.Location{Name: "fsn1"},
+		Password:       "secret",
+		AccessSettings: &hcloud.StorageBoxCreateOptsAccessSettings{SSHEnabled: hcloud.Ptr(true)},
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, result.Action))
+
+	storageBox, _, err := client.StorageBox.GetByName(ctx, "storage-box")
+	require.NoError(t, err)
+	assert.True(t, storageBox.AccessSettings.SSHEnabled)
+	assert.Equal(t, "bx11", storageBox.StorageBoxType.Name)
+
+	snapshot, _, err := client.StorageBox.CreateSnapshot(ctx, storageBox, hcloud.StorageBoxSnapshotCreateOpts{Description: "snapshot"})
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, snapshot.Action))
+
+	subaccount, _, err := client.StorageBox.CreateSubaccount(ctx, storageBox, hcloud.StorageBoxSubaccountCreateOpts{
+		HomeDirectory: "backups/server",
+		Password:      "secret",
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, subaccount.Action))
+
+	sub, _, err := client.StorageBox.GetSubaccountByID(ctx, storageBox, subaccount.Subaccount.ID)
+	require.NoError(t, err)
+	assert.Equal(t, storageBox.Username+"-sub1", sub.Username)
+
+	folders, _, err := client.StorageBox.Folders(ctx, storageBox, hcloud.StorageBoxFoldersOpts{Path: "backups"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"server"}, folders.Folders)
+
+	action, _, err := client.StorageBox.ChangeProtection(ctx, storageBox, hcloud.StorageBoxChangeProtectionOpts{Delete: hcloud.Ptr(true)})
+	require.NoError(t, err)
+	require.NoError(t, client.Action.WaitFor(ctx, action))
+
+	_, _, err = client.StorageBox.Delete(ctx, storageBox)
+	assert.True(t, hcloud.IsError(err, hcloud.ErrorCodeProtected))
+}
+
+func mustParseCIDR(t *testing.T, value string) *net.IPNet {
+	t.Helper()
+
+	_, ipNet, err := net.ParseCIDR(value)
+	require.NoError(t, err)
+	return ipNet
+}
@@ -0,0 +1,301 @@
+package fakeapi
+
+import (
+	"net/http"
+	"net/netip"
+	"slices"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+func firewallFields(f *schema.Firewall) (int64, string) { return f.ID, f.Name }
+
+// firewallAppliesTo reports whether the firewall is applied to the server,
+// either directly or through a label selector.
+func firewallAppliesTo(firewall *schema.Firewall, server *schema.Server) bool {
+	for _, res := range firewall.AppliedTo {
+		switch res.Type {
+		case "server":
+			if res.Server != nil && res.Server.ID == server.ID {
+				return true
+			}
+		case "label_selector":
+			if res.LabelSelector != nil && matchesSelector(res.LabelSelector.Selector, server.Labels) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// firewallView returns the firewall with the resources matched by its label
+// selectors filled in.
+func (s *Server) firewallView(firewall *schema.Firewall) schema.Firewall {
+	view := *firewall
+	view.Rules = slices.Clone(firewall.Rules)
+	view.AppliedTo = make([]schema.FirewallResource, 0, len(firewall.AppliedTo))
+	for _, res := range firewall.AppliedTo {
+		if res.Type == "label_selector" {
+			res.AppliedToResources = []schema.FirewallResource{}
+			for _, server := range sortedByID(s.servers) {
+				if matchesSelector(res.LabelSelector.Selector, server.Labels) {
+					res.AppliedToResources = append(res.AppliedToResources, schema.FirewallResource{
+						Type: "server", Server: &schema.FirewallResourceServer{ID: server.ID},
+					})
+				}
+			}
+		}
+		view.AppliedTo = append(view.AppliedTo, res)
+	}
+	return view
+}
+
+func (s *Server) registerFirewalls() {
+	s.handle("GET /firewalls", func(r *http.Request) (any, error) {
+		return list(r, "firewalls", s.firewalls, listFields[schema.Firewall]{
+			id:     func(f *schema.Firewall) int64 { return f.ID },
+			name:   func(f *schema.Firewall) string { return f.Name },
+			labels: func(f *schema.Firewall) map[string]string { return f.Labels },
+		}, s.firewallView)
+	})
+
+	s.handle("GET /firewalls/{id}", func(r *http.Request) (any, error) {
+		firewall, err := lookup(r, s.firewalls, "firewall")
+		if err != nil {
+			return nil, err
+		}
+		return schema.FirewallGetResponse{Firewall: s.firewallView(firewall)}, nil
+	})
+
+	s.handle("POST /firewalls", func(r *http.Request) (any, error) {
+		req, err := decode[schema.FirewallCreateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name == "" {
+			return nil, errInvalidInput("name is required")
+		}
+		if nameTaken(s.firewalls, 0, req.Name, firewallFields) {
+			return nil, errUniqueness("name")
+		}
+		rules, err := firewallRules(req.Rules)
+		if err != nil {
+			return nil, err
+		}
+
+		firewall := &schema.Firewall{
+			ID:        s.nextID(),
+			Name:      req.Name,
+			Labels:    copyLabels(req.Labels),
+			Created:   now(),
+			Rules:     rules,
+			AppliedTo: []schema.FirewallResource{},
+		}
+		ref := resourceRef("firewall", firewall.ID)
+		actions := []schema.Action{s.newAction("set_firewall_rules", ref)}
+
+		applyActions, err := s.applyFirewall(firewall, req.ApplyTo)
+		if err != nil {
+			return nil, err
+		}
+		s.firewalls[firewall.ID] = firewall
+
+		return schema.FirewallCreateResponse{
+			Firewall: s.firewallView(firewall),
+			Actions:  append(actions, applyActions...),
+		}, nil
+	})
+
+	s.handle("PUT /firewalls/{id}", func(r *http.Request) (any, error) {
+		firewall, err := lookup(r, s.firewalls, "firewall")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.FirewallUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name != nil {
+			if nameTaken(s.firewalls, firewall.ID, *req.Name, firewallFields) {
+				return nil, errUniqueness("name")
+			}
+			firewall.Name = *req.Name
+		}
+		if req.Labels != nil {
+			firewall.Labels = copyLabels(req.Labels)
+		}
+		return schema.FirewallUpdateResponse{Firewall: s.firewallView(firewall)}, nil
+	})
+
+	s.handle("DELETE /firewalls/{id}", func(r *http.Request) (any, error) {
+		firewall, err := lookup(r, s.firewalls, "firewall")
+		if err != nil {
+			return nil, err
+		}
+		if len(firewall.AppliedTo) > 0 {
+			return nil, errConflict("resource_in_use", "firewall is still applied to resources")
+		}
+		delete(s.firewalls, firewall.ID)
+		return nil, nil
+	})
+
+	s.handle("POST /firewalls/{id}/actions/{action}", func(r *http.Request) (any, error) {
+		firewall, err := lookup(r, s.firewalls, "firewall")
+		if err != nil {
+			return nil, err
+		}
+		ref := resourceRef("firewall", firewall.ID)
+
+		switch command := r.PathValue("action"); command {
+		case "set_rules":
+			req, err := decode[schema.FirewallActionSetRulesRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			rules, err := firewallRules(req.Rules)
+			if err != nil {
+				return nil, err
+			}
+			firewall.Rules = rules
+			return schema.FirewallActionSetRulesResponse{
+				Actions: []schema.Action{s.newAction("set_firewall_rules", ref)},
+			}, nil
+
+		case "apply_to_resources":
+			req, err := decode[schema.FirewallActionApplyToResourcesRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			actions, err := s.applyFirewall(firewall, req.ApplyTo)
+			if err != nil {
+				return nil, err
+			}
+			return schema.FirewallActionApplyToResourcesResponse{Actions: actions}, nil
+
+		case "remove_from_resources":
+			req, err := decode[schema.FirewallActionRemoveFromResourcesRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			actions := []schema.Action{}
+			for _, res := range req.RemoveFrom {
+				idx := slices.IndexFunc(firewall.AppliedTo, func(applied schema.FirewallResource) bool {
+					return sameFirewallResource(applied, res)
+				})
+				if idx < 0 {
+					return nil, errConflict("firewall_resource_not_found", "firewall is not applied to the resource")
+				}
+				firewall.AppliedTo = slices.Delete(firewall.AppliedTo, idx, idx+1)
+				actions = append(actions, s.newAction("remove_firewall", ref))
+			}
+			return schema.FirewallActionRemoveFromResourcesResponse{Actions: actions}, nil
+
+		default:
+			return nil, errNotFound("action")
+		}
+	})
+}
+
+// applyFirewall validates the resources and applies the firewall to them.
+func (s *Server) applyFirewall(firewall *schema.Firewall, resources []schema.FirewallResource) ([]schema.Action, error) {
+	actions := []schema.Action{}
+	for _, res := range resources {
+		switch res.Type {
+		case "server":
+			if res.Server == nil {
+				return nil, errInvalidInput("server is required for resources of type server")
+			}
+			if _, ok := s.servers[res.Server.ID]; !ok {
+				return nil, errInvalidInput("server %d not found", res.Server.ID)
+			}
+		case "label_selector":
+			if res.LabelSelector == nil {
+				return nil, errInvalidInput("label_selector is required for resources of type label_selector")
+			}
+			if _, err := parseLabelSelector(res.LabelSelector.Selector); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errInvalidInput("invalid resource type: %q", res.Type)
+		}
+		if slices.ContainsFunc(firewall.AppliedTo, func(applied schema.FirewallResource) bool {
+			return sameFirewallResource(applied, res)
+		}) {
+			return nil, errConflict("firewall_already_applied", "firewall is already applied to the resource")
+		}
+
+		firewall.AppliedTo = append(firewall.AppliedTo, schema.FirewallResource{
+			Type: res.Type, Server: res.Server, LabelSelector: res.LabelSelector,
+		})
+		actions = append(actions, s.newAction("apply_firewall", resourceRef("firewall", firewall.ID)))
+	}
+	return actions, nil
+}
+
+func sameFirewallResource(a, b schema.FirewallResource) bool {
+	switch {
+	case a.Type != b.Type:
+		return false
+	case a.Type == "server":
+		return a.Server != nil && b.Server != nil && a.Server.ID == b.Server.ID
+	case a.Type == "label_selector":
+		return a.LabelSelector != nil && b.LabelSelector != nil && a.LabelSelector.Selector == b.LabelSelector.Selector
+	default:
+		return false
+	}
+}
+
+// firewallRules validates the requested rules.
+func firewallRules(requested []schema.FirewallRuleRequest) ([]schema.FirewallRule, error) {
+	rules := make([]schema.FirewallRule, 0, len(requested))
+	for _, req := range requested {
+		rule := schema.FirewallRule{
+			Direction:      req.Direction,
+			SourceIPs:      req.SourceIPs,
+			DestinationIPs: req.DestinationIPs,
+			Protocol:       req.Protocol,
+			Port:           req.Port,
+			Description:    req.Description,
+		}
+		if rule.SourceIPs == nil {
+			rule.SourceIPs = []string{}
+		}
+		if rule.DestinationIPs == nil {
+			rule.DestinationIPs = []string{}
+		}
+
+		switch rule.Direction {
+		case "in":
+			if len(rule.SourceIPs) == 0 || len(rule.DestinationIPs) > 0 {
+				return nil, errInvalidInput("rules with direction in require source_ips only")
+			}
+		case "out":
+			if len(rule.DestinationIPs) == 0 || len(rule.SourceIPs) > 0 {
+				return nil, errInvalidInput("rules with direction out require destination_ips only")
+			}
+		default:
+			return nil, errInvalidInput("invalid direction: %q", rule.Direction)
+		}
+
+		switch rule.Protocol {
+		case "tcp", "udp":
+			if rule.Port == nil {
+				return nil, errInvalidInput("port is required for protocol %s", rule.Protocol)
+			}
+		case "icmp", "esp", "gre":
+			if rule.Port != nil {
+				return nil, errInvalidInput("port is not allowed for protocol %s", rule.Protocol)
+			}
+		default:
+			return nil, errInvalidInput("invalid protocol: %q", rule.Protocol)
+		}
+
+		for _, ip := range append(slices.Clone(rule.SourceIPs), rule.DestinationIPs...) {
+			if _, err := netip.ParsePrefix(ip); err != nil {
+				return nil, errInvalidInput("invalid ip: %q", ip)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
@@ -0,0 +1,217 @@
+package fakeapi
+
+import (
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+func floatingIPFields(f *schema.FloatingIP) (int64, string) { return f.ID, f.Name }
+
+// assignedFloatingIPs returns the IDs of the Floating IPs assigned to the server.
+func (s *Server) assignedFloatingIPs(serverID int64) []int64 {
+	result := []int64{}
+	for _, floatingIP := range sortedByID(s.floatingIPs) {
+		if floatingIP.Server != nil && *floatingIP.Server == serverID {
+			result = append(result, floatingIP.ID)
+		}
+	}
+	return result
+}
+
+// floatingIPContains reports whether the IP is the Floating IPv4 or part of the
+// Floating IPv6 network.
+func floatingIPContains(floatingIP *schema.FloatingIP, ip string) bool {
+	if floatingIP.Type == "ipv4" {
+		return floatingIP.IP == ip
+	}
+	prefix, err := netip.ParsePrefix(floatingIP.IP)
+	if err != nil {
+		return false
+	}
+	addr, err := netip.ParseAddr(ip)
+	return err == nil && prefix.Contains(addr)
+}
+
+func (s *Server) registerFloatingIPs() {
+	s.handle("GET /floating_ips", func(r *http.Request) (any, error) {
+		return list(r, "floating_ips", s.floatingIPs, listFields[schema.FloatingIP]{
+			id:     func(f *schema.FloatingIP) int64 { return f.ID },
+			name:   func(f *schema.FloatingIP) string { return f.Name },
+			labels: func(f *schema.FloatingIP) map[string]string { return f.Labels },
+		}, nil)
+	})
+
+	s.handle("GET /floating_ips/{id}", func(r *http.Request) (any, error) {
+		floatingIP, err := lookup(r, s.floatingIPs, "floating_ip")
+		if err != nil {
+			return nil, err
+		}
+		return schema.FloatingIPGetResponse{FloatingIP: *floatingIP}, nil
+	})
+
+	s.handle("POST /floating_ips", func(r *http.Request) (any, error) {
+		req, err := decode[schema.FloatingIPCreateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Type != "ipv4" && req.Type != "ipv6" {
+			return nil, errInvalidInput("type must be one of ipv4, ipv6")
+		}
+		if req.Name != nil && nameTaken(s.floatingIPs, 0, *req.Name, floatingIPFields) {
+			return nil, errUniqueness("name")
+		}
+
+		var server *schema.Server
+		if req.Server != nil {
+			var ok bool
+			if server, ok = s.servers[*req.Server]; !ok {
+				return nil, errInvalidInput("server %d not found", *req.Server)
+			}
+		}
+		var location *schema.Location
+		switch {
+		case req.HomeLocation != nil:
+			var ok bool
+			if location, ok = s.findLocation(*req.HomeLocation); !ok {
+				return nil, errInvalidInput("location %s not found", *req.HomeLocation)
+			}
+		case server != nil:
+			location = &server.Location
+		default:
+			return nil, errInvalidInput("one of home_location or server is required")
+		}
+
+		floatingIP := &schema.FloatingIP{
+			ID:           s.nextID(),
+			Description:  req.Description,
+			Created:      now(),
+			Type:         req.Type,
+			DNSPtr:       []schema.FloatingIPDNSPtr{},
+			HomeLocation: *location,
+			Labels:       copyLabels(req.Labels),
+		}
+		if req.Name != nil {
+			floatingIP.Name = *req.Name
+		} else {
+			floatingIP.Name = "floating_ip-" + strconv.FormatInt(floatingIP.ID, 10)
+		}
+		if req.Type == "ipv4" {
+			floatingIP.IP = s.nextPublicIPv4()
+			floatingIP.DNSPtr = append(floatingIP.DNSPtr, schema.FloatingIPDNSPtr{
+				IP:     floatingIP.IP,
+				DNSPtr: "static." + floatingIP.IP + ".clients.your-server.de",
+			})
+		} else {
+			floatingIP.IP = s.nextPublicIPv6()
+		}
+		s.floatingIPs[floatingIP.ID] = floatingIP
+
+		resp := schema.FloatingIPCreateResponse{}
+		if server != nil {
+			floatingIP.Server = ptr(server.ID)
+			resp.Action = ptr(s.newAction("assign_floating_ip", resourceRef("floating_ip", floatingIP.ID), resourceRef("server", server.ID)))
+		}
+		resp.FloatingIP = *floatingIP
+		return resp, nil
+	})
+
+	s.handle("PUT /floating_ips/{id}", func(r *http.Request) (any, error) {
+		floatingIP, err := lookup(r, s.floatingIPs, "floating_ip")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.FloatingIPUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name != "" {
+			if nameTaken(s.floatingIPs, floatingIP.ID, req.Name, floatingIPFields) {
+				return nil, errUniqueness("name")
+			}
+			floatingIP.Name = req.Name
+		}
+		if req.Description != "" {
+			floatingIP.Description = ptr(req.Description)
+		}
+		if req.Labels != nil {
+			floatingIP.Labels = copyLabels(req.Labels)
+		}
+		return schema.FloatingIPUpdateResponse{FloatingIP: *floatingIP}, nil
+	})
+
+	s.handle("DELETE /floating_ips/{id}", func(r *http.Request) (any, error) {
+		floatingIP, err := lookup(r, s.floatingIPs, "floating_ip")
+		if err != nil {
+			return nil, err
+		}
+		if floatingIP.Protection.Delete {
+			return nil, errProtected("floating_ip")
+		}
+		delete(s.floatingIPs, floatingIP.ID)
+		return nil, nil
+	})
+
+	s.handle("POST /floating_ips/{id}/actions/{action}", func(r *http.Request) (any, error) {
+		floatingIP, err := lookup(r, s.floatingIPs, "floating_ip")
+		if err != nil {
+			return nil, err
+		}
+		ref := resourceRef("floating_ip", floatingIP.ID)
+
+		switch command := r.PathValue("action"); command {
+		case "assign":
+			req, err := decode[schema.FloatingIPActionAssignRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := s.servers[req.Server]; !ok {
+				return nil, errInvalidInput("server %d not found", req.Server)
+			}
+			floatingIP.Server = ptr(req.Server)
+			return schema.FloatingIPActionAssignResponse{
+				Action: s.newAction("assign_floating_ip", ref, resourceRef("server", req.Server)),
+			}, nil
+
+		case "unassign":
+			refs := []schema.ActionResourceReference{ref}
+			if floatingIP.Server != nil {
+				refs = append(refs, resourceRef("server", *floatingIP.Server))
+			}
+			floatingIP.Server = nil
+			return schema.FloatingIPActionUnassignResponse{Action: s.newAction("unassign_floating_ip", refs...)}, nil
+
+		case "change_dns_ptr":
+			req, err := decode[schema.FloatingIPActionChangeDNSPtrRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if !floatingIPContains(floatingIP, req.IP) {
+				return nil, errInvalidInput("ip %s does not belong to the floating IP", req.IP)
+			}
+			floatingIP.DNSPtr = slices.DeleteFunc(floatingIP.DNSPtr, func(entry schema.FloatingIPDNSPtr) bool {
+				return entry.IP == req.IP
+			})
+			if req.DNSPtr != nil {
+				floatingIP.DNSPtr = append(floatingIP.DNSPtr, schema.FloatingIPDNSPtr{IP: req.IP, DNSPtr: *req.DNSPtr})
+			}
+			return schema.FloatingIPActionChangeDNSPtrResponse{Action: s.newAction(command, ref)}, nil
+
+		case "change_protection":
+			req, err := decode[schema.FloatingIPActionChangeProtectionRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if req.Delete != nil {
+				floatingIP.Protection.Delete = *req.Delete
+			}
+			return schema.FloatingIPActionChangeProtectionResponse{Action: s.newAction(command, ref)}, nil
+
+		default:
+			return nil, errNotFound("action")
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package fakeapi
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+func (s *Server) registerImages() {
+	s.handle("GET /images", func(r *http.Request) (any, error) {
+		includeDeprecated := r.URL.Query().Get("include_deprecated") == "true"
+		images := make(map[int64]*schema.Image)
+		for id, image := range s.images {
+			if image.Deprecated != nil && !includeDeprecated {
+				continue
+			}
+			images[id] = image
+		}
+
+		return list(r, "images", images, listFields[schema.Image]{
+			id: func(i *schema.Image) int64 { return i.ID },
+			name: func(i *schema.Image) string {
+				if i.Name == nil {
+					return ""
+				}
+				return *i.Name
+			},
+			labels: func(i *schema.Image) map[string]string { return i.Labels },
+			filters: map[string]func(*schema.Image) string{
+				"type":         func(i *schema.Image) string { return i.Type },
+				"status":       func(i *schema.Image) string { return i.Status },
+				"architecture": func(i *schema.Image) string { return i.Architecture },
+				"bound_to": func(i *schema.Image) string {
+					if i.BoundTo == nil {
+						return ""
+					}
+					return strconv.FormatInt(*i.BoundTo, 10)
+				},
+			},
+		}, nil)
+	})
+
+	s.handle("GET /images/{id}", func(r *http.Request) (any, error) {
+		image, err := lookup(r, s.images, "image")
+		if err != nil {
+			return nil, err
+		}
+		return schema.ImageGetResponse{Image: *image}, nil
+	})
+
+	s.handle("PUT /images/{id}", func(r *http.Request) (any, error) {
+		image, err := lookup(r, s.images, "image")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.ImageUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Description != nil {
+			image.Description = *req.Description
+		}
+		if req.Type != nil {
+			if image.Type != "backup" || *req.Type != "snapshot" {
+				return nil, errInvalidInput("only backups can be converted to snapshots")
+			}
+			image.Type = *req.Type
+			image.BoundTo = nil
+		}
+		if req.Labels != nil {
+			image.Labels = copyLabels(req.Labels)
+		}
+		return schema.ImageUpdateResponse{Image: *image}, nil
+	})
+
+	s.handle("DELETE /images/{id}", func(r *http.Request) (any, error) {
+		image, err := lookup(r, s.images, "image")
+		if err != nil {
+			return nil, err
+		}
+		if image.Type == "system" || image.Type == "app" {
+			return nil, &apiError{status: http.StatusForbidden, code: "forbidden", message: "system images cannot be deleted"}
+		}
+		if image.Protection.Delete {
+			return nil, errProtected("image")
+		}
+		delete(s.images, image.ID)
+		return nil, nil
+	})
+
+	s.handle("POST /images/{id}/actions/change_protection", func(r *http.Request) (any, error) {
+		image, err := lookup(r, s.images, "image")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.ImageActionChangeProtectionRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Delete != nil {
+			image.Protection.Delete = *req.Delete
+		}
+		return schema.ImageActionChangeProtectionResponse{
+			Action: s.newAction("change_protection", resourceRef("image", image.ID)),
+		}, nil
+	})
+}
+
+// findImage returns the image with the given ID, or the image with the given
+// name matching the architecture.
+func (s *Server) findImage(value schema.IDOrName, architecture string) (*schema.Image, bool) {
+	for _, image := range sortedByID(s.images) {
+		if value.ID != 0 && image.ID == value.ID {
+			return image, true
+		}
+		if value.Name != "" && image.Name != nil && *image.Name == value.Name && image.Architecture == architecture {
+			return image, true
+		}
+	}
+	return nil, false
+}
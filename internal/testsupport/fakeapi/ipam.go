@@ -0,0 +1,106 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// nextPublicIPv4 returns an unused public IPv4 address. Addresses are taken
+// from the 198.18.0.0/15 benchmarking range.
+func (s *Server) nextPublicIPv4() string {
+	s.lastIPv4++
+	return fmt.Sprintf("198.%d.%d.%d", 18+s.lastIPv4/65536%2, s.lastIPv4/256%256, s.lastIPv4%256)
+}
+
+// nextPublicIPv6 returns an unused public IPv6 /64 network. Networks are taken
+// from the 2001:db8::/32 documentation range.
+func (s *Server) nextPublicIPv6() string {
+	s.lastIPv6++
+	return fmt.Sprintf("2001:db8:%x:%x::/64", s.lastIPv6/65536%65536, s.lastIPv6%65536)
+}
+
+// firstHost returns the first host address of a network, used as gateway of
+// private networks.
+func firstHost(ipRange string) string {
+	prefix, err := netip.ParsePrefix(ipRange)
+	if err != nil {
+		return ""
+	}
+	return prefix.Masked().Addr().Next().String()
+}
+
+// usedPrivateIPs returns the IPs of the servers and Load Balancers attached to
+// the network.
+func (s *Server) usedPrivateIPs(networkID int64) map[string]bool {
+	used := make(map[string]bool)
+	for _, server := range s.servers {
+		for _, privateNet := range server.PrivateNet {
+			if privateNet.Network != networkID {
+				continue
+			}
+			used[privateNet.IP] = true
+			for _, ip := range privateNet.AliasIPs {
+				used[ip] = true
+			}
+		}
+	}
+	for _, lb := range s.loadBalancers {
+		for _, privateNet := range lb.PrivateNet {
+			if privateNet.Network == networkID {
+				used[privateNet.IP] = true
+			}
+		}
+	}
+	return used
+}
+
+// allocatePrivateIP reserves the requested IP in the network, or the first
+// free IP of the subnet matching ipRange (or of the first subnet) if ip is
+// empty.
+func (s *Server) allocatePrivateIP(networkID int64, ip, ipRange string) (string, error) {
+	network, ok := s.networks[networkID]
+	if !ok {
+		return "", errNotFound("network")
+	}
+	used := s.usedPrivateIPs(networkID)
+	gateway := firstHost(network.IPRange)
+
+	if ip != "" {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return "", errInvalidInput("invalid ip: %q", ip)
+		}
+		for _, subnet := range network.Subnets {
+			prefix, err := netip.ParsePrefix(subnet.IPRange)
+			if err != nil || !prefix.Contains(addr) {
+				continue
+			}
+			if used[ip] || ip == gateway {
+				return "", errConflict("ip_not_available", fmt.Sprintf("ip %s is not available", ip))
+			}
+			return ip, nil
+		}
+		return "", errInvalidInput("ip %s is not part of a subnet of the network", ip)
+	}
+
+	for _, subnet := range network.Subnets {
+		if subnet.Type == "vswitch" || (ipRange != "" && subnet.IPRange != ipRange) {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(subnet.IPRange)
+		if err != nil {
+			continue
+		}
+		for addr := prefix.Masked().Addr().Next(); prefix.Contains(addr); addr = addr.Next() {
+			if candidate := addr.String(); !used[candidate] && candidate != gateway {
+				return candidate, nil
+			}
+		}
+	}
+	return "", errConflict("no_subnet_available", "no free IP available in the network")
+}
+
+// macAddress returns a stable MAC address for a resource ID.
+func macAddress(id int64) string {
+	return fmt.Sprintf("86:00:%02x:%02x:%02x:%02x", id>>24&0xff, id>>16&0xff, id>>8&0xff, id&0xff)
+}
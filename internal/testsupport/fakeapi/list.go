@@ -0,0 +1,256 @@
+package fakeapi
+
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+const (
+	defaultPerPage = 25
+	maxPerPage     = 50
+)
+
+// listFields describes how to filter items of a collection.
+type listFields[T any] struct {
+	id     func(*T) int64
+	name   func(*T) string
+	labels func(*T) map[string]string
+	// filters maps query parameters to the item value they are compared with.
+	filters map[string]func(*T) string
+}
+
+// list filters the items using the name, label_selector and any other
+// supported query parameter, orders them by ID and returns the requested page
+// in a response body under key.
+func list[T any](r *http.Request, key string, items map[int64]*T, fields listFields[T], view func(*T) T) (map[string]any, error) {
+	query := r.URL.Query()
+
+	var selector labelSelector
+	if value := query.Get("label_selector"); value != "" {
+		if fields.labels == nil {
+			return nil, errInvalidInput("label_selector is not supported")
+		}
+		var err error
+		if selector, err = parseLabelSelector(value); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := slices.Sorted(maps.Keys(items))
+	result := make([]T, 0, len(ids))
+	for _, id := range ids {
+		item := items[id]
+		if name := query.Get("name"); name != "" && (fields.name == nil || fields.name(item) != name) {
+			continue
+		}
+		if selector != nil && !selector.matches(fields.labels(item)) {
+			continue
+		}
+		if values, ok := query["id"]; ok && fields.id != nil && !slices.Contains(values, strconv.FormatInt(fields.id(item), 10)) {
+			continue
+		}
+		if !matchFilters(query, item, fields.filters) {
+			continue
+		}
+		if view != nil {
+			result = append(result, view(item))
+		} else {
+			result = append(result, *item)
+		}
+	}
+
+	page, meta := paginate(r, result)
+	return map[string]any{key: page, "meta": meta}, nil
+}
+
+func matchFilters[T any](query map[string][]string, item *T, filters map[string]func(*T) string) bool {
+	for param, value := range filters {
+		values, ok := query[param]
+		if !ok {
+			continue
+		}
+		if !slices.Contains(values, value(item)) {
+			return false
+		}
+	}
+	return true
+}
+
+// paginate returns the page of items requested with the page and per_page
+// query parameters.
+func paginate[T any](r *http.Request, items []T) ([]T, schema.Meta) {
+	query := r.URL.Query()
+
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err := strconv.Atoi(query.Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	perPage = min(perPage, maxPerPage)
+
+	lastPage := max((len(items)+perPage-1)/perPage, 1)
+	pagination := &schema.MetaPagination{
+		Page:         page,
+		PerPage:      perPage,
+		LastPage:     lastPage,
+		TotalEntries: len(items),
+	}
+	if page > 1 {
+		pagination.PreviousPage = page - 1
+	}
+	if page < lastPage {
+		pagination.NextPage = page + 1
+	}
+
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+	return items[start:end], schema.Meta{Pagination: pagination}
+}
+
+// labelRequirement is a single expression of a label selector.
+type labelRequirement struct {
+	key      string
+	operator string
+	values   []string
+}
+
+// labelSelector is a parsed label selector, see
+// https://docs.hetzner.cloud/reference/cloud#label-selector.
+type labelSelector []labelRequirement
+
+func parseLabelSelector(value string) (labelSelector, error) {
+	var selector labelSelector
+	for _, expr := range splitSelector(value) {
+		expr = strings.TrimSpace(expr)
+		if expr == "" {
+			return nil, errInvalidInput("invalid label_selector: %q", value)
+		}
+
+		var req labelRequirement
+		switch {
+		case strings.HasPrefix(expr, "!"):
+			req = labelRequirement{key: strings.TrimPrefix(expr, "!"), operator: "!"}
+		case strings.Contains(expr, " notin "):
+			key, values, _ := strings.Cut(expr, " notin ")
+			req = labelRequirement{key: key, operator: "notin", values: parseSetValues(values)}
+			if req.values == nil {
+				return nil, errInvalidInput("invalid label_selector: %q", value)
+			}
+		case strings.Contains(expr, " in "):
+			key, values, _ := strings.Cut(expr, " in ")
+			req = labelRequirement{key: key, operator: "in", values: parseSetValues(values)}
+			if req.values == nil {
+				return nil, errInvalidInput("invalid label_selector: %q", value)
+			}
+		case strings.Contains(expr, "!="):
+			key, val, _ := strings.Cut(expr, "!=")
+			req = labelRequirement{key: key, operator: "!=", values: []string{val}}
+		case strings.Contains(expr, "=="):
+			key, val, _ := strings.Cut(expr, "==")
+			req = labelRequirement{key: key, operator: "=", values: []string{val}}
+		case strings.Contains(expr, "="):
+			key, val, _ := strings.Cut(expr, "=")
+			req = labelRequirement{key: key, operator: "=", values: []string{val}}
+		default:
+			req = labelRequirement{key: expr, operator: ""}
+		}
+		req.key = strings.TrimSpace(req.key)
+		for i := range req.values {
+			req.values[i] = strings.TrimSpace(req.values[i])
+		}
+		if req.key == "" {
+			return nil, errInvalidInput("invalid label_selector: %q", value)
+		}
+		selector = append(selector, req)
+	}
+	return selector, nil
+}
+
+// splitSelector splits the selector on commas that are not part of a set.
+func splitSelector(value string) []string {
+	var (
+		exprs []string
+		depth int
+		start int
+	)
+	for i, c := range value {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				exprs = append(exprs, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(exprs, value[start:])
+}
+
+// parseSetValues parses a set of values "(a,b)", or returns nil if the set is
+// malformed.
+func parseSetValues(value string) []string {
+	value, ok := strings.CutPrefix(strings.TrimSpace(value), "(")
+	if !ok {
+		return nil
+	}
+	if value, ok = strings.CutSuffix(value, ")"); !ok {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+func (s labelSelector) matches(labels map[string]string) bool {
+	for _, req := range s {
+		value, ok := labels[req.key]
+		switch req.operator {
+		case "":
+			if !ok {
+				return false
+			}
+		case "!":
+			if ok {
+				return false
+			}
+		case "=", "in":
+			if !ok || !slices.Contains(req.values, value) {
+				return false
+			}
+		case "!=", "notin":
+			if ok && slices.Contains(req.values, value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesSelector reports whether the labels match the selector, invalid
+// selectors match nothing.
+func matchesSelector(selector string, labels map[string]string) bool {
+	parsed, err := parseLabelSelector(selector)
+	if err != nil {
+		return false
+	}
+	return parsed.matches(labels)
+}
+
+// sortedByID returns the items ordered by their ID.
+func sortedByID[T any](items map[int64]*T) []*T {
+	ids := slices.Sorted(maps.Keys(items))
+	result := make([]*T, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, items[id])
+	}
+	return result
+}
@@ -0,0 +1,681 @@
+package fakeapi
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+func loadBalancerFields(lb *schema.LoadBalancer) (int64, string) { return lb.ID, lb.Name }
+
+// loadBalancerTargets reports whether the server is a target of the Load
+// Balancer, either directly or through a label selector.
+func loadBalancerTargets(lb *schema.LoadBalancer, server *schema.Server) bool {
+	for _, target := range lb.Targets {
+		switch target.Type {
+		case "server":
+			if target.Server != nil && target.Server.ID == server.ID {
+				return true
+			}
+		case "label_selector":
+			if target.LabelSelector != nil && matchesSelector(target.LabelSelector.Selector, server.Labels) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// loadBalancerView returns the Load Balancer with the health status of its
+// targets and the targets matched by its label selectors filled in.
+func (s *Server) loadBalancerView(lb *schema.LoadBalancer) schema.LoadBalancer {
+	view := *lb
+	view.Services = slices.Clone(lb.Services)
+	view.PrivateNet = slices.Clone(lb.PrivateNet)
+
+	healthStatus := make([]schema.LoadBalancerTargetHealthStatus, 0, len(lb.Services))
+	for _, service := range lb.Services {
+		healthStatus = append(healthStatus, schema.LoadBalancerTargetHealthStatus{ListenPort: service.ListenPort, Status: "healthy"})
+	}
+
+	view.Targets = make([]schema.LoadBalancerTarget, 0, len(lb.Targets))
+	for _, target := range lb.Targets {
+		target.HealthStatus = healthStatus
+		if target.Type == "label_selector" {
+			target.Targets = []schema.LoadBalancerTarget{}
+			for _, server := range sortedByID(s.servers) {
+				if matchesSelector(target.LabelSelector.Selector, server.Labels) {
+					target.Targets = append(target.Targets, schema.LoadBalancerTarget{
+						Type:         "server",
+						Server:       &schema.LoadBalancerTargetServer{ID: server.ID},
+						HealthStatus: healthStatus,
+						UsePrivateIP: target.UsePrivateIP,
+					})
+				}
+			}
+		}
+		view.Targets = append(view.Targets, target)
+	}
+	return view
+}
+
+func (s *Server) registerLoadBalancers() {
+	s.handle("GET /load_balancers", func(r *http.Request) (any, error) {
+		return list(r, "load_balancers", s.loadBalancers, listFields[schema.LoadBalancer]{
+			id:     func(lb *schema.LoadBalancer) int64 { return lb.ID },
+			name:   func(lb *schema.LoadBalancer) string { return lb.Name },
+			labels: func(lb *schema.LoadBalancer) map[string]string { return lb.Labels },
+		}, s.loadBalancerView)
+	})
+
+	s.handle("GET /load_balancers/{id}", func(r *http.Request) (any, error) {
+		lb, err := lookup(r, s.loadBalancers, "load_balancer")
+		if err != nil {
+			return nil, err
+		}
+		return schema.LoadBalancerGetResponse{LoadBalancer: s.loadBalancerView(lb)}, nil
+	})
+
+	s.handle("POST /load_balancers", s.createLoadBalancer)
+
+	s.handle("PUT /load_balancers/{id}", func(r *http.Request) (any, error) {
+		lb, err := lookup(r, s.loadBalancers, "load_balancer")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.LoadBalancerUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name != nil {
+			if nameTaken(s.loadBalancers, lb.ID, *req.Name, loadBalancerFields) {
+				return nil, errUniqueness("name")
+			}
+			lb.Name = *req.Name
+		}
+		if req.Labels != nil {
+			lb.Labels = copyLabels(req.Labels)
+		}
+		return schema.LoadBalancerUpdateResponse{LoadBalancer: s.loadBalancerView(lb)}, nil
+	})
+
+	s.handle("DELETE /load_balancers/{id}", func(r *http.Request) (any, error) {
+		lb, err := lookup(r, s.loadBalancers, "load_balancer")
+		if err != nil {
+			return nil, err
+		}
+		if lb.Protection.Delete {
+			return nil, errProtected("load_balancer")
+		}
+		delete(s.loadBalancers, lb.ID)
+		return nil, nil
+	})
+
+	s.handle("POST /load_balancers/{id}/actions/{action}", func(r *http.Request) (any, error) {
+		lb, err := lookup(r, s.loadBalancers, "load_balancer")
+		if err != nil {
+			return nil, err
+		}
+		return s.loadBalancerAction(r, lb)
+	})
+}
+
+func (s *Server) createLoadBalancer(r *http.Request) (any, error) {
+	req, err := decode[schema.LoadBalancerCreateRequest](r)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, errInvalidInput("name is required")
+	}
+	if nameTaken(s.loadBalancers, 0, req.Name, loadBalancerFields) {
+		return nil, errUniqueness("name")
+	}
+	lbType, ok := findByIDOrName(s.loadBalancerTypes, req.LoadBalancerType, loadBalancerTypeFields)
+	if !ok {
+		return nil, errInvalidInput("load_balancer_type %v not found", req.LoadBalancerType)
+	}
+
+	var location *schema.Location
+	switch {
+	case req.Location != nil:
+		if location, ok = s.findLocation(*req.Location); !ok {
+			return nil, errInvalidInput("location %q not found", *req.Location)
+		}
+	case req.NetworkZone != nil:
+		for _, l := range sortedByID(s.locations) {
+			if l.NetworkZone == *req.NetworkZone {
+				location = l
+				break
+			}
+		}
+		if location == nil {
+			return nil, errInvalidInput("network_zone %q not found", *req.NetworkZone)
+		}
+	default:
+		return nil, errInvalidInput("one of location or network_zone is required")
+	}
+
+	lb := &schema.LoadBalancer{
+		ID:               s.nextID(),
+		Name:             req.Name,
+		PrivateNet:       []schema.LoadBalancerPrivateNet{},
+		Location:         *location,
+		LoadBalancerType: *lbType,
+		Labels:           copyLabels(req.Labels),
+		Created:          now(),
+		Services:         []schema.LoadBalancerService{},
+		Targets:          []schema.LoadBalancerTarget{},
+		Algorithm:        schema.LoadBalancerAlgorithm{Type: "round_robin"},
+		IncludedTraffic:  21990232555520,
+		OutgoingTraffic:  ptr(uint64(0)),
+		IngoingTraffic:   ptr(uint64(0)),
+	}
+	ipv4, ipv6 := s.nextPublicIPv4(), s.nextPublicIPv6()
+	lb.PublicNet = schema.LoadBalancerPublicNet{
+		Enabled: req.PublicInterface == nil || *req.PublicInterface,
+		IPv4:    schema.LoadBalancerPublicNetIPv4{IP: ipv4, DNSPtr: "static." + ipv4 + ".clients.your-server.de"},
+		IPv6:    schema.LoadBalancerPublicNetIPv6{IP: firstHost(ipv6)},
+	}
+	if req.Algorithm != nil {
+		if err := validateAlgorithm(req.Algorithm.Type); err != nil {
+			return nil, err
+		}
+		lb.Algorithm.Type = req.Algorithm.Type
+	}
+
+	for _, reqService := range req.Services {
+		service, err := newLoadBalancerService(lb, loadBalancerServiceRequest(reqService))
+		if err != nil {
+			return nil, err
+		}
+		lb.Services = append(lb.Services, service)
+	}
+	if req.Network != nil {
+		if _, ok := s.networks[*req.Network]; !ok {
+			return nil, errInvalidInput("network %d not found", *req.Network)
+		}
+		ip, err := s.allocatePrivateIP(*req.Network, "", "")
+		if err != nil {
+			return nil, err
+		}
+		lb.PrivateNet = append(lb.PrivateNet, schema.LoadBalancerPrivateNet{Network: *req.Network, IP: ip})
+	}
+	for _, reqTarget := range req.Targets {
+		target := schema.LoadBalancerActionAddTargetRequest{Type: reqTarget.Type, UsePrivateIP: reqTarget.UsePrivateIP}
+		if reqTarget.Server != nil {
+			target.Server = &schema.LoadBalancerActionAddTargetRequestServer{ID: reqTarget.Server.ID}
+		}
+		if reqTarget.LabelSelector != nil {
+			target.LabelSelector = &schema.LoadBalancerActionAddTargetRequestLabelSelector{Selector: reqTarget.LabelSelector.Selector}
+		}
+		if reqTarget.IP != nil {
+			target.IP = &schema.LoadBalancerActionAddTargetRequestIP{IP: reqTarget.IP.IP}
+		}
+		if err := s.addLoadBalancerTarget(lb, target); err != nil {
+			return nil, err
+		}
+	}
+
+	s.loadBalancers[lb.ID] = lb
+	return schema.LoadBalancerCreateResponse{
+		LoadBalancer: s.loadBalancerView(lb),
+		Action:       s.newAction("create_load_balancer", resourceRef("load_balancer", lb.ID)),
+	}, nil
+}
+
+func (s *Server) loadBalancerAction(r *http.Request, lb *schema.LoadBalancer) (any, error) {
+	ref := resourceRef("load_balancer", lb.ID)
+	command := r.PathValue("action")
+	actionResponse := func(command string, refs ...schema.ActionResourceReference) any {
+		return schema.ActionGetResponse{Action: s.newAction(command, append([]schema.ActionResourceReference{ref}, refs...)...)}
+	}
+
+	switch command {
+	case "add_service":
+		req, err := decode[schema.LoadBalancerActionAddServiceRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		service, err := newLoadBalancerService(lb, req)
+		if err != nil {
+			return nil, err
+		}
+		lb.Services = append(lb.Services, service)
+		return actionResponse("add_service"), nil
+
+	case "update_service":
+		req, err := decode[schema.LoadBalancerActionUpdateServiceRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		idx := slices.IndexFunc(lb.Services, func(service schema.LoadBalancerService) bool {
+			return service.ListenPort == req.ListenPort
+		})
+		if idx < 0 {
+			return nil, errNotFound("service")
+		}
+		if err := updateLoadBalancerService(&lb.Services[idx], req); err != nil {
+			return nil, err
+		}
+		return actionResponse("update_service"), nil
+
+	case "delete_service":
+		req, err := decode[schema.LoadBalancerDeleteServiceRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		idx := slices.IndexFunc(lb.Services, func(service schema.LoadBalancerService) bool {
+			return service.ListenPort == req.ListenPort
+		})
+		if idx < 0 {
+			return nil, errNotFound("service")
+		}
+		lb.Services = slices.Delete(lb.Services, idx, idx+1)
+		return actionResponse("delete_service"), nil
+
+	case "add_target":
+		req, err := decode[schema.LoadBalancerActionAddTargetRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.addLoadBalancerTarget(lb, req); err != nil {
+			return nil, err
+		}
+		return actionResponse("add_target"), nil
+
+	case "remove_target":
+		req, err := decode[schema.LoadBalancerActionRemoveTargetRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		idx := slices.IndexFunc(lb.Targets, func(target schema.LoadBalancerTarget) bool {
+			switch {
+			case target.Type != req.Type:
+				return false
+			case req.Server != nil:
+				return target.Server != nil && target.Server.ID == req.Server.ID
+			case req.LabelSelector != nil:
+				return target.LabelSelector != nil && target.LabelSelector.Selector == req.LabelSelector.Selector
+			case req.IP != nil:
+				return target.IP != nil && target.IP.IP == req.IP.IP
+			default:
+				return false
+			}
+		})
+		if idx < 0 {
+			return nil, errNotFound("target")
+		}
+		lb.Targets = slices.Delete(lb.Targets, idx, idx+1)
+		return actionResponse("remove_target"), nil
+
+	case "change_algorithm":
+		req, err := decode[schema.LoadBalancerActionChangeAlgorithmRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateAlgorithm(req.Type); err != nil {
+			return nil, err
+		}
+		lb.Algorithm.Type = req.Type
+		return actionResponse("change_algorithm"), nil
+
+	case "change_type":
+		req, err := decode[schema.LoadBalancerActionChangeTypeRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		lbType, ok := findByIDOrName(s.loadBalancerTypes, req.LoadBalancerType, loadBalancerTypeFields)
+		if !ok {
+			return nil, errInvalidInput("load_balancer_type %v not found", req.LoadBalancerType)
+		}
+		if len(lb.Services) > lbType.MaxServices || len(lb.Targets) > lbType.MaxTargets {
+			return nil, errConflict("invalid_load_balancer_type", "load balancer type is too small")
+		}
+		lb.LoadBalancerType = *lbType
+		return actionResponse("change_load_balancer_type"), nil
+
+	case "attach_to_network":
+		req, err := decode[schema.LoadBalancerActionAttachToNetworkRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.networks[req.Network]; !ok {
+			return nil, errNotFound("network")
+		}
+		if len(lb.PrivateNet) > 0 {
+			return nil, errConflict("load_balancer_already_attached", "load balancer is already attached to a network")
+		}
+		var ip, ipRange string
+		if req.IP != nil {
+			ip = *req.IP
+		}
+		if req.IPRange != nil {
+			ipRange = *req.IPRange
+		}
+		ip, err = s.allocatePrivateIP(req.Network, ip, ipRange)
+		if err != nil {
+			return nil, err
+		}
+		lb.PrivateNet = append(lb.PrivateNet, schema.LoadBalancerPrivateNet{Network: req.Network, IP: ip})
+		return actionResponse("attach_to_network", resourceRef("network", req.Network)), nil
+
+	case "detach_from_network":
+		req, err := decode[schema.LoadBalancerActionDetachFromNetworkRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		idx := slices.IndexFunc(lb.PrivateNet, func(privateNet schema.LoadBalancerPrivateNet) bool {
+			return privateNet.Network == req.Network
+		})
+		if idx < 0 {
+			return nil, errConflict("load_balancer_not_attached_to_network", "load balancer is not attached to the network")
+		}
+		if slices.ContainsFunc(lb.Targets, func(target schema.LoadBalancerTarget) bool { return target.UsePrivateIP }) {
+			return nil, errConflict("targets_without_use_private_ip", "load balancer has targets using the private IP")
+		}
+		lb.PrivateNet = slices.Delete(lb.PrivateNet, idx, idx+1)
+		return actionResponse("detach_from_network", resourceRef("network", req.Network)), nil
+
+	case "enable_public_interface":
+		lb.PublicNet.Enabled = true
+		return actionResponse("enable_public_interface"), nil
+
+	case "disable_public_interface":
+		if len(lb.PrivateNet) == 0 {
+			return nil, errConflict("load_balancer_not_attached_to_network", "load balancer must be attached to a network")
+		}
+		lb.PublicNet.Enabled = false
+		return actionResponse("disable_public_interface"), nil
+
+	case "change_protection":
+		req, err := decode[schema.LoadBalancerActionChangeProtectionRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Delete != nil {
+			lb.Protection.Delete = *req.Delete
+		}
+		return actionResponse("change_protection"), nil
+
+	case "change_dns_ptr":
+		req, err := decode[schema.LoadBalancerActionChangeDNSPtrRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		var dnsPtr string
+		if req.DNSPtr != nil {
+			dnsPtr = *req.DNSPtr
+		}
+		switch req.IP {
+		case lb.PublicNet.IPv4.IP:
+			lb.PublicNet.IPv4.DNSPtr = dnsPtr
+		case lb.PublicNet.IPv6.IP:
+			lb.PublicNet.IPv6.DNSPtr = dnsPtr
+		default:
+			return nil, errInvalidInput("ip %s does not belong to the load balancer", req.IP)
+		}
+		return actionResponse("change_dns_ptr"), nil
+
+	default:
+		return nil, errNotFound("action")
+	}
+}
+
+func validateAlgorithm(algorithm string) error {
+	if algorithm != "round_robin" && algorithm != "least_connections" {
+		return errInvalidInput("invalid algorithm: %q", algorithm)
+	}
+	return nil
+}
+
+// addLoadBalancerTarget validates the target and adds it to the Load Balancer.
+func (s *Server) addLoadBalancerTarget(lb *schema.LoadBalancer, req schema.LoadBalancerActionAddTargetRequest) error {
+	target := schema.LoadBalancerTarget{Type: req.Type, HealthStatus: []schema.LoadBalancerTargetHealthStatus{}}
+	if req.UsePrivateIP != nil {
+		target.UsePrivateIP = *req.UsePrivateIP
+	}
+	if target.UsePrivateIP && len(lb.PrivateNet) == 0 {
+		return errConflict("load_balancer_not_attached_to_network", "load balancer must be attached to a network to use private IPs")
+	}
+
+	switch req.Type {
+	case "server":
+		if req.Server == nil {
+			return errInvalidInput("server is required for targets of type server")
+		}
+		server, ok := s.servers[req.Server.ID]
+		if !ok {
+			return errInvalidInput("server %d not found", req.Server.ID)
+		}
+		if target.UsePrivateIP && !slices.ContainsFunc(server.PrivateNet, func(privateNet schema.ServerPrivateNet) bool {
+			return privateNet.Network == lb.PrivateNet[0].Network
+		}) {
+			return errConflict("server_not_attached_to_network", "server is not attached to the load balancer network")
+		}
+		target.Server = &schema.LoadBalancerTargetServer{ID: server.ID}
+	case "label_selector":
+		if req.LabelSelector == nil {
+			return errInvalidInput("label_selector is required for targets of type label_selector")
+		}
+		if _, err := parseLabelSelector(req.LabelSelector.Selector); err != nil {
+			return err
+		}
+		target.LabelSelector = &schema.LoadBalancerTargetLabelSelector{Selector: req.LabelSelector.Selector}
+	case "ip":
+		if req.IP == nil {
+			return errInvalidInput("ip is required for targets of type ip")
+		}
+		target.IP = &schema.LoadBalancerTargetIP{IP: req.IP.IP}
+	default:
+		return errInvalidInput("invalid target type: %q", req.Type)
+	}
+
+	for _, existing := range lb.Targets {
+		if existing.Type == target.Type &&
+			((target.Server != nil && existing.Server.ID == target.Server.ID) ||
+				(target.LabelSelector != nil && existing.LabelSelector.Selector == target.LabelSelector.Selector) ||
+				(target.IP != nil && existing.IP.IP == target.IP.IP)) {
+			return errConflict("target_already_defined", "target is already defined")
+		}
+	}
+	if len(lb.Targets) >= lb.LoadBalancerType.MaxTargets {
+		return errConflict("resource_limit_exceeded", "maximum number of targets reached")
+	}
+
+	lb.Targets = append(lb.Targets, target)
+	return nil
+}
+
+// loadBalancerServiceRequest converts a service of a create request to an
+// add_service request, both have the same fields.
+func loadBalancerServiceRequest(req schema.LoadBalancerCreateRequestService) schema.LoadBalancerActionAddServiceRequest {
+	result := schema.LoadBalancerActionAddServiceRequest{
+		Protocol:        req.Protocol,
+		ListenPort:      req.ListenPort,
+		DestinationPort: req.DestinationPort,
+		Proxyprotocol:   req.Proxyprotocol,
+	}
+	if req.HTTP != nil {
+		result.HTTP = (*schema.LoadBalancerActionAddServiceRequestHTTP)(req.HTTP)
+	}
+	if req.HealthCheck != nil {
+		result.HealthCheck = &schema.LoadBalancerActionAddServiceRequestHealthCheck{
+			Protocol: req.HealthCheck.Protocol,
+			Port:     req.HealthCheck.Port,
+			Interval: req.HealthCheck.Interval,
+			Timeout:  req.HealthCheck.Timeout,
+			Retries:  req.HealthCheck.Retries,
+			HTTP:     (*schema.LoadBalancerActionAddServiceRequestHealthCheckHTTP)(req.HealthCheck.HTTP),
+		}
+	}
+	return result
+}
+
+// newLoadBalancerService validates the service and fills in the API defaults.
+func newLoadBalancerService(lb *schema.LoadBalancer, req schema.LoadBalancerActionAddServiceRequest) (schema.LoadBalancerService, error) {
+	service := schema.LoadBalancerService{Protocol: req.Protocol}
+
+	switch req.Protocol {
+	case "tcp":
+		if req.ListenPort == nil || req.DestinationPort == nil {
+			return service, errInvalidInput("listen_port and destination_port are required for protocol tcp")
+		}
+	case "http":
+		service.ListenPort, service.DestinationPort = 80, 80
+	case "https":
+		service.ListenPort, service.DestinationPort = 443, 80
+	default:
+		return service, errInvalidInput("invalid protocol: %q", req.Protocol)
+	}
+	if req.ListenPort != nil {
+		service.ListenPort = *req.ListenPort
+	}
+	if req.DestinationPort != nil {
+		service.DestinationPort = *req.DestinationPort
+	}
+	if req.Proxyprotocol != nil {
+		service.Proxyprotocol = *req.Proxyprotocol
+	}
+	for _, existing := range lb.Services {
+		if existing.ListenPort == service.ListenPort {
+			return service, errConflict("source_port_already_used", "listen_port is already used")
+		}
+	}
+	if len(lb.Services) >= lb.LoadBalancerType.MaxServices {
+		return service, errConflict("resource_limit_exceeded", "maximum number of services reached")
+	}
+
+	if service.Protocol != "tcp" {
+		service.HTTP = &schema.LoadBalancerServiceHTTP{
+			CookieName:     "HCLBSTICKY",
+			CookieLifetime: 300,
+			Certificates:   []int64{},
+			TimeoutIdle:    60,
+		}
+		if req.HTTP != nil {
+			applyServiceHTTP(service.HTTP, req.HTTP.CookieName, req.HTTP.CookieLifetime, req.HTTP.Certificates,
+				req.HTTP.RedirectHTTP, req.HTTP.StickySessions, req.HTTP.TimeoutIdle)
+		}
+	}
+
+	service.HealthCheck = &schema.LoadBalancerServiceHealthCheck{
+		Protocol: "tcp",
+		Port:     service.DestinationPort,
+		Interval: 15,
+		Timeout:  10,
+		Retries:  3,
+	}
+	if service.Protocol != "tcp" {
+		service.HealthCheck.Protocol = "http"
+	}
+	if req.HealthCheck != nil {
+		hc := req.HealthCheck
+		service.HealthCheck.Protocol = hc.Protocol
+		applyHealthCheck(service.HealthCheck, hc.Port, hc.Interval, hc.Timeout, hc.Retries)
+		if hc.HTTP != nil {
+			applyHealthCheckHTTP(service.HealthCheck, hc.HTTP.Domain, hc.HTTP.Path, hc.HTTP.Response, hc.HTTP.StatusCodes, hc.HTTP.TLS)
+		}
+	}
+	if service.HealthCheck.Protocol != "tcp" && service.HealthCheck.HTTP == nil {
+		applyHealthCheckHTTP(service.HealthCheck, nil, nil, nil, nil, nil)
+	}
+	return service, nil
+}
+
+// updateLoadBalancerService applies the requested changes to the service.
+func updateLoadBalancerService(service *schema.LoadBalancerService, req schema.LoadBalancerActionUpdateServiceRequest) error {
+	if req.Protocol != nil {
+		service.Protocol = *req.Protocol
+		if service.Protocol != "tcp" && service.HTTP == nil {
+			service.HTTP = &schema.LoadBalancerServiceHTTP{CookieName: "HCLBSTICKY", CookieLifetime: 300, Certificates: []int64{}, TimeoutIdle: 60}
+		}
+		if service.Protocol == "tcp" {
+			service.HTTP = nil
+		}
+	}
+	if req.DestinationPort != nil {
+		service.DestinationPort = *req.DestinationPort
+	}
+	if req.Proxyprotocol != nil {
+		service.Proxyprotocol = *req.Proxyprotocol
+	}
+	if req.HTTP != nil && service.HTTP != nil {
+		applyServiceHTTP(service.HTTP, req.HTTP.CookieName, req.HTTP.CookieLifetime, req.HTTP.Certificates,
+			req.HTTP.RedirectHTTP, req.HTTP.StickySessions, req.HTTP.TimeoutIdle)
+	}
+	if hc := req.HealthCheck; hc != nil {
+		if hc.Protocol != nil {
+			service.HealthCheck.Protocol = *hc.Protocol
+			if service.HealthCheck.Protocol == "tcp" {
+				service.HealthCheck.HTTP = nil
+			}
+		}
+		applyHealthCheck(service.HealthCheck, hc.Port, hc.Interval, hc.Timeout, hc.Retries)
+		if hc.HTTP != nil {
+			applyHealthCheckHTTP(service.HealthCheck, hc.HTTP.Domain, hc.HTTP.Path, hc.HTTP.Response, hc.HTTP.StatusCodes, hc.HTTP.TLS)
+		}
+	}
+	if service.HealthCheck.Protocol != "tcp" && service.HealthCheck.HTTP == nil {
+		applyHealthCheckHTTP(service.HealthCheck, nil, nil, nil, nil, nil)
+	}
+	return nil
+}
+
+func applyServiceHTTP(settings *schema.LoadBalancerServiceHTTP, cookieName *string, cookieLifetime *int, certificates *[]int64, redirectHTTP, stickySessions *bool, timeoutIdle *int) {
+	if cookieName != nil {
+		settings.CookieName = *cookieName
+	}
+	if cookieLifetime != nil {
+		settings.CookieLifetime = *cookieLifetime
+	}
+	if certificates != nil {
+		settings.Certificates = slices.Clone(*certificates)
+	}
+	if redirectHTTP != nil {
+		settings.RedirectHTTP = *redirectHTTP
+	}
+	if stickySessions != nil {
+		settings.StickySessions = *stickySessions
+	}
+	if timeoutIdle != nil {
+		settings.TimeoutIdle = *timeoutIdle
+	}
+}
+
+func applyHealthCheck(hc *schema.LoadBalancerServiceHealthCheck, port, interval, timeout, retries *int) {
+	if port != nil {
+		hc.Port = *port
+	}
+	if interval != nil {
+		hc.Interval = *interval
+	}
+	if timeout != nil {
+		hc.Timeout = *timeout
+	}
+	if retries != nil {
+		hc.Retries = *retries
+	}
+}
+
+func applyHealthCheckHTTP(hc *schema.LoadBalancerServiceHealthCheck, domain, path, response *string, statusCodes *[]string, tls *bool) {
+	if hc.HTTP == nil {
+		hc.HTTP = &schema.LoadBalancerServiceHealthCheckHTTP{Path: "/", StatusCodes: []string{"2??", "3??"}}
+	}
+	if domain != nil {
+		hc.HTTP.Domain = *domain
+	}
+	if path != nil {
+		hc.HTTP.Path = *path
+	}
+	if response != nil {
+		hc.HTTP.Response = *response
+	}
+	if statusCodes != nil {
+		hc.HTTP.StatusCodes = slices.Clone(*statusCodes)
+	}
+	if tls != nil {
+		hc.HTTP.TLS = *tls
+	}
+}
@@ -0,0 +1,121 @@
+package fakeapi
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+// metricsMaxSamples is the number of samples per time series returned when no step is
+// requested.
+const metricsMaxSamples = 100
+
+// serverMetricsSeries are the time series returned for each type of server metrics.
+var serverMetricsSeries = map[string][]string{
+	"cpu": {"cpu"},
+	"disk": {
+		"disk.0.iops.read", "disk.0.iops.write",
+		"disk.0.bandwidth.read", "disk.0.bandwidth.write",
+	},
+	"network": {
+		"network.0.pps.in", "network.0.pps.out",
+		"network.0.bandwidth.in", "network.0.bandwidth.out",
+	},
+}
+
+// loadBalancerMetricsSeries are the time series returned for each type of load balancer
+// metrics.
+var loadBalancerMetricsSeries = map[string][]string{
+	"open_connections":       {"open_connections"},
+	"connections_per_second": {"connections_per_second"},
+	"requests_per_second":    {"requests_per_second"},
+	"bandwidth":              {"bandwidth.in", "bandwidth.out"},
+}
+
+func (s *Server) registerMetrics() {
+	s.handle("GET /servers/{id}/metrics", func(r *http.Request) (any, error) {
+		if _, err := lookup(r, s.servers, "server"); err != nil {
+			return nil, err
+		}
+		start, end, step, timeSeries, err := metrics(r, serverMetricsSeries)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := schema.ServerGetMetricsResponse{}
+		resp.Metrics.Start = start
+		resp.Metrics.End = end
+		resp.Metrics.Step = step
+		resp.Metrics.TimeSeries = make(map[string]schema.ServerTimeSeriesVals, len(timeSeries))
+		for name, values := range timeSeries {
+			resp.Metrics.TimeSeries[name] = schema.ServerTimeSeriesVals{Values: values}
+		}
+		return resp, nil
+	})
+
+	s.handle("GET /load_balancers/{id}/metrics", func(r *http.Request) (any, error) {
+		if _, err := lookup(r, s.loadBalancers, "load_balancer"); err != nil {
+			return nil, err
+		}
+		start, end, step, timeSeries, err := metrics(r, loadBalancerMetricsSeries)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := schema.LoadBalancerGetMetricsResponse{}
+		resp.Metrics.Start = start
+		resp.Metrics.End = end
+		resp.Metrics.Step = step
+		resp.Metrics.TimeSeries = make(map[string]schema.LoadBalancerTimeSeriesVals, len(timeSeries))
+		for name, values := range timeSeries {
+			resp.Metrics.TimeSeries[name] = schema.LoadBalancerTimeSeriesVals{Values: values}
+		}
+		return resp, nil
+	})
+}
+
+// metrics returns the time series requested by the query parameters. The samples of
+// each series count up from 0, to make the results predictable.
+func metrics(r *http.Request, series map[string][]string) (time.Time, time.Time, float64, map[string][]any, error) {
+	query := r.URL.Query()
+
+	start, err := time.Parse(time.RFC3339, query.Get("start"))
+	if err != nil {
+		return time.Time{}, time.Time{}, 0, nil, errInvalidInput("invalid start: %s", err)
+	}
+	end, err := time.Parse(time.RFC3339, query.Get("end"))
+	if err != nil {
+		return time.Time{}, time.Time{}, 0, nil, errInvalidInput("invalid end: %s", err)
+	}
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, 0, nil, errInvalidInput("end must be after start")
+	}
+
+	step := math.Ceil(end.Sub(start).Seconds() / metricsMaxSamples)
+	if value := query.Get("step"); value != "" {
+		step, err = strconv.ParseFloat(value, 64)
+		if err != nil || step <= 0 {
+			return time.Time{}, time.Time{}, 0, nil, errInvalidInput("invalid step: %s", value)
+		}
+	}
+
+	timeSeries := make(map[string][]any)
+	for _, typ := range query["type"] {
+		names, ok := series[typ]
+		if !ok {
+			return time.Time{}, time.Time{}, 0, nil, errInvalidInput("invalid type: %s", typ)
+		}
+		for _, name := range names {
+			var values []any
+			for i, ts := 0, float64(start.Unix()); ts <= float64(end.Unix()); i, ts = i+1, ts+step {
+				values = append(values, []any{ts, strconv.Itoa(i)})
+			}
+			timeSeries[name] = values
+		}
+	}
+
+	return start, end, step, timeSeries, nil
+}
@@ -0,0 +1,310 @@
+package fakeapi
+
+import (
+	"net/http"
+	"net/netip"
+	"slices"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+var networkZones = []string{"eu-central", "us-east", "us-west", "ap-southeast"}
+
+func networkFields(n *schema.Network) (int64, string) { return n.ID, n.Name }
+
+// networkView returns the network with the attached servers and Load Balancers
+// filled in.
+func (s *Server) networkView(network *schema.Network) schema.Network {
+	view := *network
+	view.Subnets = slices.Clone(network.Subnets)
+	view.Routes = slices.Clone(network.Routes)
+
+	view.Servers = []int64{}
+	for _, server := range sortedByID(s.servers) {
+		for _, privateNet := range server.PrivateNet {
+			if privateNet.Network == network.ID {
+				view.Servers = append(view.Servers, server.ID)
+			}
+		}
+	}
+	view.LoadBalancers = []int64{}
+	for _, lb := range sortedByID(s.loadBalancers) {
+		for _, privateNet := range lb.PrivateNet {
+			if privateNet.Network == network.ID {
+				view.LoadBalancers = append(view.LoadBalancers, lb.ID)
+			}
+		}
+	}
+	return view
+}
+
+func (s *Server) registerNetworks() {
+	s.handle("GET /networks", func(r *http.Request) (any, error) {
+		return list(r, "networks", s.networks, listFields[schema.Network]{
+			id:     func(n *schema.Network) int64 { return n.ID },
+			name:   func(n *schema.Network) string { return n.Name },
+			labels: func(n *schema.Network) map[string]string { return n.Labels },
+		}, s.networkView)
+	})
+
+	s.handle("GET /networks/{id}", func(r *http.Request) (any, error) {
+		network, err := lookup(r, s.networks, "network")
+		if err != nil {
+			return nil, err
+		}
+		return schema.NetworkGetResponse{Network: s.networkView(network)}, nil
+	})
+
+	s.handle("POST /networks", func(r *http.Request) (any, error) {
+		req, err := decode[schema.NetworkCreateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name == "" {
+			return nil, errInvalidInput("name is required")
+		}
+		if nameTaken(s.networks, 0, req.Name, networkFields) {
+			return nil, errUniqueness("name")
+		}
+		ipRange, err := parsePrivateRange(req.IPRange)
+		if err != nil {
+			return nil, err
+		}
+
+		network := &schema.Network{
+			ID:                    s.nextID(),
+			Name:                  req.Name,
+			Created:               now(),
+			IPRange:               ipRange.String(),
+			Subnets:               []schema.NetworkSubnet{},
+			Routes:                []schema.NetworkRoute{},
+			Labels:                copyLabels(req.Labels),
+			ExposeRoutesToVSwitch: req.ExposeRoutesToVSwitch,
+		}
+		for _, subnet := range req.Subnets {
+			if err := addSubnet(network, subnet); err != nil {
+				return nil, err
+			}
+		}
+		for _, route := range req.Routes {
+			if err := addRoute(network, route); err != nil {
+				return nil, err
+			}
+		}
+		s.networks[network.ID] = network
+		return schema.NetworkCreateResponse{Network: s.networkView(network)}, nil
+	})
+
+	s.handle("PUT /networks/{id}", func(r *http.Request) (any, error) {
+		network, err := lookup(r, s.networks, "network")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.NetworkUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name != "" {
+			if nameTaken(s.networks, network.ID, req.Name, networkFields) {
+				return nil, errUniqueness("name")
+			}
+			network.Name = req.Name
+		}
+		if req.Labels != nil {
+			network.Labels = copyLabels(req.Labels)
+		}
+		if req.ExposeRoutesToVSwitch != nil {
+			network.ExposeRoutesToVSwitch = *req.ExposeRoutesToVSwitch
+		}
+		return schema.NetworkUpdateResponse{Network: s.networkView(network)}, nil
+	})
+
+	s.handle("DELETE /networks/{id}", func(r *http.Request) (any, error) {
+		network, err := lookup(r, s.networks, "network")
+		if err != nil {
+			return nil, err
+		}
+		if network.Protection.Delete {
+			return nil, errProtected("network")
+		}
+		for _, server := range s.servers {
+			server.PrivateNet = slices.DeleteFunc(server.PrivateNet, func(privateNet schema.ServerPrivateNet) bool {
+				return privateNet.Network == network.ID
+			})
+		}
+		for _, lb := range s.loadBalancers {
+			lb.PrivateNet = slices.DeleteFunc(lb.PrivateNet, func(privateNet schema.LoadBalancerPrivateNet) bool {
+				return privateNet.Network == network.ID
+			})
+		}
+		delete(s.networks, network.ID)
+		return nil, nil
+	})
+
+	s.handle("POST /networks/{id}/actions/{action}", func(r *http.Request) (any, error) {
+		network, err := lookup(r, s.networks, "network")
+		if err != nil {
+			return nil, err
+		}
+		ref := resourceRef("network", network.ID)
+
+		switch command := r.PathValue("action"); command {
+		case "add_subnet":
+			req, err := decode[schema.NetworkActionAddSubnetRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			subnet := schema.NetworkSubnet{Type: req.Type, IPRange: req.IPRange, NetworkZone: req.NetworkZone, VSwitchID: req.VSwitchID}
+			if err := addSubnet(network, subnet); err != nil {
+				return nil, err
+			}
+			return schema.NetworkActionAddSubnetResponse{Action: s.newAction(command, ref)}, nil
+
+		case "delete_subnet":
+			req, err := decode[schema.NetworkActionDeleteSubnetRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			idx := slices.IndexFunc(network.Subnets, func(subnet schema.NetworkSubnet) bool {
+				return subnet.IPRange == req.IPRange
+			})
+			if idx < 0 {
+				return nil, errNotFound("subnet")
+			}
+			if s.subnetInUse(network.ID, network.Subnets[idx].IPRange) {
+				return nil, errConflict("conflict", "subnet has attached resources")
+			}
+			network.Subnets = slices.Delete(network.Subnets, idx, idx+1)
+			return schema.NetworkActionDeleteSubnetResponse{Action: s.newAction(command, ref)}, nil
+
+		case "add_route":
+			req, err := decode[schema.NetworkActionAddRouteRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if err := addRoute(network, schema.NetworkRoute(req)); err != nil {
+				return nil, err
+			}
+			return schema.NetworkActionAddRouteResponse{Action: s.newAction(command, ref)}, nil
+
+		case "delete_route":
+			req, err := decode[schema.NetworkActionDeleteRouteRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			idx := slices.Index(network.Routes, schema.NetworkRoute(req))
+			if idx < 0 {
+				return nil, errNotFound("route")
+			}
+			network.Routes = slices.Delete(network.Routes, idx, idx+1)
+			return schema.NetworkActionDeleteRouteResponse{Action: s.newAction(command, ref)}, nil
+
+		case "change_ip_range":
+			req, err := decode[schema.NetworkActionChangeIPRangeRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			ipRange, err := parsePrivateRange(req.IPRange)
+			if err != nil {
+				return nil, err
+			}
+			current := netip.MustParsePrefix(network.IPRange)
+			if ipRange.Bits() > current.Bits() || !ipRange.Contains(current.Addr()) {
+				return nil, errInvalidInput("ip_range can only be extended")
+			}
+			network.IPRange = ipRange.String()
+			return schema.NetworkActionChangeIPRangeResponse{Action: s.newAction(command, ref)}, nil
+
+		case "change_protection":
+			req, err := decode[schema.NetworkActionChangeProtectionRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if req.Delete != nil {
+				network.Protection.Delete = *req.Delete
+			}
+			return schema.NetworkActionChangeProtectionResponse{Action: s.newAction(command, ref)}, nil
+
+		default:
+			return nil, errNotFound("action")
+		}
+	})
+}
+
+// parsePrivateRange parses an IPv4 network range, as accepted for networks.
+func parsePrivateRange(value string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil || !prefix.Addr().Is4() {
+		return netip.Prefix{}, errInvalidInput("invalid ip_range: %q", value)
+	}
+	if prefix != prefix.Masked() {
+		return netip.Prefix{}, errInvalidInput("ip_range %q is not a network address", value)
+	}
+	return prefix, nil
+}
+
+// addSubnet validates the subnet and adds it to the network.
+func addSubnet(network *schema.Network, subnet schema.NetworkSubnet) error {
+	switch subnet.Type {
+	case "cloud", "server":
+	case "vswitch":
+		if subnet.VSwitchID == 0 {
+			return errInvalidInput("vswitch_id is required for vswitch subnets")
+		}
+	default:
+		return errInvalidInput("invalid subnet type: %q", subnet.Type)
+	}
+	if !slices.Contains(networkZones, subnet.NetworkZone) {
+		return errInvalidInput("invalid network_zone: %q", subnet.NetworkZone)
+	}
+
+	ipRange, err := parsePrivateRange(subnet.IPRange)
+	if err != nil {
+		return err
+	}
+	networkRange := netip.MustParsePrefix(network.IPRange)
+	if ipRange.Bits() < networkRange.Bits() || !networkRange.Contains(ipRange.Addr()) {
+		return errInvalidInput("subnet %s is not part of the network ip_range %s", ipRange, networkRange)
+	}
+	for _, existing := range network.Subnets {
+		if netip.MustParsePrefix(existing.IPRange).Overlaps(ipRange) {
+			return errConflict("ip_range_overlap", "subnet overlaps with an existing subnet")
+		}
+	}
+
+	subnet.IPRange = ipRange.String()
+	subnet.Gateway = firstHost(network.IPRange)
+	network.Subnets = append(network.Subnets, subnet)
+	return nil
+}
+
+// addRoute validates the route and adds it to the network.
+func addRoute(network *schema.Network, route schema.NetworkRoute) error {
+	if _, err := netip.ParsePrefix(route.Destination); err != nil {
+		return errInvalidInput("invalid destination: %q", route.Destination)
+	}
+	gateway, err := netip.ParseAddr(route.Gateway)
+	if err != nil {
+		return errInvalidInput("invalid gateway: %q", route.Gateway)
+	}
+	if !netip.MustParsePrefix(network.IPRange).Contains(gateway) {
+		return errInvalidInput("gateway %s is not part of the network ip_range", route.Gateway)
+	}
+	if slices.Contains(network.Routes, route) {
+		return errConflict("conflict", "route already exists")
+	}
+	network.Routes = append(network.Routes, route)
+	return nil
+}
+
+// subnetInUse reports whether a server or Load Balancer has an IP in the
+// subnet.
+func (s *Server) subnetInUse(networkID int64, ipRange string) bool {
+	prefix := netip.MustParsePrefix(ipRange)
+	for ip := range s.usedPrivateIPs(networkID) {
+		if addr, err := netip.ParseAddr(ip); err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package fakeapi
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+func placementGroupFields(p *schema.PlacementGroup) (int64, string) { return p.ID, p.Name }
+
+// serverPlacementGroup returns the Placement Group of the server, or nil.
+func (s *Server) serverPlacementGroup(serverID int64) *schema.PlacementGroup {
+	for _, placementGroup := range s.placementGroups {
+		if slices.Contains(placementGroup.Servers, serverID) {
+			return placementGroup
+		}
+	}
+	return nil
+}
+
+// removeFromPlacementGroup removes the server from its Placement Group.
+func (s *Server) removeFromPlacementGroup(serverID int64) {
+	for _, placementGroup := range s.placementGroups {
+		placementGroup.Servers = slices.DeleteFunc(placementGroup.Servers, func(id int64) bool {
+			return id == serverID
+		})
+	}
+}
+
+func (s *Server) registerPlacementGroups() {
+	s.handle("GET /placement_groups", func(r *http.Request) (any, error) {
+		return list(r, "placement_groups", s.placementGroups, listFields[schema.PlacementGroup]{
+			id:     func(p *schema.PlacementGroup) int64 { return p.ID },
+			name:   func(p *schema.PlacementGroup) string { return p.Name },
+			labels: func(p *schema.PlacementGroup) map[string]string { return p.Labels },
+			filters: map[string]func(*schema.PlacementGroup) string{
+				"type": func(p *schema.PlacementGroup) string { return p.Type },
+			},
+		}, nil)
+	})
+
+	s.handle("GET /placement_groups/{id}", func(r *http.Request) (any, error) {
+		placementGroup, err := lookup(r, s.placementGroups, "placement_group")
+		if err != nil {
+			return nil, err
+		}
+		return schema.PlacementGroupGetResponse{PlacementGroup: *placementGroup}, nil
+	})
+
+	s.handle("POST /placement_groups", func(r *http.Request) (any, error) {
+		req, err := decode[schema.PlacementGroupCreateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name == "" {
+			return nil, errInvalidInput("name is required")
+		}
+		if req.Type != "spread" {
+			return nil, errInvalidInput("type must be spread")
+		}
+		if nameTaken(s.placementGroups, 0, req.Name, placementGroupFields) {
+			return nil, errUniqueness("name")
+		}
+
+		placementGroup := &schema.PlacementGroup{
+			ID:      s.nextID(),
+			Name:    req.Name,
+			Type:    req.Type,
+			Labels:  copyLabels(req.Labels),
+			Servers: []int64{},
+			Created: now(),
+		}
+		s.placementGroups[placementGroup.ID] = placementGroup
+		return schema.PlacementGroupCreateResponse{PlacementGroup: *placementGroup}, nil
+	})
+
+	s.handle("PUT /placement_groups/{id}", func(r *http.Request) (any, error) {
+		placementGroup, err := lookup(r, s.placementGroups, "placement_group")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.PlacementGroupUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name != nil {
+			if nameTaken(s.placementGroups, placementGroup.ID, *req.Name, placementGroupFields) {
+				return nil, errUniqueness("name")
+			}
+			placementGroup.Name = *req.Name
+		}
+		if req.Labels != nil {
+			placementGroup.Labels = copyLabels(req.Labels)
+		}
+		return schema.PlacementGroupUpdateResponse{PlacementGroup: *placementGroup}, nil
+	})
+
+	s.handle("DELETE /placement_groups/{id}", func(r *http.Request) (any, error) {
+		placementGroup, err := lookup(r, s.placementGroups, "placement_group")
+		if err != nil {
+			return nil, err
+		}
+		delete(s.placementGroups, placementGroup.ID)
+		return nil, nil
+	})
+}
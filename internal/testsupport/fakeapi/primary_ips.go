@@ -0,0 +1,269 @@
+package fakeapi
+
+import (
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+func primaryIPFields(p *schema.PrimaryIP) (int64, string) { return p.ID, p.Name }
+
+// newPrimaryIP creates a Primary IP of the given type in the location.
+func (s *Server) newPrimaryIP(name, typ string, location schema.Location) *schema.PrimaryIP {
+	id := s.nextID()
+	if name == "" {
+		name = "primary_ip-" + strconv.FormatInt(id, 10)
+	}
+
+	primaryIP := &schema.PrimaryIP{
+		ID:           id,
+		Name:         name,
+		Type:         typ,
+		AssigneeType: "server",
+		Labels:       map[string]string{},
+		DNSPtr:       []schema.PrimaryIPDNSPTR{},
+		Created:      now(),
+		Location:     location,
+		Datacenter:   s.datacenterIn(location),
+	}
+	if typ == "ipv4" {
+		primaryIP.IP = s.nextPublicIPv4()
+		primaryIP.DNSPtr = append(primaryIP.DNSPtr, schema.PrimaryIPDNSPTR{
+			IP:     primaryIP.IP,
+			DNSPtr: "static." + primaryIP.IP + ".clients.your-server.de",
+		})
+	} else {
+		primaryIP.IP = s.nextPublicIPv6()
+	}
+	s.primaryIPs[primaryIP.ID] = primaryIP
+	return primaryIP
+}
+
+// assignedPrimaryIP returns the Primary IP of the given type assigned to the
+// server.
+func (s *Server) assignedPrimaryIP(serverID int64, typ string) *schema.PrimaryIP {
+	for _, primaryIP := range s.primaryIPs {
+		if primaryIP.AssigneeID != nil && *primaryIP.AssigneeID == serverID && primaryIP.Type == typ {
+			return primaryIP
+		}
+	}
+	return nil
+}
+
+// assignPrimaryIP assigns the Primary IP to the server.
+func (s *Server) assignPrimaryIP(primaryIP *schema.PrimaryIP, server *schema.Server) error {
+	if primaryIP.AssigneeID != nil {
+		return errConflict("primary_ip_assigned", "primary IP is already assigned")
+	}
+	if s.assignedPrimaryIP(server.ID, primaryIP.Type) != nil {
+		return errConflict("server_has_"+primaryIP.Type, "server already has a primary IP of this type")
+	}
+	if primaryIP.Location.ID != server.Location.ID {
+		return errInvalidInput("primary IP and server must be in the same location")
+	}
+	primaryIP.AssigneeID = ptr(server.ID)
+	return nil
+}
+
+// setDNSPtr sets or resets the reverse DNS entry of an IP of the Primary IP.
+func setDNSPtr(primaryIP *schema.PrimaryIP, ip string, dnsPtr *string) error {
+	if !primaryIPContains(primaryIP, ip) {
+		return errInvalidInput("ip %s does not belong to the primary IP", ip)
+	}
+
+	primaryIP.DNSPtr = slices.DeleteFunc(primaryIP.DNSPtr, func(entry schema.PrimaryIPDNSPTR) bool {
+		return entry.IP == ip
+	})
+	if dnsPtr != nil {
+		primaryIP.DNSPtr = append(primaryIP.DNSPtr, schema.PrimaryIPDNSPTR{IP: ip, DNSPtr: *dnsPtr})
+	}
+	return nil
+}
+
+// primaryIPContains reports whether the IP is the Primary IPv4 or part of the
+// Primary IPv6 network.
+func primaryIPContains(primaryIP *schema.PrimaryIP, ip string) bool {
+	if primaryIP.Type == "ipv4" {
+		return primaryIP.IP == ip
+	}
+	prefix, err := netip.ParsePrefix(primaryIP.IP)
+	if err != nil {
+		return false
+	}
+	addr, err := netip.ParseAddr(ip)
+	return err == nil && prefix.Contains(addr)
+}
+
+func (s *Server) registerPrimaryIPs() {
+	s.handle("GET /primary_ips", func(r *http.Request) (any, error) {
+		return list(r, "primary_ips", s.primaryIPs, listFields[schema.PrimaryIP]{
+			id:     func(p *schema.PrimaryIP) int64 { return p.ID },
+			name:   func(p *schema.PrimaryIP) string { return p.Name },
+			labels: func(p *schema.PrimaryIP) map[string]string { return p.Labels },
+			filters: map[string]func(*schema.PrimaryIP) string{
+				"ip": func(p *schema.PrimaryIP) string { return p.IP },
+			},
+		}, nil)
+	})
+
+	s.handle("GET /primary_ips/{id}", func(r *http.Request) (any, error) {
+		primaryIP, err := lookup(r, s.primaryIPs, "primary_ip")
+		if err != nil {
+			return nil, err
+		}
+		return schema.PrimaryIPGetResponse{PrimaryIP: *primaryIP}, nil
+	})
+
+	s.handle("POST /primary_ips", func(r *http.Request) (any, error) {
+		req, err := decode[schema.PrimaryIPCreateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name == "" {
+			return nil, errInvalidInput("name is required")
+		}
+		if req.Type != "ipv4" && req.Type != "ipv6" {
+			return nil, errInvalidInput("type must be one of ipv4, ipv6")
+		}
+		if nameTaken(s.primaryIPs, 0, req.Name, primaryIPFields) {
+			return nil, errUniqueness("name")
+		}
+
+		var server *schema.Server
+		if req.AssigneeID != nil {
+			var ok bool
+			if server, ok = s.servers[*req.AssigneeID]; !ok {
+				return nil, errInvalidInput("server %d not found", *req.AssigneeID)
+			}
+		}
+		location, err := s.resolveLocation(req.Location, req.Datacenter)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case location == nil && server == nil:
+			return nil, errInvalidInput("one of location or assignee_id is required")
+		case location == nil:
+			location = &server.Location
+		}
+
+		primaryIP := s.newPrimaryIP(req.Name, req.Type, *location)
+		primaryIP.Labels = copyLabels(req.Labels)
+		if req.AutoDelete != nil {
+			primaryIP.AutoDelete = *req.AutoDelete
+		}
+
+		resp := schema.PrimaryIPCreateResponse{}
+		if server != nil {
+			if err := s.assignPrimaryIP(primaryIP, server); err != nil {
+				delete(s.primaryIPs, primaryIP.ID)
+				return nil, err
+			}
+			resp.Action = ptr(s.newAction("create_primary_ip", resourceRef("primary_ip", primaryIP.ID), resourceRef("server", server.ID)))
+		}
+		resp.PrimaryIP = *primaryIP
+		return resp, nil
+	})
+
+	s.handle("PUT /primary_ips/{id}", func(r *http.Request) (any, error) {
+		primaryIP, err := lookup(r, s.primaryIPs, "primary_ip")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.PrimaryIPUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name != "" {
+			if nameTaken(s.primaryIPs, primaryIP.ID, req.Name, primaryIPFields) {
+				return nil, errUniqueness("name")
+			}
+			primaryIP.Name = req.Name
+		}
+		if req.Labels != nil {
+			primaryIP.Labels = copyLabels(req.Labels)
+		}
+		if req.AutoDelete != nil {
+			primaryIP.AutoDelete = *req.AutoDelete
+		}
+		return schema.PrimaryIPUpdateResponse{PrimaryIP: *primaryIP}, nil
+	})
+
+	s.handle("DELETE /primary_ips/{id}", func(r *http.Request) (any, error) {
+		primaryIP, err := lookup(r, s.primaryIPs, "primary_ip")
+		if err != nil {
+			return nil, err
+		}
+		if primaryIP.Protection.Delete {
+			return nil, errProtected("primary_ip")
+		}
+		delete(s.primaryIPs, primaryIP.ID)
+		return nil, nil
+	})
+
+	s.handle("POST /primary_ips/{id}/actions/{action}", func(r *http.Request) (any, error) {
+		primaryIP, err := lookup(r, s.primaryIPs, "primary_ip")
+		if err != nil {
+			return nil, err
+		}
+		ref := resourceRef("primary_ip", primaryIP.ID)
+
+		switch command := r.PathValue("action"); command {
+		case "assign":
+			req, err := decode[schema.PrimaryIPActionAssignRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			server, ok := s.servers[req.AssigneeID]
+			if !ok {
+				return nil, errInvalidInput("server %d not found", req.AssigneeID)
+			}
+			if server.Status != "off" {
+				return nil, errConflict("server_not_stopped", "the server must be stopped")
+			}
+			if err := s.assignPrimaryIP(primaryIP, server); err != nil {
+				return nil, err
+			}
+			return schema.PrimaryIPActionAssignResponse{
+				Action: s.newAction("assign_primary_ip", ref, resourceRef("server", server.ID)),
+			}, nil
+
+		case "unassign":
+			if primaryIP.AssigneeID == nil {
+				return nil, errInvalidInput("primary IP is not assigned")
+			}
+			serverID := *primaryIP.AssigneeID
+			if server, ok := s.servers[serverID]; ok && server.Status != "off" {
+				return nil, errConflict("server_not_stopped", "the server must be stopped")
+			}
+			primaryIP.AssigneeID = nil
+			return schema.PrimaryIPActionUnassignResponse{
+				Action: s.newAction("unassign_primary_ip", ref, resourceRef("server", serverID)),
+			}, nil
+
+		case "change_dns_ptr":
+			req, err := decode[schema.PrimaryIPActionChangeDNSPtrRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if err := setDNSPtr(primaryIP, req.IP, req.DNSPtr); err != nil {
+				return nil, err
+			}
+			return schema.PrimaryIPActionChangeDNSPtrResponse{Action: s.newAction(command, ref)}, nil
+
+		case "change_protection":
+			req, err := decode[schema.PrimaryIPActionChangeProtectionRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			primaryIP.Protection.Delete = req.Delete
+			return schema.PrimaryIPActionChangeProtectionResponse{Action: s.newAction(command, ref)}, nil
+
+		default:
+			return nil, errNotFound("action")
+		}
+	})
+}
@@ -0,0 +1,331 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+func rrsetView(rrset *schema.ZoneRRSet) schema.ZoneRRSet {
+	view := *rrset
+	view.Records = slices.Clone(rrset.Records)
+	return view
+}
+
+func (s *Server) registerRRSets() {
+	s.handle("GET /zones/{zone}/rrsets", func(r *http.Request) (any, error) {
+		zone, err := s.findZone(r)
+		if err != nil {
+			return nil, err
+		}
+		rrsets := make(map[int64]*schema.ZoneRRSet)
+		for key, rrset := range s.rrsets {
+			if rrset.Zone == zone.ID {
+				rrsets[key] = rrset
+			}
+		}
+		return list(r, "rrsets", rrsets, listFields[schema.ZoneRRSet]{
+			name:   func(rr *schema.ZoneRRSet) string { return rr.Name },
+			labels: func(rr *schema.ZoneRRSet) map[string]string { return rr.Labels },
+			filters: map[string]func(*schema.ZoneRRSet) string{
+				"type": func(rr *schema.ZoneRRSet) string { return rr.Type },
+			},
+		}, rrsetView)
+	})
+
+	s.handle("GET /zones/{zone}/rrsets/{name}/{type}", func(r *http.Request) (any, error) {
+		_, _, rrset, err := s.findRRSet(r)
+		if err != nil {
+			return nil, err
+		}
+		return schema.ZoneRRSetGetResponse{RRSet: rrsetView(rrset)}, nil
+	})
+
+	s.handle("POST /zones/{zone}/rrsets", func(r *http.Request) (any, error) {
+		zone, err := s.findZone(r)
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.ZoneRRSetCreateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if zone.Mode != "primary" {
+			return nil, errSecondaryZone()
+		}
+		rrset, err := s.addRRSet(zone, req)
+		if err != nil {
+			return nil, err
+		}
+		return schema.ZoneRRSetCreateResponse{
+			RRSet:  rrsetView(rrset),
+			Action: s.newAction("create_rrset", resourceRef("zone", zone.ID)),
+		}, nil
+	})
+
+	s.handle("PUT /zones/{zone}/rrsets/{name}/{type}", func(r *http.Request) (any, error) {
+		_, _, rrset, err := s.findRRSet(r)
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.ZoneRRSetUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Labels != nil {
+			rrset.Labels = copyLabels(req.Labels)
+		}
+		return schema.ZoneRRSetUpdateResponse{RRSet: rrsetView(rrset)}, nil
+	})
+
+	s.handle("DELETE /zones/{zone}/rrsets/{name}/{type}", func(r *http.Request) (any, error) {
+		zone, key, rrset, err := s.findRRSet(r)
+		if err != nil {
+			return nil, err
+		}
+		if rrset.Protection.Change {
+			return nil, errProtected("rrset")
+		}
+		if rrset.Type == "SOA" {
+			return nil, errInvalidInput("the SOA record can not be deleted")
+		}
+		delete(s.rrsets, key)
+		return schema.ActionGetResponse{Action: s.newAction("delete_rrset", resourceRef("zone", zone.ID))}, nil
+	})
+
+	s.handle("POST /zones/{zone}/rrsets/{name}/{type}/actions/{action}", func(r *http.Request) (any, error) {
+		zone, key, rrset, err := s.findRRSet(r)
+		if err != nil {
+			return nil, err
+		}
+		command := r.PathValue("action")
+		if rrset.Protection.Change && command != "change_protection" {
+			return nil, errProtected("rrset")
+		}
+		ref := resourceRef("zone", zone.ID)
+
+		switch command {
+		case "change_protection":
+			req, err := decode[schema.ZoneRRSetChangeProtectionRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if req.Change != nil {
+				rrset.Protection.Change = *req.Change
+			}
+
+		case "change_ttl":
+			req, err := decode[schema.ZoneRRSetChangeTTLRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if req.TTL != nil {
+				if err := validateTTL(*req.TTL); err != nil {
+					return nil, err
+				}
+			}
+			rrset.TTL = req.TTL
+
+		case "set_records":
+			req, err := decode[schema.ZoneRRSetSetRecordsRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if err := validateRecords(rrset.Type, req.Records); err != nil {
+				return nil, err
+			}
+			rrset.Records = slices.Clone(req.Records)
+
+		case "add_records":
+			req, err := decode[schema.ZoneRRSetAddRecordsRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if err := validateRecords(rrset.Type, req.Records); err != nil {
+				return nil, err
+			}
+			for _, record := range req.Records {
+				if !slices.ContainsFunc(rrset.Records, sameRecordValue(record.Value)) {
+					rrset.Records = append(rrset.Records, record)
+				}
+			}
+			if req.TTL != nil {
+				rrset.TTL = req.TTL
+			}
+
+		case "update_records":
+			req, err := decode[schema.ZoneRRSetUpdateRecordsRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			for _, record := range req.Records {
+				idx := slices.IndexFunc(rrset.Records, sameRecordValue(record.Value))
+				if idx < 0 {
+					return nil, errNotFound("record")
+				}
+				rrset.Records[idx].Comment = record.Comment
+			}
+
+		case "remove_records":
+			req, err := decode[schema.ZoneRRSetRemoveRecordsRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			for _, record := range req.Records {
+				rrset.Records = slices.DeleteFunc(rrset.Records, sameRecordValue(record.Value))
+			}
+			if len(rrset.Records) == 0 {
+				delete(s.rrsets, key)
+			}
+
+		default:
+			return nil, errNotFound("action")
+		}
+		return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+	})
+}
+
+// addRRSet validates the RRSet and adds it to the zone.
+func (s *Server) addRRSet(zone *schema.Zone, req schema.ZoneRRSetCreateRequest) (*schema.ZoneRRSet, error) {
+	if req.Name == "" || req.Name != strings.ToLower(req.Name) {
+		return nil, errInvalidInput("invalid name: %q", req.Name)
+	}
+	if req.TTL != nil {
+		if err := validateTTL(*req.TTL); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateRecords(req.Type, req.Records); err != nil {
+		return nil, err
+	}
+
+	id := req.Name + "/" + req.Type
+	for _, rrset := range s.zoneRRSets(zone.ID) {
+		if rrset.ID == id {
+			return nil, errUniqueness("name")
+		}
+	}
+
+	rrset := &schema.ZoneRRSet{
+		ID:      id,
+		Name:    req.Name,
+		Type:    req.Type,
+		TTL:     req.TTL,
+		Labels:  copyLabels(req.Labels),
+		Records: slices.Clone(req.Records),
+		Zone:    zone.ID,
+	}
+	s.rrsets[s.nextID()] = rrset
+	return rrset, nil
+}
+
+func validateRecords(typ string, records []schema.ZoneRRSetRecord) error {
+	if !slices.Contains(rrsetTypes, typ) {
+		return errInvalidInput("invalid type: %q", typ)
+	}
+	if len(records) == 0 {
+		return errInvalidInput("records must not be empty")
+	}
+	for _, record := range records {
+		if record.Value == "" {
+			return errInvalidInput("record value must not be empty")
+		}
+	}
+	return nil
+}
+
+func sameRecordValue(value string) func(schema.ZoneRRSetRecord) bool {
+	return func(record schema.ZoneRRSetRecord) bool { return record.Value == value }
+}
+
+// exportZonefile renders the RRSets of the zone in the zone file format.
+func (s *Server) exportZonefile(zone *schema.Zone) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s.\n$TTL %d\n", zone.Name, zone.TTL)
+	for _, rrset := range s.zoneRRSets(zone.ID) {
+		ttl := ""
+		if rrset.TTL != nil {
+			ttl = strconv.Itoa(*rrset.TTL)
+		}
+		for _, record := range rrset.Records {
+			line := strings.Join(slices.DeleteFunc([]string{rrset.Name, ttl, "IN", rrset.Type, record.Value}, func(v string) bool {
+				return v == ""
+			}), " ")
+			if record.Comment != "" {
+				line += " ; " + record.Comment
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// importZonefile replaces the RRSets of the zone with the records of the zone
+// file. Only the subset of the format written by exportZonefile is supported.
+func (s *Server) importZonefile(zone *schema.Zone, zonefile string) error {
+	rrsets := make(map[string]*schema.ZoneRRSet)
+	var order []string
+
+	for n, line := range strings.Split(zonefile, "\n") {
+		line, comment, _ := strings.Cut(line, ";")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "$ORIGIN" {
+			continue
+		}
+		if fields[0] == "$TTL" {
+			if len(fields) != 2 {
+				return errInvalidInput("invalid zonefile: line %d", n+1)
+			}
+			ttl, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return errInvalidInput("invalid zonefile: line %d", n+1)
+			}
+			zone.TTL = ttl
+			continue
+		}
+
+		name, rest := fields[0], fields[1:]
+		var ttl *int
+		if len(rest) > 0 {
+			if value, err := strconv.Atoi(rest[0]); err == nil {
+				ttl, rest = &value, rest[1:]
+			}
+		}
+		if len(rest) > 0 && rest[0] == "IN" {
+			rest = rest[1:]
+		}
+		if len(rest) < 2 {
+			return errInvalidInput("invalid zonefile: line %d", n+1)
+		}
+		typ, value := rest[0], strings.Join(rest[1:], " ")
+		if !slices.Contains(rrsetTypes, typ) {
+			return errInvalidInput("invalid zonefile: unsupported type %q in line %d", typ, n+1)
+		}
+
+		id := name + "/" + typ
+		rrset, ok := rrsets[id]
+		if !ok {
+			rrset = &schema.ZoneRRSet{ID: id, Name: name, Type: typ, TTL: ttl, Labels: map[string]string{}, Zone: zone.ID}
+			rrsets[id] = rrset
+			order = append(order, id)
+		}
+		rrset.Records = append(rrset.Records, schema.ZoneRRSetRecord{Value: value, Comment: strings.TrimSpace(comment)})
+	}
+
+	for key, rrset := range s.rrsets {
+		if rrset.Zone == zone.ID {
+			delete(s.rrsets, key)
+		}
+	}
+	for _, id := range order {
+		s.rrsets[s.nextID()] = rrsets[id]
+	}
+	return nil
+}
@@ -0,0 +1,688 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+func serverFields(s *schema.Server) (int64, string) { return s.ID, s.Name }
+
+// serverView returns the server with the attributes derived from other
+// resources (Primary IPs, Volumes, Firewalls, Load Balancers) filled in.
+func (s *Server) serverView(server *schema.Server) schema.Server {
+	view := *server
+
+	view.PublicNet = schema.ServerPublicNet{
+		IPv4:        schema.ServerPublicNetIPv4{},
+		IPv6:        schema.ServerPublicNetIPv6{DNSPtr: []schema.ServerPublicNetIPv6DNSPtr{}},
+		FloatingIPs: s.assignedFloatingIPs(server.ID),
+		Firewalls:   []schema.ServerFirewall{},
+	}
+	if ipv4 := s.assignedPrimaryIP(server.ID, "ipv4"); ipv4 != nil {
+		view.PublicNet.IPv4 = schema.ServerPublicNetIPv4{ID: ipv4.ID, IP: ipv4.IP, Blocked: ipv4.Blocked}
+		for _, entry := range ipv4.DNSPtr {
+			if entry.IP == ipv4.IP {
+				view.PublicNet.IPv4.DNSPtr = entry.DNSPtr
+			}
+		}
+	}
+	if ipv6 := s.assignedPrimaryIP(server.ID, "ipv6"); ipv6 != nil {
+		view.PublicNet.IPv6 = schema.ServerPublicNetIPv6{ID: ipv6.ID, IP: ipv6.IP, Blocked: ipv6.Blocked, DNSPtr: []schema.ServerPublicNetIPv6DNSPtr{}}
+		for _, entry := range ipv6.DNSPtr {
+			view.PublicNet.IPv6.DNSPtr = append(view.PublicNet.IPv6.DNSPtr, schema.ServerPublicNetIPv6DNSPtr{IP: entry.IP, DNSPtr: entry.DNSPtr})
+		}
+	}
+	for _, firewall := range sortedByID(s.firewalls) {
+		if firewallAppliesTo(firewall, server) {
+			view.PublicNet.Firewalls = append(view.PublicNet.Firewalls, schema.ServerFirewall{ID: firewall.ID, Status: "applied"})
+		}
+	}
+
+	view.Volumes = []int64{}
+	for _, volume := range sortedByID(s.volumes) {
+		if volume.Server != nil && *volume.Server == server.ID {
+			view.Volumes = append(view.Volumes, volume.ID)
+		}
+	}
+
+	view.LoadBalancers = []int64{}
+	for _, lb := range sortedByID(s.loadBalancers) {
+		if loadBalancerTargets(lb, server) {
+			view.LoadBalancers = append(view.LoadBalancers, lb.ID)
+		}
+	}
+
+	view.PlacementGroup = nil
+	if placementGroup := s.serverPlacementGroup(server.ID); placementGroup != nil {
+		view.PlacementGroup = ptr(*placementGroup)
+	}
+
+	view.PrivateNet = slices.Clone(server.PrivateNet)
+	return view
+}
+
+func (s *Server) registerServers() {
+	s.handle("GET /servers", func(r *http.Request) (any, error) {
+		return list(r, "servers", s.servers, listFields[schema.Server]{
+			id:     func(srv *schema.Server) int64 { return srv.ID },
+			name:   func(srv *schema.Server) string { return srv.Name },
+			labels: func(srv *schema.Server) map[string]string { return srv.Labels },
+			filters: map[string]func(*schema.Server) string{
+				"status": func(srv *schema.Server) string { return srv.Status },
+			},
+		}, s.serverView)
+	})
+
+	s.handle("GET /servers/{id}", func(r *http.Request) (any, error) {
+		server, err := lookup(r, s.servers, "server")
+		if err != nil {
+			return nil, err
+		}
+		return schema.ServerGetResponse{Server: s.serverView(server)}, nil
+	})
+
+	s.handle("POST /servers", s.createServer)
+
+	s.handle("PUT /servers/{id}", func(r *http.Request) (any, error) {
+		server, err := lookup(r, s.servers, "server")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.ServerUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name != "" {
+			if nameTaken(s.servers, server.ID, req.Name, serverFields) {
+				return nil, errUniqueness("name")
+			}
+			server.Name = req.Name
+		}
+		if req.Labels != nil {
+			server.Labels = copyLabels(req.Labels)
+		}
+		return schema.ServerUpdateResponse{Server: s.serverView(server)}, nil
+	})
+
+	s.handle("DELETE /servers/{id}", func(r *http.Request) (any, error) {
+		server, err := lookup(r, s.servers, "server")
+		if err != nil {
+			return nil, err
+		}
+		if server.Protection.Delete {
+			return nil, errProtected("server")
+		}
+		s.deleteServer(server)
+		return schema.ServerDeleteResponse{Action: s.newAction("delete_server", resourceRef("server", server.ID))}, nil
+	})
+
+	s.handle("POST /servers/{id}/actions/{action}", func(r *http.Request) (any, error) {
+		server, err := lookup(r, s.servers, "server")
+		if err != nil {
+			return nil, err
+		}
+		if server.Locked {
+			return nil, errConflict("locked", "server is locked")
+		}
+		return s.serverAction(r, server)
+	})
+}
+
+func (s *Server) createServer(r *http.Request) (any, error) {
+	req, err := decode[schema.ServerCreateRequest](r)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, errInvalidInput("name is required")
+	}
+	if nameTaken(s.servers, 0, req.Name, serverFields) {
+		return nil, errUniqueness("name")
+	}
+
+	serverType, ok := findByIDOrName(s.serverTypes, req.ServerType, serverTypeFields)
+	if !ok {
+		return nil, errInvalidInput("server_type %v not found", req.ServerType)
+	}
+	image, ok := s.findImage(req.Image, serverType.Architecture)
+	if !ok {
+		return nil, errInvalidInput("image %v not found", req.Image)
+	}
+	if image.Architecture != serverType.Architecture {
+		return nil, errInvalidInput("image architecture does not match the server type architecture")
+	}
+
+	location, err := s.resolveLocation(req.Location, req.Datacenter)
+	if err != nil {
+		return nil, err
+	}
+	if location == nil {
+		location = s.locations[1]
+	}
+
+	for _, id := range req.SSHKeys {
+		if _, ok := s.sshKeys[id]; !ok {
+			return nil, errInvalidInput("ssh_key %d not found", id)
+		}
+	}
+	for _, id := range req.Volumes {
+		volume, ok := s.volumes[id]
+		if !ok {
+			return nil, errInvalidInput("volume %d not found", id)
+		}
+		if volume.Server != nil {
+			return nil, errConflict("volume_already_attached", fmt.Sprintf("volume %d is already attached", id))
+		}
+		if volume.Location.ID != location.ID {
+			return nil, errInvalidInput("volume %d is not in the server location", id)
+		}
+	}
+	for _, id := range req.Networks {
+		if _, ok := s.networks[id]; !ok {
+			return nil, errInvalidInput("network %d not found", id)
+		}
+	}
+	for _, fw := range req.Firewalls {
+		if _, ok := s.firewalls[fw.Firewall]; !ok {
+			return nil, errInvalidInput("firewall %d not found", fw.Firewall)
+		}
+	}
+	if req.PlacementGroup != 0 {
+		if _, ok := s.placementGroups[req.PlacementGroup]; !ok {
+			return nil, errInvalidInput("placement_group %d not found", req.PlacementGroup)
+		}
+	}
+
+	publicNet := schema.ServerCreatePublicNet{EnableIPv4: true, EnableIPv6: true}
+	if req.PublicNet != nil {
+		publicNet = *req.PublicNet
+	}
+	for _, id := range []int64{publicNet.IPv4ID, publicNet.IPv6ID} {
+		if id == 0 {
+			continue
+		}
+		primaryIP, ok := s.primaryIPs[id]
+		if !ok {
+			return nil, errInvalidInput("primary_ip %d not found", id)
+		}
+		if primaryIP.AssigneeID != nil {
+			return nil, errConflict("primary_ip_assigned", fmt.Sprintf("primary_ip %d is already assigned", id))
+		}
+	}
+	if !publicNet.EnableIPv4 && !publicNet.EnableIPv6 && len(req.Networks) == 0 {
+		return nil, errInvalidInput("server must have a public IP or be attached to a network")
+	}
+
+	status := "running"
+	if req.StartAfterCreate != nil && !*req.StartAfterCreate {
+		status = "off"
+	}
+
+	server := &schema.Server{
+		ID:              s.nextID(),
+		Name:            req.Name,
+		Status:          status,
+		Created:         now(),
+		PrivateNet:      []schema.ServerPrivateNet{},
+		ServerType:      *serverType,
+		IncludedTraffic: uint64(serverType.IncludedTraffic),
+		OutgoingTraffic: ptr(uint64(0)),
+		IngoingTraffic:  ptr(uint64(0)),
+		Location:        *location,
+		Datacenter:      s.datacenterIn(*location),
+		Image:           ptr(*image),
+		Labels:          copyLabels(req.Labels),
+		PrimaryDiskSize: serverType.Disk,
+	}
+	s.servers[server.ID] = server
+	if req.PlacementGroup != 0 {
+		placementGroup := s.placementGroups[req.PlacementGroup]
+		placementGroup.Servers = append(placementGroup.Servers, server.ID)
+	}
+
+	if publicNet.EnableIPv4 {
+		err = s.assignPublicIP(server, "ipv4", publicNet.IPv4ID)
+	}
+	if err == nil && publicNet.EnableIPv6 {
+		err = s.assignPublicIP(server, "ipv6", publicNet.IPv6ID)
+	}
+	if err != nil {
+		s.deleteServer(server)
+		return nil, err
+	}
+
+	ref := resourceRef("server", server.ID)
+	var nextActions []schema.Action
+
+	for _, id := range req.Networks {
+		ip, err := s.allocatePrivateIP(id, "", "")
+		if err != nil {
+			s.deleteServer(server)
+			return nil, err
+		}
+		server.PrivateNet = append(server.PrivateNet, schema.ServerPrivateNet{
+			Network: id, IP: ip, AliasIPs: []string{}, MACAddress: macAddress(server.ID),
+		})
+		nextActions = append(nextActions, s.newAction("attach_to_network", ref, resourceRef("network", id)))
+	}
+	for _, id := range req.Volumes {
+		s.volumes[id].Server = ptr(server.ID)
+		nextActions = append(nextActions, s.newAction("attach_volume", ref, resourceRef("volume", id)))
+	}
+	for _, fw := range req.Firewalls {
+		firewall := s.firewalls[fw.Firewall]
+		firewall.AppliedTo = append(firewall.AppliedTo, schema.FirewallResource{
+			Type: "server", Server: &schema.FirewallResourceServer{ID: server.ID},
+		})
+		nextActions = append(nextActions, s.newAction("apply_firewall", ref, resourceRef("firewall", fw.Firewall)))
+	}
+	if status == "running" {
+		nextActions = append(nextActions, s.newAction("start_server", ref))
+	}
+
+	resp := schema.ServerCreateResponse{
+		Server:      s.serverView(server),
+		Action:      s.newAction("create_server", ref),
+		NextActions: nextActions,
+	}
+	if resp.NextActions == nil {
+		resp.NextActions = []schema.Action{}
+	}
+	if len(req.SSHKeys) == 0 {
+		resp.RootPassword = ptr(randomPassword(server.ID))
+	}
+	return resp, nil
+}
+
+// assignPublicIP assigns the Primary IP with the given ID to the server, or a
+// new auto deleted Primary IP if id is 0.
+func (s *Server) assignPublicIP(server *schema.Server, typ string, id int64) error {
+	if id != 0 {
+		return s.assignPrimaryIP(s.primaryIPs[id], server)
+	}
+	primaryIP := s.newPrimaryIP("", typ, server.Location)
+	primaryIP.AutoDelete = true
+	primaryIP.AssigneeID = ptr(server.ID)
+	return nil
+}
+
+// deleteServer removes the server and releases the resources attached to it.
+func (s *Server) deleteServer(server *schema.Server) {
+	delete(s.servers, server.ID)
+
+	for _, primaryIP := range s.primaryIPs {
+		if primaryIP.AssigneeID == nil || *primaryIP.AssigneeID != server.ID {
+			continue
+		}
+		if primaryIP.AutoDelete {
+			delete(s.primaryIPs, primaryIP.ID)
+		} else {
+			primaryIP.AssigneeID = nil
+		}
+	}
+	for _, floatingIP := range s.floatingIPs {
+		if floatingIP.Server != nil && *floatingIP.Server == server.ID {
+			floatingIP.Server = nil
+		}
+	}
+	s.removeFromPlacementGroup(server.ID)
+	for _, volume := range s.volumes {
+		if volume.Server != nil && *volume.Server == server.ID {
+			volume.Server = nil
+		}
+	}
+	for _, firewall := range s.firewalls {
+		firewall.AppliedTo = slices.DeleteFunc(firewall.AppliedTo, func(res schema.FirewallResource) bool {
+			return res.Type == "server" && res.Server != nil && res.Server.ID == server.ID
+		})
+	}
+	for _, lb := range s.loadBalancers {
+		lb.Targets = slices.DeleteFunc(lb.Targets, func(target schema.LoadBalancerTarget) bool {
+			return target.Type == "server" && target.Server != nil && target.Server.ID == server.ID
+		})
+	}
+	for _, image := range s.images {
+		if image.BoundTo != nil && *image.BoundTo == server.ID {
+			delete(s.images, image.ID)
+		}
+	}
+}
+
+func (s *Server) serverAction(r *http.Request, server *schema.Server) (any, error) {
+	ref := resourceRef("server", server.ID)
+	command := r.PathValue("action")
+	actionResponse := func(command string, refs ...schema.ActionResourceReference) any {
+		return schema.ActionGetResponse{Action: s.newAction(command, append([]schema.ActionResourceReference{ref}, refs...)...)}
+	}
+
+	switch command {
+	case "poweron":
+		server.Status = "running"
+		return actionResponse("start_server"), nil
+
+	case "poweroff":
+		server.Status = "off"
+		return actionResponse("stop_server"), nil
+
+	case "shutdown":
+		if !s.ignoreShutdown {
+			server.Status = "off"
+		}
+		return actionResponse("shutdown_server"), nil
+
+	case "reboot":
+		server.Status = "running"
+		return actionResponse("reboot_server"), nil
+
+	case "reset":
+		server.Status = "running"
+		return actionResponse("reset_server"), nil
+
+	case "reset_password":
+		return schema.ServerActionResetPasswordResponse{
+			Action:       s.newAction("reset_password", ref),
+			RootPassword: randomPassword(s.lastID),
+		}, nil
+
+	case "request_console":
+		return schema.ServerActionRequestConsoleResponse{
+			Action:   s.newAction("request_console", ref),
+			WSSURL:   "wss://console.hetzner.cloud/?server_id=" + strconv.FormatInt(server.ID, 10),
+			Password: randomPassword(s.lastID),
+		}, nil
+
+	case "rebuild":
+		req, err := decode[schema.ServerActionRebuildRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if server.Protection.Rebuild {
+			return nil, errProtected("server")
+		}
+		image, ok := s.findImage(req.Image, server.ServerType.Architecture)
+		if !ok {
+			return nil, errInvalidInput("image %v not found", req.Image)
+		}
+		server.Image = ptr(*image)
+		server.Status = "running"
+		return schema.ServerActionRebuildResponse{
+			Action:       s.newAction("rebuild_server", ref),
+			RootPassword: ptr(randomPassword(s.lastID)),
+		}, nil
+
+	case "change_type":
+		req, err := decode[schema.ServerActionChangeTypeRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if server.Status != "off" {
+			return nil, errConflict("server_not_stopped", "the server must be stopped")
+		}
+		serverType, ok := findByIDOrName(s.serverTypes, req.ServerType, serverTypeFields)
+		if !ok {
+			return nil, errInvalidInput("server_type %v not found", req.ServerType)
+		}
+		if serverType.Architecture != server.ServerType.Architecture {
+			return nil, errInvalidInput("server type architecture does not match the server architecture")
+		}
+		if serverType.Disk < server.PrimaryDiskSize {
+			return nil, errInvalidInput("server type disk is smaller than the current disk")
+		}
+		server.ServerType = *serverType
+		server.IncludedTraffic = uint64(serverType.IncludedTraffic)
+		if req.UpgradeDisk {
+			server.PrimaryDiskSize = serverType.Disk
+		}
+		return actionResponse("change_server_type"), nil
+
+	case "enable_backup":
+		server.BackupWindow = ptr("22-02")
+		return actionResponse("enable_backup"), nil
+
+	case "disable_backup":
+		server.BackupWindow = nil
+		for _, image := range s.images {
+			if image.Type == "backup" && image.BoundTo != nil && *image.BoundTo == server.ID {
+				delete(s.images, image.ID)
+			}
+		}
+		return actionResponse("disable_backup"), nil
+
+	case "enable_rescue":
+		req, err := decode[schema.ServerActionEnableRescueRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range req.SSHKeys {
+			if _, ok := s.sshKeys[id]; !ok {
+				return nil, errInvalidInput("ssh_key %d not found", id)
+			}
+		}
+		server.RescueEnabled = true
+		return schema.ServerActionEnableRescueResponse{
+			Action:       s.newAction("enable_rescue", ref),
+			RootPassword: randomPassword(s.lastID),
+		}, nil
+
+	case "disable_rescue":
+		server.RescueEnabled = false
+		return actionResponse("disable_rescue"), nil
+
+	case "attach_iso":
+		req, err := decode[schema.ServerActionAttachISORequest](r)
+		if err != nil {
+			return nil, err
+		}
+		iso, ok := findByIDOrName(s.isos, req.ISO, func(iso *schema.ISO) (int64, string) { return iso.ID, iso.Name })
+		if !ok {
+			return nil, errInvalidInput("iso %v not found", req.ISO)
+		}
+		server.ISO = ptr(*iso)
+		return actionResponse("attach_iso"), nil
+
+	case "detach_iso":
+		server.ISO = nil
+		return actionResponse("detach_iso"), nil
+
+	case "change_protection":
+		req, err := decode[schema.ServerActionChangeProtectionRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Delete != nil {
+			server.Protection.Delete = *req.Delete
+		}
+		if req.Rebuild != nil {
+			server.Protection.Rebuild = *req.Rebuild
+		}
+		if server.Protection.Delete != server.Protection.Rebuild {
+			return nil, errInvalidInput("delete and rebuild protection must have the same value")
+		}
+		return actionResponse("change_protection"), nil
+
+	case "change_dns_ptr":
+		req, err := decode[schema.ServerActionChangeDNSPtrRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		for _, typ := range []string{"ipv4", "ipv6"} {
+			primaryIP := s.assignedPrimaryIP(server.ID, typ)
+			if primaryIP != nil && primaryIPContains(primaryIP, req.IP) {
+				if err := setDNSPtr(primaryIP, req.IP, req.DNSPtr); err != nil {
+					return nil, err
+				}
+				return actionResponse("change_dns_ptr"), nil
+			}
+		}
+		return nil, errInvalidInput("ip %s does not belong to the server", req.IP)
+
+	case "create_image":
+		req, err := decode[schema.ServerActionCreateImageRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		image := s.newImageFromServer(server, req)
+		return schema.ServerActionCreateImageResponse{
+			Action: s.newAction("create_image", ref, resourceRef("image", image.ID)),
+			Image:  *image,
+		}, nil
+
+	case "attach_to_network":
+		req, err := decode[schema.ServerActionAttachToNetworkRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.networks[req.Network]; !ok {
+			return nil, errNotFound("network")
+		}
+		for _, privateNet := range server.PrivateNet {
+			if privateNet.Network == req.Network {
+				return nil, errConflict("server_already_attached", "server is already attached to the network")
+			}
+		}
+		var ip, ipRange string
+		if req.IP != nil {
+			ip = *req.IP
+		}
+		if req.IPRange != nil {
+			ipRange = *req.IPRange
+		}
+		ip, err = s.allocatePrivateIP(req.Network, ip, ipRange)
+		if err != nil {
+			return nil, err
+		}
+		privateNet := schema.ServerPrivateNet{Network: req.Network, IP: ip, AliasIPs: []string{}, MACAddress: macAddress(server.ID)}
+		server.PrivateNet = append(server.PrivateNet, privateNet)
+		for _, aliasIP := range req.AliasIPs {
+			if aliasIP == nil {
+				continue
+			}
+			if err := s.addAliasIP(server, req.Network, *aliasIP); err != nil {
+				return nil, err
+			}
+		}
+		return actionResponse("attach_to_network", resourceRef("network", req.Network)), nil
+
+	case "detach_from_network":
+		req, err := decode[schema.ServerActionDetachFromNetworkRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		idx := slices.IndexFunc(server.PrivateNet, func(privateNet schema.ServerPrivateNet) bool {
+			return privateNet.Network == req.Network
+		})
+		if idx < 0 {
+			return nil, errConflict("server_not_attached", "server is not attached to the network")
+		}
+		server.PrivateNet = slices.Delete(server.PrivateNet, idx, idx+1)
+		return actionResponse("detach_from_network", resourceRef("network", req.Network)), nil
+
+	case "change_alias_ips":
+		req, err := decode[schema.ServerActionChangeAliasIPsRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		idx := slices.IndexFunc(server.PrivateNet, func(privateNet schema.ServerPrivateNet) bool {
+			return privateNet.Network == req.Network
+		})
+		if idx < 0 {
+			return nil, errConflict("server_not_attached", "server is not attached to the network")
+		}
+		previous := server.PrivateNet[idx].AliasIPs
+		server.PrivateNet[idx].AliasIPs = []string{}
+		for _, aliasIP := range req.AliasIPs {
+			if err := s.addAliasIP(server, req.Network, aliasIP); err != nil {
+				server.PrivateNet[idx].AliasIPs = previous
+				return nil, err
+			}
+		}
+		return actionResponse("change_alias_ips", resourceRef("network", req.Network)), nil
+
+	case "add_to_placement_group":
+		req, err := decode[schema.ServerActionAddToPlacementGroupRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		placementGroup, ok := s.placementGroups[req.PlacementGroup]
+		if !ok {
+			return nil, errInvalidInput("placement_group %d not found", req.PlacementGroup)
+		}
+		if server.Status != "off" {
+			return nil, errConflict("server_not_stopped", "the server must be stopped")
+		}
+		if s.serverPlacementGroup(server.ID) != nil {
+			return nil, errConflict("server_already_in_placement_group", "server is already in a placement group")
+		}
+		placementGroup.Servers = append(placementGroup.Servers, server.ID)
+		return actionResponse("add_to_placement_group", resourceRef("placement_group", placementGroup.ID)), nil
+
+	case "remove_from_placement_group":
+		placementGroup := s.serverPlacementGroup(server.ID)
+		if placementGroup == nil {
+			return nil, errInvalidInput("server is not in a placement group")
+		}
+		if server.Status != "off" {
+			return nil, errConflict("server_not_stopped", "the server must be stopped")
+		}
+		s.removeFromPlacementGroup(server.ID)
+		return actionResponse("remove_from_placement_group", resourceRef("placement_group", placementGroup.ID)), nil
+
+	default:
+		return nil, errNotFound("action")
+	}
+}
+
+// addAliasIP adds an alias IP to the server in the network.
+func (s *Server) addAliasIP(server *schema.Server, networkID int64, ip string) error {
+	ip, err := s.allocatePrivateIP(networkID, ip, "")
+	if err != nil {
+		return err
+	}
+	for i := range server.PrivateNet {
+		if server.PrivateNet[i].Network == networkID {
+			server.PrivateNet[i].AliasIPs = append(server.PrivateNet[i].AliasIPs, ip)
+		}
+	}
+	return nil
+}
+
+// newImageFromServer creates a snapshot or backup image of the server.
+func (s *Server) newImageFromServer(server *schema.Server, req schema.ServerActionCreateImageRequest) *schema.Image {
+	image := &schema.Image{
+		ID:           s.nextID(),
+		Status:       "available",
+		Type:         "snapshot",
+		ImageSize:    ptr(float32(1)),
+		DiskSize:     float32(server.PrimaryDiskSize),
+		Created:      ptr(now()),
+		CreatedFrom:  &schema.ImageCreatedFrom{ID: server.ID, Name: server.Name},
+		OSFlavor:     "unknown",
+		Architecture: server.ServerType.Architecture,
+		Labels:       copyLabels(req.Labels),
+	}
+	if req.Type != nil {
+		image.Type = *req.Type
+	}
+	if req.Description != nil {
+		image.Description = *req.Description
+	}
+	if image.Type == "backup" {
+		image.BoundTo = ptr(server.ID)
+	}
+	if server.Image != nil {
+		image.OSFlavor = server.Image.OSFlavor
+		image.OSVersion = server.Image.OSVersion
+	}
+	s.images[image.ID] = image
+	return image
+}
+
+// randomPassword returns a password that is unique for the seed. The fake does
+// not need unpredictable passwords.
+func randomPassword(seed int64) string {
+	return fmt.Sprintf("fake-password-%d", seed)
+}
@@ -0,0 +1,117 @@
+package fakeapi
+
+import (
+	"crypto/md5" // nolint: gosec
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+func sshKeyFields(k *schema.SSHKey) (int64, string) { return k.ID, k.Name }
+
+func (s *Server) registerSSHKeys() {
+	s.handle("GET /ssh_keys", func(r *http.Request) (any, error) {
+		return list(r, "ssh_keys", s.sshKeys, listFields[schema.SSHKey]{
+			id:     func(k *schema.SSHKey) int64 { return k.ID },
+			name:   func(k *schema.SSHKey) string { return k.Name },
+			labels: func(k *schema.SSHKey) map[string]string { return k.Labels },
+			filters: map[string]func(*schema.SSHKey) string{
+				"fingerprint": func(k *schema.SSHKey) string { return k.Fingerprint },
+			},
+		}, nil)
+	})
+
+	s.handle("GET /ssh_keys/{id}", func(r *http.Request) (any, error) {
+		key, err := lookup(r, s.sshKeys, "ssh_key")
+		if err != nil {
+			return nil, err
+		}
+		return schema.SSHKeyGetResponse{SSHKey: *key}, nil
+	})
+
+	s.handle("POST /ssh_keys", func(r *http.Request) (any, error) {
+		req, err := decode[schema.SSHKeyCreateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name == "" {
+			return nil, errInvalidInput("name is required")
+		}
+		if nameTaken(s.sshKeys, 0, req.Name, sshKeyFields) {
+			return nil, errUniqueness("name")
+		}
+		fingerprint, err := sshFingerprint(req.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range s.sshKeys {
+			if key.Fingerprint == fingerprint {
+				return nil, errUniqueness("public_key")
+			}
+		}
+
+		key := &schema.SSHKey{
+			ID:          s.nextID(),
+			Name:        req.Name,
+			Fingerprint: fingerprint,
+			PublicKey:   strings.TrimSpace(req.PublicKey),
+			Labels:      copyLabels(req.Labels),
+			Created:     now(),
+		}
+		s.sshKeys[key.ID] = key
+		return schema.SSHKeyCreateResponse{SSHKey: *key}, nil
+	})
+
+	s.handle("PUT /ssh_keys/{id}", func(r *http.Request) (any, error) {
+		key, err := lookup(r, s.sshKeys, "ssh_key")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.SSHKeyUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name != "" {
+			if nameTaken(s.sshKeys, key.ID, req.Name, sshKeyFields) {
+				return nil, errUniqueness("name")
+			}
+			key.Name = req.Name
+		}
+		if req.Labels != nil {
+			key.Labels = copyLabels(req.Labels)
+		}
+		return schema.SSHKeyUpdateResponse{SSHKey: *key}, nil
+	})
+
+	s.handle("DELETE /ssh_keys/{id}", func(r *http.Request) (any, error) {
+		key, err := lookup(r, s.sshKeys, "ssh_key")
+		if err != nil {
+			return nil, err
+		}
+		delete(s.sshKeys, key.ID)
+		return nil, nil
+	})
+}
+
+// sshFingerprint returns the MD5 fingerprint of an authorized_keys formatted
+// public key.
+func sshFingerprint(publicKey string) (string, error) {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return "", errInvalidInput("invalid public_key")
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", errInvalidInput("invalid public_key: %v", err)
+	}
+
+	sum := md5.Sum(blob) // nolint: gosec
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(parts, ":"), nil
+}
@@ -0,0 +1,574 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+func storageBoxFields(sb *schema.StorageBox) (int64, string) { return sb.ID, sb.Name }
+
+func storageBoxSnapshotFields(sn *schema.StorageBoxSnapshot) (int64, string) { return sn.ID, sn.Name }
+
+func storageBoxView(storageBox *schema.StorageBox) schema.StorageBox {
+	view := *storageBox
+	if storageBox.SnapshotPlan != nil {
+		plan := *storageBox.SnapshotPlan
+		view.SnapshotPlan = &plan
+	}
+	return view
+}
+
+// storageBoxItems returns the items belonging to the Storage Box with the ID
+// in the path value "id".
+func storageBoxItems[T any](items map[int64]*T, storageBoxID int64, owner func(*T) int64) map[int64]*T {
+	result := make(map[int64]*T)
+	for id, item := range items {
+		if owner(item) == storageBoxID {
+			result[id] = item
+		}
+	}
+	return result
+}
+
+// lookupChild returns the item with the ID in the path value name, which must
+// belong to the Storage Box.
+func lookupChild[T any](r *http.Request, items map[int64]*T, storageBoxID int64, name string, owner func(*T) int64) (*T, error) {
+	id, err := pathID(r, name)
+	if err != nil {
+		return nil, err
+	}
+	item, ok := items[id]
+	if !ok || owner(item) != storageBoxID {
+		return nil, errNotFound(name)
+	}
+	return item, nil
+}
+
+func snapshotOwner(sn *schema.StorageBoxSnapshot) int64 { return sn.StorageBox }
+
+func subaccountOwner(sa *schema.StorageBoxSubaccount) int64 { return sa.StorageBox }
+
+func (s *Server) registerStorageBoxes() {
+	s.handleHetzner("GET /storage_boxes", func(r *http.Request) (any, error) {
+		return list(r, "storage_boxes", s.storageBoxes, listFields[schema.StorageBox]{
+			id:     func(sb *schema.StorageBox) int64 { return sb.ID },
+			name:   func(sb *schema.StorageBox) string { return sb.Name },
+			labels: func(sb *schema.StorageBox) map[string]string { return sb.Labels },
+		}, storageBoxView)
+	})
+
+	s.handleHetzner("GET /storage_boxes/{id}", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		return schema.StorageBoxGetResponse{StorageBox: storageBoxView(storageBox)}, nil
+	})
+
+	s.handleHetzner("POST /storage_boxes", s.createStorageBox)
+
+	s.handleHetzner("PUT /storage_boxes/{id}", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.StorageBoxUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name != "" {
+			if nameTaken(s.storageBoxes, storageBox.ID, req.Name, storageBoxFields) {
+				return nil, errUniqueness("name")
+			}
+			storageBox.Name = req.Name
+		}
+		if req.Labels != nil {
+			storageBox.Labels = copyLabels(req.Labels)
+		}
+		return schema.StorageBoxUpdateResponse{StorageBox: storageBoxView(storageBox)}, nil
+	})
+
+	s.handleHetzner("DELETE /storage_boxes/{id}", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		if storageBox.Protection.Delete {
+			return nil, errProtected("storage_box")
+		}
+		for id := range storageBoxItems(s.storageBoxSnapshots, storageBox.ID, snapshotOwner) {
+			delete(s.storageBoxSnapshots, id)
+		}
+		for id := range storageBoxItems(s.storageBoxSubaccounts, storageBox.ID, subaccountOwner) {
+			delete(s.storageBoxSubaccounts, id)
+		}
+		delete(s.storageBoxes, storageBox.ID)
+		return schema.ActionGetResponse{Action: s.newAction("delete_storage_box", resourceRef("storage_box", storageBox.ID))}, nil
+	})
+
+	s.handleHetzner("GET /storage_boxes/{id}/folders", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		return schema.StorageBoxFoldersResponse{Folders: s.storageBoxFolders(storageBox.ID, r.URL.Query().Get("path"))}, nil
+	})
+
+	s.handleHetzner("POST /storage_boxes/{id}/actions/{action}", s.storageBoxAction)
+
+	s.registerStorageBoxSnapshots()
+	s.registerStorageBoxSubaccounts()
+}
+
+func (s *Server) createStorageBox(r *http.Request) (any, error) {
+	req, err := decode[schema.StorageBoxCreateRequest](r)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, errInvalidInput("name is required")
+	}
+	if nameTaken(s.storageBoxes, 0, req.Name, storageBoxFields) {
+		return nil, errUniqueness("name")
+	}
+	if req.Password == "" {
+		return nil, errInvalidInput("password is required")
+	}
+	storageBoxType, ok := findByIDOrName(s.storageBoxTypes, req.StorageBoxType, storageBoxTypeFields)
+	if !ok {
+		return nil, errInvalidInput("storage_box_type not found")
+	}
+	location, ok := s.findLocation(locationIDOrName(req.Location))
+	if !ok {
+		return nil, errInvalidInput("location not found")
+	}
+
+	id := s.nextID()
+	username := fmt.Sprintf("u%d", id)
+	storageBox := &schema.StorageBox{
+		ID:             id,
+		Username:       ptr(username),
+		Status:         "active",
+		Name:           req.Name,
+		StorageBoxType: *storageBoxType,
+		Location:       *location,
+		Server:         ptr(username + ".your-storagebox.de"),
+		System:         ptr(strings.ToUpper(location.Name) + "-BX"),
+		Labels:         copyLabels(req.Labels),
+		Created:        now(),
+	}
+	if settings := req.AccessSettings; settings != nil {
+		applyStorageBoxAccessSettings(&storageBox.AccessSettings, schema.StorageBoxUpdateAccessSettingsRequest(*settings))
+	}
+	s.storageBoxes[storageBox.ID] = storageBox
+
+	return schema.StorageBoxCreateResponse{
+		StorageBox: storageBoxView(storageBox),
+		Action:     s.newAction("create", resourceRef("storage_box", storageBox.ID)),
+	}, nil
+}
+
+func (s *Server) storageBoxAction(r *http.Request) (any, error) {
+	storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+	if err != nil {
+		return nil, err
+	}
+	ref := resourceRef("storage_box", storageBox.ID)
+
+	switch command := r.PathValue("action"); command {
+	case "change_protection":
+		req, err := decode[schema.StorageBoxChangeProtectionRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Delete != nil {
+			storageBox.Protection.Delete = *req.Delete
+		}
+		return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+
+	case "change_type":
+		req, err := decode[schema.StorageBoxChangeTypeRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		storageBoxType, ok := findByIDOrName(s.storageBoxTypes, req.StorageBoxType, storageBoxTypeFields)
+		if !ok {
+			return nil, errInvalidInput("storage_box_type not found")
+		}
+		storageBox.StorageBoxType = *storageBoxType
+		return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+
+	case "reset_password":
+		req, err := decode[schema.StorageBoxResetPasswordRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Password == "" {
+			return nil, errInvalidInput("password is required")
+		}
+		return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+
+	case "update_access_settings":
+		req, err := decode[schema.StorageBoxUpdateAccessSettingsRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		applyStorageBoxAccessSettings(&storageBox.AccessSettings, req)
+		return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+
+	case "rollback_snapshot":
+		req, err := decode[schema.StorageBoxRollbackSnapshotRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		snapshots := storageBoxItems(s.storageBoxSnapshots, storageBox.ID, snapshotOwner)
+		if _, ok := findByIDOrName(snapshots, req.Snapshot, storageBoxSnapshotFields); !ok {
+			return nil, errNotFound("snapshot")
+		}
+		return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+
+	case "enable_snapshot_plan":
+		req, err := decode[schema.StorageBoxEnableSnapshotPlanRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.MaxSnapshots < 1 || req.Minute < 0 || req.Minute > 59 || req.Hour < 0 || req.Hour > 23 {
+			return nil, errInvalidInput("invalid snapshot plan")
+		}
+		plan := schema.StorageBoxSnapshotPlan(req)
+		storageBox.SnapshotPlan = &plan
+		return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+
+	case "disable_snapshot_plan":
+		storageBox.SnapshotPlan = nil
+		return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+
+	default:
+		return nil, errNotFound("action")
+	}
+}
+
+func applyStorageBoxAccessSettings(settings *schema.StorageBoxAccessSettings, req schema.StorageBoxUpdateAccessSettingsRequest) {
+	if req.ReachableExternally != nil {
+		settings.ReachableExternally = *req.ReachableExternally
+	}
+	if req.SambaEnabled != nil {
+		settings.SambaEnabled = *req.SambaEnabled
+	}
+	if req.SSHEnabled != nil {
+		settings.SSHEnabled = *req.SSHEnabled
+	}
+	if req.WebDAVEnabled != nil {
+		settings.WebDAVEnabled = *req.WebDAVEnabled
+	}
+	if req.ZFSEnabled != nil {
+		settings.ZFSEnabled = *req.ZFSEnabled
+	}
+}
+
+// storageBoxFolders returns the direct sub folders of dir, derived from the
+// home directories of the subaccounts.
+func (s *Server) storageBoxFolders(storageBoxID int64, dir string) []string {
+	dir = strings.Trim(path.Clean("/"+dir), "/")
+	folders := []string{}
+	for _, subaccount := range storageBoxItems(s.storageBoxSubaccounts, storageBoxID, subaccountOwner) {
+		home := strings.Trim(path.Clean("/"+subaccount.HomeDirectory), "/")
+		rest := home
+		if dir != "" {
+			var ok bool
+			if rest, ok = strings.CutPrefix(home, dir+"/"); !ok {
+				continue
+			}
+		}
+		folder, _, _ := strings.Cut(rest, "/")
+		if folder != "" && !slices.Contains(folders, folder) {
+			folders = append(folders, folder)
+		}
+	}
+	slices.Sort(folders)
+	return folders
+}
+
+func (s *Server) registerStorageBoxSnapshots() {
+	s.handleHetzner("GET /storage_boxes/{id}/snapshots", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		return list(r, "snapshots", storageBoxItems(s.storageBoxSnapshots, storageBox.ID, snapshotOwner), listFields[schema.StorageBoxSnapshot]{
+			id:     func(sn *schema.StorageBoxSnapshot) int64 { return sn.ID },
+			name:   func(sn *schema.StorageBoxSnapshot) string { return sn.Name },
+			labels: func(sn *schema.StorageBoxSnapshot) map[string]string { return sn.Labels },
+			filters: map[string]func(*schema.StorageBoxSnapshot) string{
+				"is_automatic": func(sn *schema.StorageBoxSnapshot) string { return fmt.Sprint(sn.IsAutomatic) },
+			},
+		}, nil)
+	})
+
+	s.handleHetzner("GET /storage_boxes/{id}/snapshots/{snapshot}", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		snapshot, err := lookupChild(r, s.storageBoxSnapshots, storageBox.ID, "snapshot", snapshotOwner)
+		if err != nil {
+			return nil, err
+		}
+		return schema.StorageBoxSnapshotGetResponse{Snapshot: *snapshot}, nil
+	})
+
+	s.handleHetzner("POST /storage_boxes/{id}/snapshots", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.StorageBoxSnapshotCreateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot := &schema.StorageBoxSnapshot{
+			ID:          s.nextID(),
+			Description: req.Description,
+			Labels:      copyLabels(&req.Labels),
+			Created:     now(),
+			StorageBox:  storageBox.ID,
+		}
+		// Snapshots are named after their creation time, which is not
+		// unique for snapshots created within the same second.
+		snapshots := storageBoxItems(s.storageBoxSnapshots, storageBox.ID, snapshotOwner)
+		for created := snapshot.Created; ; created = created.Add(time.Second) {
+			snapshot.Name = created.Format("2006-01-02T15-04-05")
+			if !nameTaken(snapshots, 0, snapshot.Name, storageBoxSnapshotFields) {
+				break
+			}
+		}
+		s.storageBoxSnapshots[snapshot.ID] = snapshot
+
+		return schema.StorageBoxSnapshotCreateResponse{
+			Snapshot: *snapshot,
+			Action:   s.newAction("create_snapshot", resourceRef("storage_box", storageBox.ID)),
+		}, nil
+	})
+
+	s.handleHetzner("PUT /storage_boxes/{id}/snapshots/{snapshot}", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		snapshot, err := lookupChild(r, s.storageBoxSnapshots, storageBox.ID, "snapshot", snapshotOwner)
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.StorageBoxSnapshotUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Description != nil {
+			snapshot.Description = *req.Description
+		}
+		if req.Labels != nil {
+			snapshot.Labels = copyLabels(req.Labels)
+		}
+		return schema.StorageBoxSnapshotUpdateResponse{Snapshot: *snapshot}, nil
+	})
+
+	s.handleHetzner("DELETE /storage_boxes/{id}/snapshots/{snapshot}", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		snapshot, err := lookupChild(r, s.storageBoxSnapshots, storageBox.ID, "snapshot", snapshotOwner)
+		if err != nil {
+			return nil, err
+		}
+		delete(s.storageBoxSnapshots, snapshot.ID)
+		return schema.ActionGetResponse{Action: s.newAction("delete_snapshot", resourceRef("storage_box", storageBox.ID))}, nil
+	})
+}
+
+func (s *Server) registerStorageBoxSubaccounts() {
+	s.handleHetzner("GET /storage_boxes/{id}/subaccounts", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		return list(r, "subaccounts", storageBoxItems(s.storageBoxSubaccounts, storageBox.ID, subaccountOwner), listFields[schema.StorageBoxSubaccount]{
+			id:     func(sa *schema.StorageBoxSubaccount) int64 { return sa.ID },
+			name:   func(sa *schema.StorageBoxSubaccount) string { return sa.Name },
+			labels: func(sa *schema.StorageBoxSubaccount) map[string]string { return sa.Labels },
+			filters: map[string]func(*schema.StorageBoxSubaccount) string{
+				"username": func(sa *schema.StorageBoxSubaccount) string { return sa.Username },
+			},
+		}, nil)
+	})
+
+	s.handleHetzner("GET /storage_boxes/{id}/subaccounts/{subaccount}", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		subaccount, err := lookupChild(r, s.storageBoxSubaccounts, storageBox.ID, "subaccount", subaccountOwner)
+		if err != nil {
+			return nil, err
+		}
+		return schema.StorageBoxSubaccountGetResponse{Subaccount: *subaccount}, nil
+	})
+
+	s.handleHetzner("POST /storage_boxes/{id}/subaccounts", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.StorageBoxSubaccountCreateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Password == "" {
+			return nil, errInvalidInput("password is required")
+		}
+		if req.HomeDirectory == "" {
+			return nil, errInvalidInput("home_directory is required")
+		}
+		subaccounts := storageBoxItems(s.storageBoxSubaccounts, storageBox.ID, subaccountOwner)
+		if req.Name != "" && nameTaken(subaccounts, 0, req.Name, func(sa *schema.StorageBoxSubaccount) (int64, string) {
+			return sa.ID, sa.Name
+		}) {
+			return nil, errUniqueness("name")
+		}
+
+		id := s.nextID()
+		username := fmt.Sprintf("%s-sub%d", *storageBox.Username, len(subaccounts)+1)
+		subaccount := &schema.StorageBoxSubaccount{
+			ID:            id,
+			Name:          req.Name,
+			Username:      username,
+			HomeDirectory: req.HomeDirectory,
+			Server:        username + ".your-storagebox.de",
+			Description:   req.Description,
+			Labels:        copyLabels(&req.Labels),
+			Created:       now(),
+			StorageBox:    storageBox.ID,
+		}
+		if subaccount.Name == "" {
+			subaccount.Name = username
+		}
+		if settings := req.AccessSettings; settings != nil {
+			applySubaccountAccessSettings(&subaccount.AccessSettings, schema.StorageBoxSubaccountUpdateAccessSettingsRequest(*settings))
+		}
+		s.storageBoxSubaccounts[subaccount.ID] = subaccount
+
+		return schema.StorageBoxSubaccountCreateResponse{
+			Subaccount: schema.StorageBoxSubaccountCreateResponseSubaccount{ID: subaccount.ID, StorageBox: storageBox.ID},
+			Action:     s.newAction("create_subaccount", resourceRef("storage_box", storageBox.ID)),
+		}, nil
+	})
+
+	s.handleHetzner("PUT /storage_boxes/{id}/subaccounts/{subaccount}", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		subaccount, err := lookupChild(r, s.storageBoxSubaccounts, storageBox.ID, "subaccount", subaccountOwner)
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.StorageBoxSubaccountUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name != "" {
+			subaccount.Name = req.Name
+		}
+		if req.Description != nil {
+			subaccount.Description = *req.Description
+		}
+		if req.Labels != nil {
+			subaccount.Labels = copyLabels(req.Labels)
+		}
+		return schema.StorageBoxSubaccountUpdateResponse{Subaccount: *subaccount}, nil
+	})
+
+	s.handleHetzner("DELETE /storage_boxes/{id}/subaccounts/{subaccount}", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		subaccount, err := lookupChild(r, s.storageBoxSubaccounts, storageBox.ID, "subaccount", subaccountOwner)
+		if err != nil {
+			return nil, err
+		}
+		delete(s.storageBoxSubaccounts, subaccount.ID)
+		return schema.ActionGetResponse{Action: s.newAction("delete_subaccount", resourceRef("storage_box", storageBox.ID))}, nil
+	})
+
+	s.handleHetzner("POST /storage_boxes/{id}/subaccounts/{subaccount}/actions/{action}", func(r *http.Request) (any, error) {
+		storageBox, err := lookup(r, s.storageBoxes, "storage_box")
+		if err != nil {
+			return nil, err
+		}
+		subaccount, err := lookupChild(r, s.storageBoxSubaccounts, storageBox.ID, "subaccount", subaccountOwner)
+		if err != nil {
+			return nil, err
+		}
+		ref := resourceRef("storage_box", storageBox.ID)
+
+		switch command := r.PathValue("action"); command {
+		case "reset_subaccount_password":
+			req, err := decode[schema.StorageBoxSubaccountResetPasswordRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if req.Password == "" {
+				return nil, errInvalidInput("password is required")
+			}
+			return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+
+		case "update_access_settings":
+			req, err := decode[schema.StorageBoxSubaccountUpdateAccessSettingsRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			applySubaccountAccessSettings(&subaccount.AccessSettings, req)
+			return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+
+		case "change_home_directory":
+			req, err := decode[schema.StorageBoxSubaccountChangeHomeDirectoryRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if req.HomeDirectory == "" {
+				return nil, errInvalidInput("home_directory is required")
+			}
+			subaccount.HomeDirectory = req.HomeDirectory
+			return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+
+		default:
+			return nil, errNotFound("action")
+		}
+	})
+}
+
+func applySubaccountAccessSettings(settings *schema.StorageBoxSubaccountAccessSettings, req schema.StorageBoxSubaccountUpdateAccessSettingsRequest) {
+	if req.ReachableExternally != nil {
+		settings.ReachableExternally = *req.ReachableExternally
+	}
+	if req.Readonly != nil {
+		settings.Readonly = *req.Readonly
+	}
+	if req.SambaEnabled != nil {
+		settings.SambaEnabled = *req.SambaEnabled
+	}
+	if req.SSHEnabled != nil {
+		settings.SSHEnabled = *req.SSHEnabled
+	}
+	if req.WebDAVEnabled != nil {
+		settings.WebDAVEnabled = *req.WebDAVEnabled
+	}
+}
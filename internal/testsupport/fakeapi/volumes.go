@@ -0,0 +1,207 @@
+package fakeapi
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+const (
+	volumeMinSize = 10
+	volumeMaxSize = 10240
+)
+
+func volumeFields(v *schema.Volume) (int64, string) { return v.ID, v.Name }
+
+func (s *Server) registerVolumes() {
+	s.handle("GET /volumes", func(r *http.Request) (any, error) {
+		return list(r, "volumes", s.volumes, listFields[schema.Volume]{
+			id:     func(v *schema.Volume) int64 { return v.ID },
+			name:   func(v *schema.Volume) string { return v.Name },
+			labels: func(v *schema.Volume) map[string]string { return v.Labels },
+			filters: map[string]func(*schema.Volume) string{
+				"status": func(v *schema.Volume) string { return v.Status },
+			},
+		}, nil)
+	})
+
+	s.handle("GET /volumes/{id}", func(r *http.Request) (any, error) {
+		volume, err := lookup(r, s.volumes, "volume")
+		if err != nil {
+			return nil, err
+		}
+		return schema.VolumeGetResponse{Volume: *volume}, nil
+	})
+
+	s.handle("POST /volumes", func(r *http.Request) (any, error) {
+		req, err := decode[schema.VolumeCreateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name == "" {
+			return nil, errInvalidInput("name is required")
+		}
+		if nameTaken(s.volumes, 0, req.Name, volumeFields) {
+			return nil, errUniqueness("name")
+		}
+		if req.Size < volumeMinSize || req.Size > volumeMaxSize {
+			return nil, errInvalidInput("size must be between %d and %d", volumeMinSize, volumeMaxSize)
+		}
+		if req.Format != nil && *req.Format != "ext4" && *req.Format != "xfs" {
+			return nil, errInvalidInput("format must be one of ext4, xfs")
+		}
+
+		var (
+			server   *schema.Server
+			location *schema.Location
+		)
+		switch {
+		case req.Server != nil:
+			var ok bool
+			if server, ok = s.servers[*req.Server]; !ok {
+				return nil, errInvalidInput("server %d not found", *req.Server)
+			}
+			location = &server.Location
+		case req.Location != nil:
+			var ok bool
+			if location, ok = s.findLocation(locationIDOrName(*req.Location)); !ok {
+				return nil, errInvalidInput("location %v not found", *req.Location)
+			}
+		default:
+			return nil, errInvalidInput("one of server or location is required")
+		}
+
+		volume := &schema.Volume{
+			ID:       s.nextID(),
+			Name:     req.Name,
+			Status:   "available",
+			Location: *location,
+			Size:     req.Size,
+			Format:   req.Format,
+			Labels:   copyLabels(req.Labels),
+			Created:  now(),
+		}
+		volume.LinuxDevice = "/dev/disk/by-id/scsi-0HC_Volume_" + strconv.FormatInt(volume.ID, 10)
+		s.volumes[volume.ID] = volume
+
+		ref := resourceRef("volume", volume.ID)
+		resp := schema.VolumeCreateResponse{
+			Action:      ptr(s.newAction("create_volume", ref)),
+			NextActions: []schema.Action{},
+		}
+		if server != nil {
+			volume.Server = ptr(server.ID)
+			resp.NextActions = append(resp.NextActions, s.newAction("attach_volume", ref, resourceRef("server", server.ID)))
+		}
+		resp.Volume = *volume
+		return resp, nil
+	})
+
+	s.handle("PUT /volumes/{id}", func(r *http.Request) (any, error) {
+		volume, err := lookup(r, s.volumes, "volume")
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.VolumeUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Name != "" {
+			if nameTaken(s.volumes, volume.ID, req.Name, volumeFields) {
+				return nil, errUniqueness("name")
+			}
+			volume.Name = req.Name
+		}
+		if req.Labels != nil {
+			volume.Labels = copyLabels(req.Labels)
+		}
+		return schema.VolumeUpdateResponse{Volume: *volume}, nil
+	})
+
+	s.handle("DELETE /volumes/{id}", func(r *http.Request) (any, error) {
+		volume, err := lookup(r, s.volumes, "volume")
+		if err != nil {
+			return nil, err
+		}
+		if volume.Protection.Delete {
+			return nil, errProtected("volume")
+		}
+		if volume.Server != nil {
+			return nil, errConflict("volume_attached", "volume must be detached before deletion")
+		}
+		delete(s.volumes, volume.ID)
+		return nil, nil
+	})
+
+	s.handle("POST /volumes/{id}/actions/{action}", func(r *http.Request) (any, error) {
+		volume, err := lookup(r, s.volumes, "volume")
+		if err != nil {
+			return nil, err
+		}
+		ref := resourceRef("volume", volume.ID)
+
+		switch command := r.PathValue("action"); command {
+		case "attach":
+			req, err := decode[schema.VolumeActionAttachVolumeRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			server, ok := s.servers[req.Server]
+			if !ok {
+				return nil, errInvalidInput("server %d not found", req.Server)
+			}
+			if volume.Server != nil {
+				return nil, errConflict("volume_already_attached", "volume is already attached")
+			}
+			if server.Location.ID != volume.Location.ID {
+				return nil, errInvalidInput("volume and server must be in the same location")
+			}
+			volume.Server = ptr(server.ID)
+			return schema.VolumeActionAttachVolumeResponse{
+				Action: s.newAction("attach_volume", ref, resourceRef("server", server.ID)),
+			}, nil
+
+		case "detach":
+			if volume.Server == nil {
+				return nil, errConflict("volume_not_attached", "volume is not attached")
+			}
+			serverID := *volume.Server
+			volume.Server = nil
+			return schema.VolumeActionDetachVolumeResponse{
+				Action: s.newAction("detach_volume", ref, resourceRef("server", serverID)),
+			}, nil
+
+		case "resize":
+			req, err := decode[schema.VolumeActionResizeVolumeRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if req.Size < volume.Size || req.Size > volumeMaxSize {
+				return nil, errInvalidInput("size can only be increased up to %d", volumeMaxSize)
+			}
+			volume.Size = req.Size
+			return schema.VolumeActionResizeVolumeResponse{Action: s.newAction("resize_volume", ref)}, nil
+
+		case "change_protection":
+			req, err := decode[schema.VolumeActionChangeProtectionRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if req.Delete != nil {
+				volume.Protection.Delete = *req.Delete
+			}
+			return schema.VolumeActionChangeProtectionResponse{Action: s.newAction(command, ref)}, nil
+
+		default:
+			return nil, errNotFound("action")
+		}
+	})
+}
+
+func locationIDOrName(value schema.IDOrName) string {
+	if value.ID != 0 {
+		return strconv.FormatInt(value.ID, 10)
+	}
+	return value.Name
+}
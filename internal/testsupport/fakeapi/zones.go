@@ -0,0 +1,339 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+const defaultZoneTTL = 3600
+
+var (
+	zoneNameservers = []string{"hydrogen.ns.hetzner.com.", "oxygen.ns.hetzner.com.", "helium.ns.hetzner.de."}
+	rrsetTypes      = []string{"A", "AAAA", "CAA", "CNAME", "DS", "HINFO", "HTTPS", "MX", "NS", "PTR", "RP", "SOA", "SRV", "SVCB", "TLSA", "TXT"}
+)
+
+func zoneFields(z *schema.Zone) (int64, string) { return z.ID, z.Name }
+
+// zoneView returns the zone with its record count filled in.
+func (s *Server) zoneView(zone *schema.Zone) schema.Zone {
+	view := *zone
+	view.PrimaryNameservers = slices.Clone(zone.PrimaryNameservers)
+	view.RecordCount = 0
+	for _, rrset := range s.zoneRRSets(zone.ID) {
+		view.RecordCount += len(rrset.Records)
+	}
+	return view
+}
+
+// findZone returns the zone with the ID or name in the path value "zone".
+func (s *Server) findZone(r *http.Request) (*schema.Zone, error) {
+	idOrName := r.PathValue("zone")
+	for _, zone := range s.zones {
+		if zone.Name == idOrName || strconv.FormatInt(zone.ID, 10) == idOrName {
+			return zone, nil
+		}
+	}
+	return nil, errNotFound("zone")
+}
+
+// zoneRRSets returns the RRSets of the zone ordered by creation.
+func (s *Server) zoneRRSets(zoneID int64) []*schema.ZoneRRSet {
+	var result []*schema.ZoneRRSet
+	for _, rrset := range sortedByID(s.rrsets) {
+		if rrset.Zone == zoneID {
+			result = append(result, rrset)
+		}
+	}
+	return result
+}
+
+// findRRSet returns the RRSet with the name and type in the path values.
+func (s *Server) findRRSet(r *http.Request) (*schema.Zone, int64, *schema.ZoneRRSet, error) {
+	zone, err := s.findZone(r)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	id := r.PathValue("name") + "/" + r.PathValue("type")
+	for key, rrset := range s.rrsets {
+		if rrset.Zone == zone.ID && rrset.ID == id {
+			return zone, key, rrset, nil
+		}
+	}
+	return nil, 0, nil, errNotFound("rrset")
+}
+
+func (s *Server) registerZones() {
+	s.handle("GET /zones", func(r *http.Request) (any, error) {
+		return list(r, "zones", s.zones, listFields[schema.Zone]{
+			id:     func(z *schema.Zone) int64 { return z.ID },
+			name:   func(z *schema.Zone) string { return z.Name },
+			labels: func(z *schema.Zone) map[string]string { return z.Labels },
+			filters: map[string]func(*schema.Zone) string{
+				"mode": func(z *schema.Zone) string { return z.Mode },
+			},
+		}, s.zoneView)
+	})
+
+	s.handle("GET /zones/{zone}", func(r *http.Request) (any, error) {
+		zone, err := s.findZone(r)
+		if err != nil {
+			return nil, err
+		}
+		return schema.ZoneGetResponse{Zone: s.zoneView(zone)}, nil
+	})
+
+	s.handle("POST /zones", s.createZone)
+
+	s.handle("PUT /zones/{zone}", func(r *http.Request) (any, error) {
+		zone, err := s.findZone(r)
+		if err != nil {
+			return nil, err
+		}
+		req, err := decode[schema.ZoneUpdateRequest](r)
+		if err != nil {
+			return nil, err
+		}
+		if req.Labels != nil {
+			zone.Labels = copyLabels(req.Labels)
+		}
+		return schema.ZoneUpdateResponse{Zone: s.zoneView(zone)}, nil
+	})
+
+	s.handle("DELETE /zones/{zone}", func(r *http.Request) (any, error) {
+		zone, err := s.findZone(r)
+		if err != nil {
+			return nil, err
+		}
+		if zone.Protection.Delete {
+			return nil, errProtected("zone")
+		}
+		for key, rrset := range s.rrsets {
+			if rrset.Zone == zone.ID {
+				delete(s.rrsets, key)
+			}
+		}
+		delete(s.zones, zone.ID)
+		return schema.ActionGetResponse{Action: s.newAction("delete_zone", resourceRef("zone", zone.ID))}, nil
+	})
+
+	s.handle("GET /zones/{zone}/zonefile", func(r *http.Request) (any, error) {
+		zone, err := s.findZone(r)
+		if err != nil {
+			return nil, err
+		}
+		return schema.ZoneExportZonefileResponse{Zonefile: s.exportZonefile(zone)}, nil
+	})
+
+	s.handle("POST /zones/{zone}/actions/{action}", func(r *http.Request) (any, error) {
+		zone, err := s.findZone(r)
+		if err != nil {
+			return nil, err
+		}
+		ref := resourceRef("zone", zone.ID)
+
+		switch command := r.PathValue("action"); command {
+		case "change_protection":
+			req, err := decode[schema.ZoneChangeProtectionRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if req.Delete != nil {
+				zone.Protection.Delete = *req.Delete
+			}
+			return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+
+		case "change_ttl":
+			req, err := decode[schema.ZoneChangeTTLRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if err := validateTTL(req.TTL); err != nil {
+				return nil, err
+			}
+			zone.TTL = req.TTL
+			return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+
+		case "change_primary_nameservers":
+			req, err := decode[schema.ZoneChangePrimaryNameserversRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if zone.Mode != "secondary" {
+				return nil, errInvalidInput("primary nameservers can only be set on secondary zones")
+			}
+			nameservers := make([]schema.ZoneCreateRequestPrimaryNameserver, 0, len(req.PrimaryNameservers))
+			for _, ns := range req.PrimaryNameservers {
+				nameservers = append(nameservers, schema.ZoneCreateRequestPrimaryNameserver(ns))
+			}
+			if zone.PrimaryNameservers, err = primaryNameservers(nameservers); err != nil {
+				return nil, err
+			}
+			return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+
+		case "import_zonefile":
+			req, err := decode[schema.ZoneImportZonefileRequest](r)
+			if err != nil {
+				return nil, err
+			}
+			if zone.Mode != "primary" {
+				return nil, errSecondaryZone()
+			}
+			if err := s.importZonefile(zone, req.Zonefile); err != nil {
+				return nil, err
+			}
+			return schema.ActionGetResponse{Action: s.newAction(command, ref)}, nil
+
+		default:
+			return nil, errNotFound("action")
+		}
+	})
+
+	s.registerRRSets()
+}
+
+func (s *Server) createZone(r *http.Request) (any, error) {
+	req, err := decode[schema.ZoneCreateRequest](r)
+	if err != nil {
+		return nil, err
+	}
+	if !validZoneName(req.Name) {
+		return nil, errInvalidInput("invalid name: %q", req.Name)
+	}
+	if nameTaken(s.zones, 0, req.Name, zoneFields) {
+		return nil, errUniqueness("name")
+	}
+
+	zone := &schema.Zone{
+		ID:                 s.nextID(),
+		Name:               req.Name,
+		Created:            now(),
+		TTL:                defaultZoneTTL,
+		Mode:               req.Mode,
+		PrimaryNameservers: []schema.ZonePrimaryNameserver{},
+		Labels:             copyLabels(req.Labels),
+		AuthoritativeNameservers: schema.ZoneAuthoritativeNameservers{
+			Assigned:         slices.Clone(zoneNameservers),
+			Delegated:        []string{},
+			DelegationStatus: "unknown",
+		},
+		Registrar: "other",
+		Status:    "ok",
+	}
+	if req.TTL != nil {
+		if err := validateTTL(*req.TTL); err != nil {
+			return nil, err
+		}
+		zone.TTL = *req.TTL
+	}
+
+	switch req.Mode {
+	case "primary":
+		if len(req.PrimaryNameservers) > 0 {
+			return nil, errInvalidInput("primary_nameservers are only allowed for secondary zones")
+		}
+	case "secondary":
+		if len(req.PrimaryNameservers) == 0 {
+			return nil, errInvalidInput("primary_nameservers are required for secondary zones")
+		}
+		if len(req.RRSets) > 0 || req.Zonefile != "" {
+			return nil, errSecondaryZone()
+		}
+		if zone.PrimaryNameservers, err = primaryNameservers(req.PrimaryNameservers); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errInvalidInput("mode must be one of primary, secondary")
+	}
+	if len(req.RRSets) > 0 && req.Zonefile != "" {
+		return nil, errInvalidInput("only one of rrsets or zonefile is allowed")
+	}
+
+	s.zones[zone.ID] = zone
+	if req.Mode == "primary" {
+		s.addDefaultRRSets(zone)
+	}
+
+	var rrsetErr error
+	for _, rrset := range req.RRSets {
+		if _, rrsetErr = s.addRRSet(zone, schema.ZoneRRSetCreateRequest{
+			Name: rrset.Name, Type: rrset.Type, TTL: rrset.TTL, Labels: rrset.Labels, Records: rrset.Records,
+		}); rrsetErr != nil {
+			break
+		}
+	}
+	if rrsetErr == nil && req.Zonefile != "" {
+		rrsetErr = s.importZonefile(zone, req.Zonefile)
+	}
+	if rrsetErr != nil {
+		for key, rrset := range s.rrsets {
+			if rrset.Zone == zone.ID {
+				delete(s.rrsets, key)
+			}
+		}
+		delete(s.zones, zone.ID)
+		return nil, rrsetErr
+	}
+
+	return schema.ZoneCreateResponse{
+		Zone:   s.zoneView(zone),
+		Action: s.newAction("create_zone", resourceRef("zone", zone.ID)),
+	}, nil
+}
+
+// addDefaultRRSets adds the SOA and NS RRSets created by the API for primary
+// zones.
+func (s *Server) addDefaultRRSets(zone *schema.Zone) {
+	soa := fmt.Sprintf("%s dns.hetzner.com. %s01 86400 10800 3600000 3600", zoneNameservers[0], zone.Created.Format("20060102"))
+	nsRecords := make([]schema.ZoneRRSetRecord, 0, len(zoneNameservers))
+	for _, ns := range zoneNameservers {
+		nsRecords = append(nsRecords, schema.ZoneRRSetRecord{Value: ns})
+	}
+
+	for _, rrset := range []schema.ZoneRRSet{
+		{Name: "@", Type: "SOA", Records: []schema.ZoneRRSetRecord{{Value: soa}}},
+		{Name: "@", Type: "NS", Records: nsRecords},
+	} {
+		rrset.ID = rrset.Name + "/" + rrset.Type
+		rrset.Zone = zone.ID
+		rrset.Labels = map[string]string{}
+		s.rrsets[s.nextID()] = &rrset
+	}
+}
+
+func primaryNameservers(requested []schema.ZoneCreateRequestPrimaryNameserver) ([]schema.ZonePrimaryNameserver, error) {
+	result := make([]schema.ZonePrimaryNameserver, 0, len(requested))
+	for _, ns := range requested {
+		if ns.Address == "" {
+			return nil, errInvalidInput("primary nameserver address is required")
+		}
+		if ns.Port == 0 {
+			ns.Port = 53
+		}
+		result = append(result, schema.ZonePrimaryNameserver(ns))
+	}
+	return result, nil
+}
+
+func validZoneName(name string) bool {
+	if name == "" || name != strings.ToLower(name) || !strings.Contains(name, ".") ||
+		strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".") {
+		return false
+	}
+	return !strings.ContainsAny(name, " _*@")
+}
+
+func validateTTL(ttl int) error {
+	if ttl < 60 || ttl > 2147483647 {
+		return errInvalidInput("ttl must be at least 60")
+	}
+	return nil
+}
+
+func errSecondaryZone() error {
+	return &apiError{status: http.StatusUnprocessableEntity, code: "dns_zone_is_secondary_zone", message: "zone is a secondary zone"}
+}
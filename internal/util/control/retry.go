@@ -1,11 +1,16 @@
 package control
 
 import (
+	"context"
 	"errors"
-	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/traceutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/transportutil"
 )
 
 // DefaultRetries is a constant for the maximum number of retries we usually do.
@@ -35,8 +40,10 @@ func AbortRetry(err error) error {
 	return abortErr{Err: err}
 }
 
-// Retry executes f at most maxTries times.
-func Retry(maxTries int, f func() error) error {
+// Retry executes f at most maxTries times. It stops retrying once the context is done,
+// when waiting for the next try would exceed the context deadline, or when f fails with
+// an error that must not be retried, see [retryable].
+func Retry(ctx context.Context, maxTries int, f func() error) error {
 	var err error
 
 	backoff := hcloud.ExponentialBackoff(2, 1*time.Second)
@@ -48,15 +55,62 @@ func Retry(maxTries int, f func() error) error {
 		if errors.As(err, &aerr) {
 			return aerr.Err
 		}
-		if err != nil {
-			sleep := backoff(try)
-			log.Printf("[WARN] try %d/%d failed: retrying after %v: error: %v", try+1, maxTries, sleep, err)
-			time.Sleep(sleep)
-			continue
+		if err == nil {
+			return nil
+		}
+		if !retryable(err) {
+			return err
+		}
+		if try+1 >= maxTries {
+			break
 		}
 
-		return nil
+		sleep := backoff(try)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(sleep).After(deadline) {
+			return errors.Join(context.DeadlineExceeded, err)
+		}
+
+		tflog.Warn(ctx, "try failed, retrying", map[string]any{
+			"try":       try + 1,
+			"max_tries": maxTries,
+			"backoff":   sleep.String(),
+			"error":     err.Error(),
+		})
+
+		if err := wait(ctx, try+1, sleep, err); err != nil {
+			return err
+		}
 	}
 
 	return err
 }
+
+// retryable reports whether err may be retried. Requests rejected by the read-only
+// mode fail fast, and API errors already retried by the HTTP client, according to the
+// provider retry policy, are not retried again.
+func retryable(err error) bool {
+	var readOnlyErr *transportutil.ReadOnlyError
+	if errors.As(err, &readOnlyErr) {
+		return false
+	}
+	return !transportutil.IsRetried(err)
+}
+
+// wait sleeps before the next try, in its own trace span.
+func wait(ctx context.Context, try int, sleep time.Duration, tryErr error) error {
+	_, span := traceutil.Start(ctx, "retry backoff",
+		attribute.Int("try", try),
+		attribute.String("backoff", sleep.String()),
+		attribute.String("error", tryErr.Error()),
+	)
+	defer span.End()
+
+	timer := time.NewTimer(sleep)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		return errors.Join(ctx.Err(), tryErr)
+	case <-timer.C:
+		return nil
+	}
+}
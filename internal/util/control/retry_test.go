@@ -1,12 +1,19 @@
 package control_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/control"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/transportutil"
 )
 
 func TestRetry(t *testing.T) {
@@ -62,11 +69,20 @@ func TestRetry(t *testing.T) {
 			expectedTries: 1,
 			expectedErr:   errors.New("pointless"),
 		},
+		{
+			name: "No retries in read-only mode",
+			f: func(tt *testCase) error {
+				return fmt.Errorf("wrapped: %w", tt.expectedErr)
+			},
+			maxTries:      5,
+			expectedTries: 1,
+			expectedErr:   &transportutil.ReadOnlyError{Method: http.MethodPost, Path: "/servers"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := control.Retry(tt.maxTries, func() error {
+			err := control.Retry(context.Background(), tt.maxTries, func() error {
 				tt.actualTries++
 				t.Logf("Try %d/%d", tt.actualTries, tt.expectedTries)
 				return tt.f(&tt)
@@ -78,3 +94,60 @@ func TestRetry(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryContext(t *testing.T) {
+	t.Run("Stops when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		tries := 0
+		err := control.Retry(ctx, 5, func() error {
+			tries++
+			cancel()
+			return errors.New("retry me")
+		})
+		assert.Equal(t, 1, tries)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorContains(t, err, "retry me")
+	})
+
+	t.Run("Stops when the backoff exceeds the context deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		tries := 0
+		start := time.Now()
+		err := control.Retry(ctx, 5, func() error {
+			tries++
+			return errors.New("retry me")
+		})
+		assert.Equal(t, 1, tries)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+	})
+}
+
+func TestRetryRetriedByHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusLocked)
+		w.Write([]byte(`{"error":{"code":"locked","message":"locked"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := hcloud.NewClient(
+		hcloud.WithEndpoint(server.URL),
+		hcloud.WithHTTPClient(&http.Client{
+			Transport: transportutil.NewRetryTransport(transportutil.RetryConfig{MaxAttempts: 1}, http.DefaultTransport),
+		}),
+		hcloud.WithRetryOpts(hcloud.RetryOpts{MaxRetries: 0}),
+	)
+
+	tries := 0
+	err := control.Retry(t.Context(), 5, func() error {
+		tries++
+		_, _, err := client.Server.GetByID(t.Context(), 1)
+		return err
+	})
+	assert.Equal(t, 1, tries)
+	assert.True(t, hcloud.IsError(err, hcloud.ErrorCodeLocked))
+}
@@ -0,0 +1,309 @@
+package datasourceutil
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/timeutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/validateutil"
+)
+
+// DefaultMetricsDuration is the period of time the metrics are requested for, when
+// neither the start nor the end of the period is configured.
+const DefaultMetricsDuration = time.Hour
+
+// MetricsValue is a single sample of a metrics time series, as returned by the API.
+type MetricsValue struct {
+	// Timestamp is the Unix time of the sample in seconds.
+	Timestamp float64
+	Value     string
+}
+
+// MetricsData is the model of the fields of [MetricsSchema], meant to be embedded in
+// the model of a metrics data source.
+type MetricsData struct {
+	Start    types.String `tfsdk:"start"`
+	End      types.String `tfsdk:"end"`
+	Duration types.String `tfsdk:"duration"`
+	Step     types.Int64  `tfsdk:"step"`
+	Series   types.List   `tfsdk:"series"`
+}
+
+// MetricsResult is the metrics returned by the API.
+type MetricsResult struct {
+	Start      time.Time
+	End        time.Time
+	Step       float64
+	TimeSeries map[string][]MetricsValue
+}
+
+// MetricsGetter requests the metrics of the period of time from start to end, with
+// the given resolution in seconds (0 lets the API choose it).
+type MetricsGetter func(ctx context.Context, start, end time.Time, step int) (*MetricsResult, diag.Diagnostics)
+
+type metricsSeriesData struct {
+	Name   types.String       `tfsdk:"name"`
+	Values []metricsValueData `tfsdk:"values"`
+	Avg    types.Float64      `tfsdk:"avg"`
+	Max    types.Float64      `tfsdk:"max"`
+	P95    types.Float64      `tfsdk:"p95"`
+}
+
+type metricsValueData struct {
+	Timestamp types.String  `tfsdk:"timestamp"`
+	Value     types.Float64 `tfsdk:"value"`
+}
+
+var metricsSeriesAttrTypes = map[string]attr.Type{
+	"name": types.StringType,
+	"values": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+		"timestamp": types.StringType,
+		"value":     types.Float64Type,
+	}}},
+	"avg": types.Float64Type,
+	"max": types.Float64Type,
+	"p95": types.Float64Type,
+}
+
+// MetricsSchema returns the schema of the fields shared by the metrics data sources:
+// the period of time the metrics are requested for, and the returned time series.
+func MetricsSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"start": schema.StringAttribute{
+			MarkdownDescription: "Start of the period of time, in RFC3339 format. Defaults to `end` minus `duration`.",
+			Optional:            true,
+			Computed:            true,
+			Validators: []validator.String{
+				validateutil.RFC3339(),
+			},
+		},
+		"end": schema.StringAttribute{
+			MarkdownDescription: "End of the period of time, in RFC3339 format. Defaults to `start` plus `duration`, or the current time.",
+			Optional:            true,
+			Computed:            true,
+			Validators: []validator.String{
+				validateutil.RFC3339(),
+			},
+		},
+		"duration": schema.StringAttribute{
+			MarkdownDescription: "Length of the period of time, e.g. `30m` or `24h`. Defaults to `1h`. Must not be set together with both `start` and `end`.",
+			Optional:            true,
+			Validators: []validator.String{
+				validateutil.Duration(),
+			},
+		},
+		"step": schema.Int64Attribute{
+			MarkdownDescription: "Resolution of the time series in seconds. Defaults to a resolution chosen by the API.",
+			Optional:            true,
+			Computed:            true,
+		},
+		"series": schema.ListNestedAttribute{
+			MarkdownDescription: "Time series returned by the API, sorted by name.",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						MarkdownDescription: "Name of the time series, e.g. `cpu` or `open_connections`.",
+						Computed:            true,
+					},
+					"values": schema.ListNestedAttribute{
+						MarkdownDescription: "Samples of the time series.",
+						Computed:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"timestamp": schema.StringAttribute{
+									MarkdownDescription: "Time of the sample, in RFC3339 format.",
+									Computed:            true,
+								},
+								"value": schema.Float64Attribute{
+									MarkdownDescription: "Value of the sample.",
+									Computed:            true,
+								},
+							},
+						},
+					},
+					"avg": schema.Float64Attribute{
+						MarkdownDescription: "Average of the samples.",
+						Computed:            true,
+					},
+					"max": schema.Float64Attribute{
+						MarkdownDescription: "Maximum of the samples.",
+						Computed:            true,
+					},
+					"p95": schema.Float64Attribute{
+						MarkdownDescription: "95th percentile of the samples.",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// MetricsPeriod returns the period of time configured by the start, end and
+// duration fields of [MetricsSchema].
+func MetricsPeriod(start, end, duration types.String, now time.Time) (time.Time, time.Time, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	parse := func(value types.String, attribute string) time.Time {
+		if value.ValueString() == "" {
+			return time.Time{}
+		}
+		result, err := timeutil.ParseRFC3339(value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(attribute), "Invalid timestamp", err.Error())
+		}
+		return result
+	}
+	startTime, endTime := parse(start, "start"), parse(end, "end")
+
+	period := DefaultMetricsDuration
+	if duration.ValueString() != "" {
+		var err error
+		period, err = time.ParseDuration(duration.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("duration"), "Invalid duration", err.Error())
+		}
+	}
+	if !startTime.IsZero() && !endTime.IsZero() && !duration.IsNull() {
+		diags.AddAttributeError(
+			path.Root("duration"),
+			"Invalid period of time",
+			"Only two of start, end and duration can be set.",
+		)
+	}
+	if diags.HasError() {
+		return startTime, endTime, diags
+	}
+
+	switch {
+	case startTime.IsZero() && endTime.IsZero():
+		endTime = now
+		startTime = endTime.Add(-period)
+	case startTime.IsZero():
+		startTime = endTime.Add(-period)
+	case endTime.IsZero() && !duration.IsNull():
+		endTime = startTime.Add(period)
+	case endTime.IsZero():
+		endTime = now
+	}
+
+	if !endTime.After(startTime) {
+		diags.AddAttributeError(
+			path.Root("end"),
+			"Invalid period of time",
+			fmt.Sprintf("The end of the period (%s) must be after its start (%s).", timeutil.FormatRFC3339(endTime), timeutil.FormatRFC3339(startTime)),
+		)
+	}
+
+	return startTime, endTime, diags
+}
+
+// ReadMetrics requests the metrics of the period of time configured in data using get,
+// and sets the computed fields of data from the result.
+func ReadMetrics(ctx context.Context, data *MetricsData, now time.Time, get MetricsGetter) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	start, end, newDiags := MetricsPeriod(data.Start, data.End, data.Duration, now)
+	diags.Append(newDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	result, newDiags := get(ctx, start, end, int(data.Step.ValueInt64()))
+	diags.Append(newDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	data.Start, data.End, data.Step = metricsComputedValues(data.Start, data.End, data.Step, result.Start, result.End, result.Step)
+	data.Series, newDiags = NewMetricsSeries(ctx, result.TimeSeries)
+	diags.Append(newDiags...)
+
+	return diags
+}
+
+// metricsComputedValues returns the values of the start, end and step fields of
+// [MetricsSchema] once the metrics were requested. Configured values are kept as is,
+// Terraform rejects a different value even if it represents the same time: only the
+// fields that are not configured are set from the values returned by the API.
+func metricsComputedValues(start, end types.String, step types.Int64, resultStart, resultEnd time.Time, resultStep float64) (types.String, types.String, types.Int64) {
+	if start.IsNull() {
+		start = types.StringValue(timeutil.FormatRFC3339(resultStart))
+	}
+	if end.IsNull() {
+		end = types.StringValue(timeutil.FormatRFC3339(resultEnd))
+	}
+	if step.IsNull() {
+		step = types.Int64Value(int64(resultStep))
+	}
+	return start, end, step
+}
+
+// NewMetricsSeries returns the value of the series field of [MetricsSchema] for the
+// time series returned by the API. Samples that are not a finite number are skipped.
+func NewMetricsSeries(ctx context.Context, timeSeries map[string][]MetricsValue) (types.List, diag.Diagnostics) {
+	names := slices.Sorted(maps.Keys(timeSeries))
+
+	series := make([]metricsSeriesData, 0, len(names))
+	for _, name := range names {
+		data := metricsSeriesData{
+			Name:   types.StringValue(name),
+			Values: make([]metricsValueData, 0, len(timeSeries[name])),
+		}
+
+		samples := make([]float64, 0, len(timeSeries[name]))
+		for _, v := range timeSeries[name] {
+			value, err := strconv.ParseFloat(v.Value, 64)
+			if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+			samples = append(samples, value)
+
+			timestamp := time.Unix(0, 0).Add(timeutil.DurationFromSeconds(v.Timestamp))
+			data.Values = append(data.Values, metricsValueData{
+				Timestamp: types.StringValue(timeutil.FormatRFC3339(timestamp)),
+				Value:     types.Float64Value(value),
+			})
+		}
+
+		data.Avg, data.Max, data.P95 = types.Float64Null(), types.Float64Null(), types.Float64Null()
+		if avg, maximum, p95, ok := metricsStats(samples); ok {
+			data.Avg, data.Max, data.P95 = types.Float64Value(avg), types.Float64Value(maximum), types.Float64Value(p95)
+		}
+
+		series = append(series, data)
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: metricsSeriesAttrTypes}, series)
+}
+
+// metricsStats returns the average, the maximum and the 95th percentile (nearest-rank
+// method) of the samples. It returns false if there are no samples.
+func metricsStats(samples []float64) (float64, float64, float64, bool) {
+	if len(samples) == 0 {
+		return 0, 0, 0, false
+	}
+
+	sorted := slices.Sorted(slices.Values(samples))
+
+	var sum float64
+	for _, sample := range sorted {
+		sum += sample
+	}
+
+	rank := int(math.Ceil(0.95 * float64(len(sorted))))
+	return sum / float64(len(sorted)), sorted[len(sorted)-1], sorted[rank-1], true
+}
@@ -0,0 +1,199 @@
+package datasourceutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsPeriod(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		start     types.String
+		end       types.String
+		duration  types.String
+		wantStart string
+		wantEnd   string
+		wantError bool
+	}{
+		{
+			name:      "defaults",
+			start:     types.StringNull(),
+			end:       types.StringNull(),
+			duration:  types.StringNull(),
+			wantStart: "2025-01-02T11:00:00Z",
+			wantEnd:   "2025-01-02T12:00:00Z",
+		},
+		{
+			name:      "duration",
+			start:     types.StringNull(),
+			end:       types.StringNull(),
+			duration:  types.StringValue("24h"),
+			wantStart: "2025-01-01T12:00:00Z",
+			wantEnd:   "2025-01-02T12:00:00Z",
+		},
+		{
+			name:      "start",
+			start:     types.StringValue("2025-01-02T10:00:00Z"),
+			end:       types.StringNull(),
+			duration:  types.StringNull(),
+			wantStart: "2025-01-02T10:00:00Z",
+			wantEnd:   "2025-01-02T12:00:00Z",
+		},
+		{
+			name:      "start and duration",
+			start:     types.StringValue("2025-01-02T10:00:00Z"),
+			end:       types.StringNull(),
+			duration:  types.StringValue("30m"),
+			wantStart: "2025-01-02T10:00:00Z",
+			wantEnd:   "2025-01-02T10:30:00Z",
+		},
+		{
+			name:      "end",
+			start:     types.StringNull(),
+			end:       types.StringValue("2025-01-02T10:00:00+01:00"),
+			duration:  types.StringNull(),
+			wantStart: "2025-01-02T08:00:00Z",
+			wantEnd:   "2025-01-02T09:00:00Z",
+		},
+		{
+			name:      "end and duration",
+			start:     types.StringNull(),
+			end:       types.StringValue("2025-01-02T10:00:00Z"),
+			duration:  types.StringValue("30m"),
+			wantStart: "2025-01-02T09:30:00Z",
+			wantEnd:   "2025-01-02T10:00:00Z",
+		},
+		{
+			name:      "start, end and duration",
+			start:     types.StringValue("2025-01-02T09:00:00Z"),
+			end:       types.StringValue("2025-01-02T10:00:00Z"),
+			duration:  types.StringValue("30m"),
+			wantError: true,
+		},
+		{
+			name:      "end before start",
+			start:     types.StringValue("2025-01-02T10:00:00Z"),
+			end:       types.StringValue("2025-01-02T09:00:00Z"),
+			duration:  types.StringNull(),
+			wantError: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, diags := MetricsPeriod(tt.start, tt.end, tt.duration, now)
+			if tt.wantError {
+				assert.True(t, diags.HasError())
+				return
+			}
+			require.False(t, diags.HasError(), diags)
+			assert.Equal(t, tt.wantStart, start.UTC().Format(time.RFC3339))
+			assert.Equal(t, tt.wantEnd, end.UTC().Format(time.RFC3339))
+		})
+	}
+}
+
+func TestMetricsComputedValues(t *testing.T) {
+	resultStart := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	resultEnd := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	t.Run("not configured", func(t *testing.T) {
+		start, end, step := metricsComputedValues(types.StringNull(), types.StringNull(), types.Int64Null(), resultStart, resultEnd, 60)
+		assert.Equal(t, types.StringValue("2025-01-02T09:00:00Z"), start)
+		assert.Equal(t, types.StringValue("2025-01-02T10:00:00Z"), end)
+		assert.Equal(t, types.Int64Value(60), step)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		// The end is configured with a non-UTC offset and fractional seconds, and the
+		// API adjusted the step.
+		start, end, step := metricsComputedValues(types.StringNull(), types.StringValue("2025-01-02T11:00:00.5+01:00"), types.Int64Value(1), resultStart, resultEnd, 60)
+		assert.Equal(t, types.StringValue("2025-01-02T09:00:00Z"), start)
+		assert.Equal(t, types.StringValue("2025-01-02T11:00:00.5+01:00"), end)
+		assert.Equal(t, types.Int64Value(1), step)
+	})
+}
+
+func TestReadMetrics(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	data := MetricsData{
+		Start:    types.StringNull(),
+		End:      types.StringValue("2025-01-02T11:00:00+01:00"),
+		Duration: types.StringValue("30m"),
+		Step:     types.Int64Null(),
+	}
+
+	diags := ReadMetrics(ctx, &data, now, func(_ context.Context, start, end time.Time, step int) (*MetricsResult, diag.Diagnostics) {
+		assert.Equal(t, time.Date(2025, 1, 2, 9, 30, 0, 0, time.UTC), start.UTC())
+		assert.Equal(t, time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC), end.UTC())
+		assert.Equal(t, 0, step)
+
+		return &MetricsResult{
+			Start:      start.UTC(),
+			End:        end.UTC(),
+			Step:       60,
+			TimeSeries: map[string][]MetricsValue{"cpu": {{Timestamp: 1735810200, Value: "1.5"}}},
+		}, nil
+	})
+	require.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, types.StringValue("2025-01-02T09:30:00Z"), data.Start)
+	assert.Equal(t, types.StringValue("2025-01-02T11:00:00+01:00"), data.End)
+	assert.Equal(t, types.Int64Value(60), data.Step)
+	assert.Len(t, data.Series.Elements(), 1)
+}
+
+func TestNewMetricsSeries(t *testing.T) {
+	values := make([]MetricsValue, 0, 21)
+	for i := range 20 {
+		values = append(values, MetricsValue{Timestamp: float64(1735819200 + 60*i), Value: []string{"1", "2", "3", "4"}[i%4]})
+	}
+	values = append(values, MetricsValue{Timestamp: 1735820400, Value: "NaN"})
+
+	series, diags := NewMetricsSeries(t.Context(), map[string][]MetricsValue{
+		"disk.0.iops.write": {},
+		"cpu":               values,
+	})
+	require.False(t, diags.HasError(), diags)
+
+	var data []metricsSeriesData
+	require.False(t, series.ElementsAs(t.Context(), &data, false).HasError())
+	require.Len(t, data, 2)
+
+	assert.Equal(t, "cpu", data[0].Name.ValueString())
+	assert.Len(t, data[0].Values, 20)
+	assert.Equal(t, "2025-01-02T12:00:00Z", data[0].Values[0].Timestamp.ValueString())
+	assert.InDelta(t, 2.5, data[0].Avg.ValueFloat64(), 0.0001)
+	assert.InDelta(t, 4, data[0].Max.ValueFloat64(), 0.0001)
+	assert.InDelta(t, 4, data[0].P95.ValueFloat64(), 0.0001)
+
+	assert.Equal(t, "disk.0.iops.write", data[1].Name.ValueString())
+	assert.Empty(t, data[1].Values)
+	assert.True(t, data[1].Avg.IsNull())
+}
+
+func TestMetricsStats(t *testing.T) {
+	samples := make([]float64, 0, 100)
+	for i := 100; i > 0; i-- {
+		samples = append(samples, float64(i))
+	}
+
+	avg, maximum, p95, ok := metricsStats(samples)
+	assert.True(t, ok)
+	assert.InDelta(t, 50.5, avg, 0.0001)
+	assert.InDelta(t, 100, maximum, 0.0001)
+	assert.InDelta(t, 95, p95, 0.0001)
+
+	_, _, _, ok = metricsStats(nil)
+	assert.False(t, ok)
+}
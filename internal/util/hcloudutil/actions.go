@@ -8,18 +8,43 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/traceutil"
 )
 
 type ActionWaiter interface {
 	WaitForFunc(ctx context.Context, handleUpdate func(update *hcloud.Action) error, actions ...*hcloud.Action) error
 }
 
+// WaitForActions waits until all actions succeed or one of them fails, like
+// [hcloud.ActionClient.WaitFor]. The wait is traced in its own span.
+func WaitForActions(ctx context.Context, client ActionWaiter, actions ...*hcloud.Action) (err error) {
+	ctx, span := startActionWaitSpan(ctx, actions)
+	defer func() { traceutil.End(span, err) }()
+
+	return client.WaitForFunc(ctx, func(update *hcloud.Action) error {
+		if update.Status == hcloud.ActionStatusError {
+			return update.Error()
+		}
+		return nil
+	}, actions...)
+}
+
 func SettleActions(ctx context.Context, client ActionWaiter, actions ...*hcloud.Action) (diags diag.Diagnostics) {
 	// Filter out nil actions
 	actions = slices.DeleteFunc(actions, func(a *hcloud.Action) bool { return a == nil })
 
+	ctx, span := startActionWaitSpan(ctx, actions)
+	defer func() {
+		if diags.HasError() {
+			span.SetStatus(codes.Error, diags.Errors()[0].Summary())
+		}
+		span.End()
+	}()
+
 	running := slices.Clone(actions)
 	failed := make([]*hcloud.Action, 0)
 
@@ -59,6 +84,28 @@ func SettleActions(ctx context.Context, client ActionWaiter, actions ...*hcloud.
 	return
 }
 
+// startActionWaitSpan starts the span of a wait on actions, holding the actions IDs,
+// commands and resources.
+func startActionWaitSpan(ctx context.Context, actions []*hcloud.Action) (context.Context, trace.Span) {
+	ids := make([]int64, 0, len(actions))
+	commands := make([]string, 0, len(actions))
+	resources := make([]string, 0, len(actions))
+	for _, action := range actions {
+		if action == nil {
+			continue
+		}
+		ids = append(ids, action.ID)
+		commands = append(commands, action.Command)
+		resources = append(resources, actionResourceDescription(action))
+	}
+
+	return traceutil.Start(ctx, "wait for actions",
+		traceutil.ActionIDsKey.Int64Slice(ids),
+		traceutil.ActionCmdsKey.StringSlice(commands),
+		traceutil.ActionResourcesKey.StringSlice(resources),
+	)
+}
+
 func ActionErrorDiagnostic(action *hcloud.Action) diag.Diagnostic {
 	detail := strings.Builder{}
 
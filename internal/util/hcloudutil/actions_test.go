@@ -7,8 +7,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/traceutil"
 )
 
 type mockActionWaiter struct {
@@ -105,6 +107,36 @@ func TestSettleActions(t *testing.T) {
 	}
 }
 
+func TestWaitForActions(t *testing.T) {
+	exporter := traceutil.NewInMemoryExporter(t)
+
+	actions := []*hcloud.Action{
+		{
+			ID:        1,
+			Status:    hcloud.ActionStatusSuccess,
+			Command:   "create_server",
+			Resources: []*hcloud.ActionResource{{ID: 42, Type: hcloud.ActionResourceTypeServer}},
+		},
+		{ID: 2, Status: hcloud.ActionStatusSuccess, Command: "start_server"},
+	}
+
+	err := WaitForActions(t.Context(), &mockActionWaiter{t: t, actions: actions}, actions...)
+	require.NoError(t, err)
+
+	err = WaitForActions(t.Context(), &mockActionWaiter{t: t, actions: actions, err: context.DeadlineExceeded}, actions...)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "wait for actions", spans[0].Name)
+	assert.Contains(t, spans[0].Attributes, traceutil.ActionIDsKey.Int64Slice([]int64{1, 2}))
+	assert.Contains(t, spans[0].Attributes, traceutil.ActionCmdsKey.StringSlice([]string{"create_server", "start_server"}))
+	assert.Contains(t, spans[0].Attributes, traceutil.ActionResourcesKey.StringSlice([]string{"server: 42", ""}))
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+}
+
 func TestActionErrorDiagnostic(t *testing.T) {
 	for _, testCase := range []struct {
 		name     string
@@ -0,0 +1,39 @@
+package hcloudutil
+
+import (
+	"slices"
+)
+
+// DefaultsConfig holds the provider level default values, shared by all resources.
+type DefaultsConfig struct {
+	// Location is used by the resources that do not configure any location.
+	Location string
+	// Datacenter is used by the resources that do not configure any location, when no
+	// default location is configured.
+	Datacenter string
+}
+
+// Placement returns the name of the attribute to set, and its default value, for a
+// resource that configures none of the location, datacenter and exclusive attributes.
+// An empty datacenter means the resource does not accept a datacenter. The configured
+// function reports whether an attribute is set in the resource configuration.
+//
+// An empty attribute name is returned when no default must be used.
+func (c DefaultsConfig) Placement(location, datacenter string, exclusive []string, configured func(name string) bool) (string, string) {
+	attributes := append([]string{location}, exclusive...)
+	if datacenter != "" {
+		attributes = append(attributes, datacenter)
+	}
+	if slices.ContainsFunc(attributes, configured) {
+		return "", ""
+	}
+
+	switch {
+	case c.Location != "":
+		return location, c.Location
+	case datacenter != "" && c.Datacenter != "":
+		return datacenter, c.Datacenter
+	default:
+		return "", ""
+	}
+}
@@ -0,0 +1,39 @@
+package hcloudutil
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// CustomizeDiffDefaultLocation returns a [schema.CustomizeDiffFunc] planning the provider
+// default location of a SDK resource being created, see [DefaultsConfig.Placement].
+func CustomizeDiffDefaultLocation(location, datacenter string, exclusive ...string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, m any) error {
+		// Defaults only apply to new resources, to never replace an existing resource.
+		if d.Id() != "" {
+			return nil
+		}
+
+		config := d.GetRawConfig()
+		if config.IsNull() || !config.IsKnown() {
+			return nil
+		}
+
+		attribute, value := defaultsConfigFromMeta(m).Placement(location, datacenter, exclusive, func(name string) bool {
+			return !config.GetAttr(name).IsNull()
+		})
+		if attribute == "" {
+			return nil
+		}
+
+		return d.SetNew(attribute, value)
+	}
+}
+
+func defaultsConfigFromMeta(m any) DefaultsConfig {
+	if data, ok := m.(*ProviderData); ok && data != nil {
+		return data.Defaults
+	}
+	return DefaultsConfig{}
+}
@@ -0,0 +1,71 @@
+package hcloudutil
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultsConfigPlacement(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        DefaultsConfig
+		datacenter    string
+		configured    []string
+		wantAttribute string
+		wantValue     string
+	}{
+		{
+			name:          "default location",
+			config:        DefaultsConfig{Location: "fsn1", Datacenter: "nbg1-dc3"},
+			datacenter:    "datacenter",
+			wantAttribute: "location",
+			wantValue:     "fsn1",
+		},
+		{
+			name:          "default datacenter",
+			config:        DefaultsConfig{Datacenter: "nbg1-dc3"},
+			datacenter:    "datacenter",
+			wantAttribute: "datacenter",
+			wantValue:     "nbg1-dc3",
+		},
+		{
+			name:       "default datacenter not accepted",
+			config:     DefaultsConfig{Datacenter: "nbg1-dc3"},
+			datacenter: "",
+		},
+		{
+			name:       "no defaults",
+			config:     DefaultsConfig{},
+			datacenter: "datacenter",
+		},
+		{
+			name:       "location configured",
+			config:     DefaultsConfig{Location: "fsn1"},
+			datacenter: "datacenter",
+			configured: []string{"location"},
+		},
+		{
+			name:       "datacenter configured",
+			config:     DefaultsConfig{Location: "fsn1"},
+			datacenter: "datacenter",
+			configured: []string{"datacenter"},
+		},
+		{
+			name:       "exclusive configured",
+			config:     DefaultsConfig{Location: "fsn1"},
+			configured: []string{"server_id"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			attribute, value := tt.config.Placement("location", tt.datacenter, []string{"server_id"}, func(name string) bool {
+				return slices.Contains(tt.configured, name)
+			})
+			assert.Equal(t, tt.wantAttribute, attribute)
+			assert.Equal(t, tt.wantValue, value)
+		})
+	}
+}
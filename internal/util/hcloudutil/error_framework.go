@@ -8,12 +8,28 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/transportutil"
 )
 
+// readOnlyDetail is the detail of the diagnostic returned when a request is rejected by
+// the read-only mode.
+const readOnlyDetail = "The provider is configured in read-only mode, using the read_only attribute or the " +
+	"HCLOUD_READ_ONLY environment variable. Requests that could mutate a resource are rejected.\n\n" +
+	"Rejected request: %s %s"
+
 // APIErrorDiagnostics creates diagnostics from the errors that occurred during an API requests.
 func APIErrorDiagnostics(err error) diag.Diagnostics {
 	var diagnostics diag.Diagnostics
 	var hcloudErr hcloud.Error
+	var readOnlyErr *transportutil.ReadOnlyError
+
+	if errors.As(err, &readOnlyErr) {
+		diagnostics.AddError(
+			"Provider is in read-only mode",
+			fmt.Sprintf(readOnlyDetail, readOnlyErr.Method, readOnlyErr.Path),
+		)
+		return diagnostics
+	}
 
 	if errors.As(err, &hcloudErr) {
 		statusCodeMessage := ""
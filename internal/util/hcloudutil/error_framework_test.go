@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/transportutil"
 )
 
 func TestAPIErrorDiagnostics(t *testing.T) {
@@ -113,6 +114,17 @@ something broke :(
 `),
 			},
 		},
+		{
+			name: "read-only mode error",
+			err:  fmt.Errorf("Delete \"https://api.hetzner.cloud/v1/servers/42\": %w", &transportutil.ReadOnlyError{Method: "DELETE", Path: "/v1/servers/42"}),
+			diagnostics: []diag.Diagnostic{
+				diag.NewErrorDiagnostic(
+					"Provider is in read-only mode",
+					`The provider is configured in read-only mode, using the read_only attribute or the HCLOUD_READ_ONLY environment variable. Requests that could mutate a resource are rejected.
+
+Rejected request: DELETE /v1/servers/42`),
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			err := tc.err
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/transportutil"
 )
 
 // ErrorToDiag creates a terraform diag
@@ -15,6 +16,11 @@ import (
 // them more understandable for users
 func ErrorToDiag(err error) diag.Diagnostics {
 	var hcloudErr hcloud.Error
+	var readOnlyErr *transportutil.ReadOnlyError
+
+	if errors.As(err, &readOnlyErr) {
+		return diag.Diagnostics{readOnlyDiagnostic(readOnlyErr)}
+	}
 
 	if !errors.As(err, &hcloudErr) {
 		return diag.FromErr(err)
@@ -24,6 +30,15 @@ func ErrorToDiag(err error) diag.Diagnostics {
 	}
 	return diag.FromErr(err)
 }
+
+func readOnlyDiagnostic(err *transportutil.ReadOnlyError) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  "Provider is in read-only mode",
+		Detail:   fmt.Sprintf(readOnlyDetail, err.Method, err.Path),
+	}
+}
+
 func enrichInvalidInput(err hcloud.Error) diag.Diagnostics {
 	ie := err.Details.(hcloud.ErrorDetailsInvalidInput)
 	invalidInputs := make([]string, len(ie.Fields))
@@ -2,11 +2,13 @@ package hcloudutil
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/transportutil"
 )
 
 func TestErrorToDiag(t *testing.T) {
@@ -30,6 +32,11 @@ func TestErrorToDiag(t *testing.T) {
 			hcloud.Error{Code: hcloud.ErrorCodeInvalidInput, Message: "Invalid Input", Details: hcloud.ErrorDetailsInvalidInput{Fields: []hcloud.ErrorDetailsInvalidInputField{{Name: "ip", Messages: []string{"invalid field"}}}}},
 			"Invalid Input (invalid_input): [ip => [invalid field]]",
 		},
+		{
+			"read-only mode",
+			fmt.Errorf("Post \"https://api.hetzner.cloud/v1/servers\": %w", &transportutil.ReadOnlyError{Method: "POST", Path: "/v1/servers"}),
+			"Provider is in read-only mode",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2,9 +2,14 @@ package hcloudutil
 
 import (
 	"context"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/merge"
 )
 
 func TerraformLabelsToHCloud(ctx context.Context, inputLabels types.Map, outputLabels *map[string]string) diag.Diagnostics {
@@ -17,3 +22,100 @@ func TerraformLabelsToHCloud(ctx context.Context, inputLabels types.Map, outputL
 
 	return diagnostics
 }
+
+// LabelsConfig holds the provider level labels settings, shared by all resources.
+type LabelsConfig struct {
+	// Default labels are merged into the labels of every resource.
+	Default map[string]string
+	// IgnoreKeys are label keys managed outside of Terraform.
+	IgnoreKeys []string
+	// IgnoreKeyPrefixes are label key prefixes managed outside of Terraform.
+	IgnoreKeyPrefixes []string
+}
+
+// All returns the resource labels merged with the default labels. The resource labels
+// take precedence over the default labels.
+func (c LabelsConfig) All(labels map[string]string) map[string]string {
+	return merge.Maps(c.Default, labels)
+}
+
+// Ignored reports whether the label key is managed outside of Terraform.
+func (c LabelsConfig) Ignored(key string) bool {
+	if slices.Contains(c.IgnoreKeys, key) {
+		return true
+	}
+	for _, prefix := range c.IgnoreKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasIgnored reports whether some labels are managed outside of Terraform.
+func (c LabelsConfig) HasIgnored() bool {
+	return len(c.IgnoreKeys) > 0 || len(c.IgnoreKeyPrefixes) > 0
+}
+
+// Filter returns the labels returned by the API without the ignored labels. Ignored
+// labels are kept when they are configured on the resource or in the default labels.
+func (c LabelsConfig) Filter(configured, all map[string]string) map[string]string {
+	result := make(map[string]string, len(all))
+	for key, value := range all {
+		if c.Ignored(key) {
+			_, isConfigured := configured[key]
+			_, isDefault := c.Default[key]
+			if !isConfigured && !isDefault {
+				continue
+			}
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// Resource returns the labels to store in the resource labels attribute, from all the
+// labels returned by the API. Default labels are omitted, unless they were previously
+// configured on the resource, or their value differs from the default value. Ignored
+// labels are omitted.
+func (c LabelsConfig) Resource(configured, all map[string]string) map[string]string {
+	result := c.Filter(configured, all)
+	for key, value := range result {
+		if defaultValue, ok := c.Default[key]; ok && defaultValue == value {
+			if _, ok := configured[key]; !ok {
+				delete(result, key)
+			}
+		}
+	}
+	return result
+}
+
+// Preserve returns the labels to send to the API, completed with the ignored labels
+// currently set on the resource, so they are not removed by an update.
+func (c LabelsConfig) Preserve(labels, remote map[string]string) map[string]string {
+	result := maps.Clone(labels)
+	if result == nil {
+		result = make(map[string]string)
+	}
+	for key, value := range remote {
+		if _, ok := result[key]; !ok && c.Ignored(key) {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// Update returns the labels to send to the API in an update request. When some labels
+// are ignored, the current labels of the resource are fetched using remote, and the
+// ignored labels are preserved.
+func (c LabelsConfig) Update(labels map[string]string, remote func() (map[string]string, error)) (map[string]string, error) {
+	if !c.HasIgnored() {
+		return labels, nil
+	}
+
+	remoteLabels, err := remote()
+	if err != nil {
+		return nil, err
+	}
+	return c.Preserve(labels, remoteLabels), nil
+}
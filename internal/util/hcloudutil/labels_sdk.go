@@ -0,0 +1,86 @@
+package hcloudutil
+
+import (
+	"context"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// LabelsAllSchema returns the schema of the computed labels_all attribute, for the SDK
+// resources.
+func LabelsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "All labels of the resource, including the labels inherited from the provider `default_labels`.",
+	}
+}
+
+// CustomizeDiffLabelsAll plans the labels_all attribute of the SDK resources, by merging
+// the provider default labels with the resource labels.
+func CustomizeDiffLabelsAll(_ context.Context, d *schema.ResourceDiff, m any) error {
+	if !d.NewValueKnown("labels") {
+		return d.SetNewComputed("labels_all")
+	}
+
+	labels := labelsConfigFromMeta(m).All(toLabels(d.Get("labels")))
+	if maps.Equal(labels, toLabels(d.Get("labels_all"))) {
+		return nil
+	}
+
+	return d.SetNew("labels_all", labels)
+}
+
+// LabelsFromResourceData returns the labels of a SDK resource merged with the provider
+// default labels, ready to be sent to the API.
+func LabelsFromResourceData(d *schema.ResourceData, m any) map[string]string {
+	return labelsConfigFromMeta(m).All(toLabels(d.Get("labels")))
+}
+
+// UpdateLabelsFromResourceData returns the labels of a SDK resource merged with the
+// provider default labels, ready to be sent to the API in an update request. The
+// remote function must return the labels currently set on the resource, it is only
+// called when the provider ignores some labels, to preserve them.
+func UpdateLabelsFromResourceData(d *schema.ResourceData, m any, remote func() (map[string]string, error)) (map[string]string, error) {
+	config := labelsConfigFromMeta(m)
+	return config.Update(config.All(toLabels(d.Get("labels"))), remote)
+}
+
+// SetResourceDataLabels sets the labels and labels_all attributes of a SDK resource from
+// the labels returned by the API. The configured labels must be read before any of
+// the attributes are overwritten.
+func SetResourceDataLabels(d *schema.ResourceData, m any, configured any, labels map[string]string) error {
+	config := labelsConfigFromMeta(m)
+	if err := d.Set("labels_all", config.Filter(toLabels(configured), labels)); err != nil {
+		return err
+	}
+	return d.Set("labels", config.Resource(toLabels(configured), labels))
+}
+
+// DataSourceAttributes removes the labels ignored by the provider from the attributes
+// of a SDK data source.
+func DataSourceAttributes(m any, attributes map[string]any) map[string]any {
+	if labels, ok := attributes["labels"].(map[string]string); ok {
+		attributes["labels"] = labelsConfigFromMeta(m).Filter(nil, labels)
+	}
+	return attributes
+}
+
+func labelsConfigFromMeta(m any) LabelsConfig {
+	if data, ok := m.(*ProviderData); ok && data != nil {
+		return data.Labels
+	}
+	return LabelsConfig{}
+}
+
+func toLabels(value any) map[string]string {
+	result := make(map[string]string)
+	if value, ok := value.(map[string]any); ok {
+		for k, v := range value {
+			result[k] = v.(string)
+		}
+	}
+	return result
+}
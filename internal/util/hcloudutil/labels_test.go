@@ -51,3 +51,113 @@ func TestTerraformLabelsToHCloud(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelsConfig(t *testing.T) {
+	config := LabelsConfig{
+		Default: map[string]string{"env": "prod", "team": "infra"},
+	}
+
+	t.Run("All", func(t *testing.T) {
+		assert.Equal(t,
+			map[string]string{"env": "dev", "team": "infra", "key": "value"},
+			config.All(map[string]string{"env": "dev", "key": "value"}),
+		)
+		assert.Equal(t,
+			map[string]string{"env": "prod", "team": "infra"},
+			config.All(nil),
+		)
+	})
+
+	t.Run("Resource", func(t *testing.T) {
+		all := map[string]string{"env": "prod", "team": "other", "key": "value"}
+
+		// Default labels with the default value are omitted.
+		assert.Equal(t,
+			map[string]string{"team": "other", "key": "value"},
+			config.Resource(map[string]string{"key": "value"}, all),
+		)
+		// Default labels configured on the resource are kept.
+		assert.Equal(t,
+			map[string]string{"env": "prod", "team": "other", "key": "value"},
+			config.Resource(map[string]string{"env": "prod", "key": "value"}, all),
+		)
+	})
+}
+
+func TestLabelsConfigIgnore(t *testing.T) {
+	config := LabelsConfig{
+		Default:           map[string]string{"env": "prod"},
+		IgnoreKeys:        []string{"hcloud/node-group"},
+		IgnoreKeyPrefixes: []string{"csi.hetzner.cloud/"},
+	}
+
+	assert.True(t, config.HasIgnored())
+	assert.False(t, LabelsConfig{}.HasIgnored())
+
+	assert.True(t, config.Ignored("hcloud/node-group"))
+	assert.True(t, config.Ignored("csi.hetzner.cloud/volume"))
+	assert.False(t, config.Ignored("hcloud/node-group-2"))
+	assert.False(t, config.Ignored("key"))
+
+	remote := map[string]string{
+		"env":                      "prod",
+		"key":                      "value",
+		"hcloud/node-group":        "workers",
+		"csi.hetzner.cloud/volume": "data",
+	}
+
+	t.Run("Filter", func(t *testing.T) {
+		assert.Equal(t,
+			map[string]string{"env": "prod", "key": "value"},
+			config.Filter(map[string]string{"key": "value"}, remote),
+		)
+		// Ignored labels configured on the resource are kept.
+		assert.Equal(t,
+			map[string]string{"env": "prod", "key": "value", "hcloud/node-group": "workers"},
+			config.Filter(map[string]string{"key": "value", "hcloud/node-group": "workers"}, remote),
+		)
+	})
+
+	t.Run("Resource", func(t *testing.T) {
+		assert.Equal(t,
+			map[string]string{"key": "value"},
+			config.Resource(map[string]string{"key": "value"}, remote),
+		)
+	})
+
+	t.Run("Preserve", func(t *testing.T) {
+		assert.Equal(t,
+			map[string]string{"env": "prod", "key": "other", "hcloud/node-group": "workers", "csi.hetzner.cloud/volume": "data"},
+			config.Preserve(map[string]string{"env": "prod", "key": "other"}, remote),
+		)
+		assert.Equal(t,
+			map[string]string{"hcloud/node-group": "workers", "csi.hetzner.cloud/volume": "data"},
+			config.Preserve(nil, remote),
+		)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		labels, err := config.Update(map[string]string{"key": "value"}, func() (map[string]string, error) {
+			return remote, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t,
+			map[string]string{"key": "value", "hcloud/node-group": "workers", "csi.hetzner.cloud/volume": "data"},
+			labels,
+		)
+
+		labels, err = LabelsConfig{}.Update(map[string]string{"key": "value"}, func() (map[string]string, error) {
+			t.Fatal("remote labels must not be fetched when no labels are ignored")
+			return nil, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"key": "value"}, labels)
+	})
+
+	t.Run("DataSourceAttributes", func(t *testing.T) {
+		assert.Equal(t,
+			map[string]any{"name": "server", "labels": map[string]string{"env": "prod", "key": "value"}},
+			DataSourceAttributes(&ProviderData{Labels: config}, map[string]any{"name": "server", "labels": remote}),
+		)
+	})
+}
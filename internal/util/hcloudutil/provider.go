@@ -8,21 +8,37 @@ import (
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 )
 
-func ConfigureClient(providerData any) (*hcloud.Client, diag.Diagnostics) {
+// ProviderData holds the configured client and the provider level settings, it is
+// passed to every resource, data source and action of both the plugin framework and
+// the SDK provider.
+type ProviderData struct {
+	Client   *hcloud.Client
+	Labels   LabelsConfig
+	Defaults DefaultsConfig
+}
+
+// ConfigureProviderData returns the [ProviderData] configured by the provider. An
+// empty value is returned when the provider is not yet configured.
+func ConfigureProviderData(providerData any) (ProviderData, diag.Diagnostics) {
 	var diagnostics diag.Diagnostics
 
 	if providerData == nil {
-		return nil, diagnostics
+		return ProviderData{}, diagnostics
 	}
 
-	client, ok := providerData.(*hcloud.Client)
+	data, ok := providerData.(*ProviderData)
 	if !ok {
 		diagnostics.AddError(
 			"Unexpected Configure Type",
-			fmt.Sprintf("Expected *hcloud.Client, got: %T. Please report this issue to the provider developers.", providerData),
+			fmt.Sprintf("Expected *hcloudutil.ProviderData, got: %T. Please report this issue to the provider developers.", providerData),
 		)
-		return nil, diagnostics
+		return ProviderData{}, diagnostics
 	}
 
-	return client, diagnostics
+	return *data, diagnostics
+}
+
+func ConfigureClient(providerData any) (*hcloud.Client, diag.Diagnostics) {
+	data, diagnostics := ConfigureProviderData(providerData)
+	return data.Client, diagnostics
 }
@@ -0,0 +1,163 @@
+package hcloudutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// ShutdownFallback is the action taken when a server did not shut down gracefully
+// within the shutdown timeout.
+type ShutdownFallback string
+
+const (
+	// ShutdownFallbackPoweroff forcefully powers off the server.
+	ShutdownFallbackPoweroff ShutdownFallback = "poweroff"
+	// ShutdownFallbackFail aborts the operation with an error.
+	ShutdownFallbackFail ShutdownFallback = "fail"
+	// ShutdownFallbackDelete leaves the server running, so it is deleted while
+	// running. It only applies to the deletion of a server, every other operation
+	// needs a stopped server and falls back to [ShutdownFallbackPoweroff].
+	ShutdownFallbackDelete ShutdownFallback = "delete"
+)
+
+// ShutdownFallbackValues lists the valid [ShutdownFallback] values.
+var ShutdownFallbackValues = []string{
+	string(ShutdownFallbackPoweroff),
+	string(ShutdownFallbackFail),
+	string(ShutdownFallbackDelete),
+}
+
+// DefaultShutdownTimeout is the time given to a server to shut down gracefully
+// when none is configured.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// serverStatusPollInterval is the interval at which the server status is read while
+// waiting for the server to reach a status.
+const serverStatusPollInterval = 2 * time.Second
+
+// ShutdownOpts configures how [StopServer] stops a server.
+type ShutdownOpts struct {
+	// Timeout is the time given to the server to shut down gracefully.
+	Timeout time.Duration
+	// Fallback is the action taken when the server did not shut down in time.
+	Fallback ShutdownFallback
+	// Deleting reports whether the server is stopped to be deleted. Without it,
+	// [ShutdownFallbackDelete] falls back to [ShutdownFallbackPoweroff].
+	Deleting bool
+	// Progress, if set, is called with a message every time the server status is
+	// polled and when the fallback is applied.
+	Progress func(message string)
+}
+
+// StopServer gracefully shuts down the server and waits until it is off. When the
+// server is still running after the timeout, the configured fallback is applied.
+//
+// It reports whether the server is off, which is only false when the server was
+// left running by [ShutdownFallbackDelete]. A warning is returned in that case.
+func StopServer(ctx context.Context, client *hcloud.Client, server *hcloud.Server, opts ShutdownOpts) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultShutdownTimeout
+	}
+	if opts.Fallback == ShutdownFallbackDelete && !opts.Deleting {
+		opts.Fallback = ShutdownFallbackPoweroff
+	}
+	if opts.Progress == nil {
+		opts.Progress = func(string) {}
+	}
+
+	result, _, err := client.Server.GetByID(ctx, server.ID)
+	if err != nil {
+		diags.Append(APIErrorDiagnostics(err)...)
+		return false, diags
+	}
+	if result == nil || result.Status == hcloud.ServerStatusOff {
+		return true, diags
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	err = shutdownAndWait(waitCtx, client, server, opts.Progress)
+	switch {
+	case err == nil:
+		return true, diags
+	case !errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil:
+		// Only the shutdown timeout triggers the fallback.
+		diags.Append(APIErrorDiagnostics(err)...)
+		return false, diags
+	}
+
+	switch opts.Fallback {
+	case ShutdownFallbackFail:
+		diags.AddError(
+			"Server did not shut down",
+			fmt.Sprintf("Server %d did not finish shutting down gracefully within %s.", server.ID, opts.Timeout),
+		)
+		return false, diags
+
+	case ShutdownFallbackDelete:
+		diags.AddWarning(
+			fmt.Sprintf("Server id %d did not finish shutting down gracefully in time, deleting it anyways.", server.ID),
+			fmt.Sprintf("The server was still running after %s.", opts.Timeout),
+		)
+		return false, diags
+
+	default:
+		opts.Progress(fmt.Sprintf("Server %d did not shut down within %s, powering it off", server.ID, opts.Timeout))
+		action, _, err := client.Server.Poweroff(ctx, server)
+		if err != nil {
+			diags.Append(APIErrorDiagnostics(err)...)
+			return false, diags
+		}
+		diags.Append(SettleActions(ctx, &client.Action, action)...)
+		return !diags.HasError(), diags
+	}
+}
+
+// shutdownAndWait requests a graceful shutdown of the server and polls its status
+// until it is off or the context is done.
+func shutdownAndWait(ctx context.Context, client *hcloud.Client, server *hcloud.Server, progress func(string)) error {
+	action, _, err := client.Server.Shutdown(ctx, server)
+	if err != nil {
+		return err
+	}
+	if err := WaitForActions(ctx, &client.Action, action); err != nil {
+		return err
+	}
+
+	return WaitForServerStatus(ctx, client, server, hcloud.ServerStatusOff, progress)
+}
+
+// WaitForServerStatus polls the status of the server until it has the given status,
+// the server no longer exists or the context is done. If progress is set, it is
+// called with a message every time the server does not have the status yet.
+func WaitForServerStatus(ctx context.Context, client *hcloud.Client, server *hcloud.Server, status hcloud.ServerStatus, progress func(message string)) error {
+	start := time.Now()
+
+	for {
+		result, _, err := client.Server.GetByID(ctx, server.ID)
+		if err != nil {
+			return err
+		}
+		if result == nil || result.Status == status {
+			return nil
+		}
+		if progress != nil {
+			progress(fmt.Sprintf("Waiting for server %d to be %s, currently %s (%s elapsed)", server.ID, status, result.Status, time.Since(start).Round(time.Second)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(serverStatusPollInterval):
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package hcloudutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/testsupport/fakeapi"
+)
+
+func TestStopServer(t *testing.T) {
+	testCases := []struct {
+		name           string
+		ignoreShutdown bool
+		opts           ShutdownOpts
+		wantStopped    bool
+		wantStatus     hcloud.ServerStatus
+		wantSeverity   diag.Severity
+		wantProgress   string
+	}{
+		{
+			name:        "shutdown",
+			opts:        ShutdownOpts{Timeout: time.Second, Fallback: ShutdownFallbackFail},
+			wantStopped: true,
+			wantStatus:  hcloud.ServerStatusOff,
+		},
+		{
+			name:           "fallback poweroff",
+			ignoreShutdown: true,
+			opts:           ShutdownOpts{Timeout: 10 * time.Millisecond, Fallback: ShutdownFallbackPoweroff},
+			wantStopped:    true,
+			wantStatus:     hcloud.ServerStatusOff,
+			wantProgress:   "did not shut down within 10ms, powering it off",
+		},
+		{
+			name:           "fallback fail",
+			ignoreShutdown: true,
+			opts:           ShutdownOpts{Timeout: 10 * time.Millisecond, Fallback: ShutdownFallbackFail},
+			wantStatus:     hcloud.ServerStatusRunning,
+			wantSeverity:   diag.SeverityError,
+			wantProgress:   "to be off, currently running",
+		},
+		{
+			name:           "fallback delete",
+			ignoreShutdown: true,
+			opts:           ShutdownOpts{Timeout: 10 * time.Millisecond, Fallback: ShutdownFallbackDelete, Deleting: true},
+			wantStatus:     hcloud.ServerStatusRunning,
+			wantSeverity:   diag.SeverityWarning,
+		},
+		{
+			name:           "fallback delete without deletion",
+			ignoreShutdown: true,
+			opts:           ShutdownOpts{Timeout: 10 * time.Millisecond, Fallback: ShutdownFallbackDelete},
+			wantStopped:    true,
+			wantStatus:     hcloud.ServerStatusOff,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []fakeapi.Option
+			if tt.ignoreShutdown {
+				opts = append(opts, fakeapi.WithShutdownIgnored())
+			}
+			api := fakeapi.New(opts...)
+			t.Cleanup(api.Close)
+
+			client := hcloud.NewClient(
+				hcloud.WithEndpoint(api.Endpoint()),
+				hcloud.WithToken("token"),
+				hcloud.WithPollOpts(hcloud.PollOpts{BackoffFunc: hcloud.ConstantBackoff(time.Millisecond)}),
+			)
+
+			result, _, err := client.Server.Create(t.Context(), hcloud.ServerCreateOpts{
+				Name:       "server",
+				ServerType: &hcloud.ServerType{Name: "cpx22"},
+				Image:      &hcloud.Image{Name: "ubuntu-24.04"},
+			})
+			require.NoError(t, err)
+			require.NoError(t, client.Action.WaitFor(t.Context(), result.Action))
+
+			var progress []string
+			tt.opts.Progress = func(message string) { progress = append(progress, message) }
+
+			stopped, diags := StopServer(t.Context(), client, result.Server, tt.opts)
+			assert.Equal(t, tt.wantStopped, stopped)
+			if tt.wantSeverity == diag.SeverityInvalid {
+				assert.Empty(t, diags)
+			} else {
+				require.Len(t, diags, 1)
+				assert.Equal(t, tt.wantSeverity, diags[0].Severity())
+			}
+
+			if tt.wantProgress != "" {
+				require.NotEmpty(t, progress)
+				assert.Contains(t, progress[len(progress)-1], tt.wantProgress)
+			}
+
+			server, _, err := client.Server.GetByID(t.Context(), result.Server.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, server.Status)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package hcloudutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenLength is the length of a valid API token.
+const TokenLength = 64
+
+// TokenPrecedence describes the order in which the token sources are used.
+const TokenPrecedence = "The token is read from the first configured source, in the following order: " +
+	"the provider token attribute, the HCLOUD_TOKEN environment variable, " +
+	"the provider token_file attribute and the provider token_command attribute."
+
+// tokenCommandTimeout is the maximum duration of the token command.
+const tokenCommandTimeout = time.Minute
+
+// ErrMissingToken is returned when no token source is configured.
+var ErrMissingToken = errors.New("the Hetzner Cloud API token was not found in any source. " + TokenPrecedence)
+
+// TokenSources holds the sources the API token can be read from.
+type TokenSources struct {
+	// Token is the value of the provider token attribute.
+	Token string
+	// Env is the value of the HCLOUD_TOKEN environment variable.
+	Env string
+	// File is the path of a file containing the token.
+	File string
+	// Command is an executable and its arguments, printing the token on its stdout.
+	Command []string
+}
+
+// ResolveToken returns the API token read from the first configured source, and the name
+// of that source. The token is validated, whatever the source it was read from.
+func ResolveToken(ctx context.Context, sources TokenSources) (token string, source string, err error) {
+	switch {
+	case sources.Token != "":
+		token, source = sources.Token, "token"
+	case sources.Env != "":
+		token, source = sources.Env, "HCLOUD_TOKEN"
+	case sources.File != "":
+		source = "token_file"
+		content, err := os.ReadFile(sources.File)
+		if err != nil {
+			return "", source, fmt.Errorf("could not read the token file: %w", err)
+		}
+		token = strings.TrimSpace(string(content))
+	case len(sources.Command) > 0:
+		source = "token_command"
+		token, err = runTokenCommand(ctx, sources.Command)
+		if err != nil {
+			return "", source, err
+		}
+	default:
+		return "", "", ErrMissingToken
+	}
+
+	if err := ValidateToken(token); err != nil {
+		return "", source, fmt.Errorf("token from %s: %w", source, err)
+	}
+
+	return token, source, nil
+}
+
+// ConfiguredTokenSources returns the names of the configured token sources.
+func ConfiguredTokenSources(sources TokenSources) []string {
+	result := make([]string, 0, 4)
+	if sources.Token != "" {
+		result = append(result, "token")
+	}
+	if sources.Env != "" {
+		result = append(result, "HCLOUD_TOKEN")
+	}
+	if sources.File != "" {
+		result = append(result, "token_file")
+	}
+	if len(sources.Command) > 0 {
+		result = append(result, "token_command")
+	}
+	return result
+}
+
+// ValidateToken validates the format of an API token.
+func ValidateToken(token string) error {
+	if len(token) != TokenLength {
+		return fmt.Errorf("entered token is invalid (must be exactly %d characters long)", TokenLength)
+	}
+	return nil
+}
+
+var tokenCommandCache = struct {
+	sync.Mutex
+	values map[string]string
+}{values: make(map[string]string)}
+
+// runTokenCommand runs the token command and returns the token printed on its stdout.
+// The token is cached for the lifetime of the process, so the command only runs once
+// per Terraform run, even though both the framework and the SDK providers are configured.
+func runTokenCommand(ctx context.Context, command []string) (string, error) {
+	key := strings.Join(command, "\x00")
+
+	tokenCommandCache.Lock()
+	defer tokenCommandCache.Unlock()
+
+	if token, ok := tokenCommandCache.values[key]; ok {
+		return token, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...) // nolint:gosec
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("token command failed: %w: %s", err, message)
+		}
+		return "", fmt.Errorf("token command failed: %w", err)
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	tokenCommandCache.values[key] = token
+
+	return token, nil
+}
@@ -0,0 +1,113 @@
+package hcloudutil
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveToken(t *testing.T) {
+	tokenA := strings.Repeat("a", TokenLength)
+	tokenB := strings.Repeat("b", TokenLength)
+	tokenC := strings.Repeat("c", TokenLength)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte(tokenC+"\n"), 0600))
+
+	testCases := []struct {
+		name       string
+		sources    TokenSources
+		wantToken  string
+		wantSource string
+		wantErr    string
+	}{
+		{
+			name:       "token",
+			sources:    TokenSources{Token: tokenA, Env: tokenB, File: tokenFile},
+			wantToken:  tokenA,
+			wantSource: "token",
+		},
+		{
+			name:       "env",
+			sources:    TokenSources{Env: tokenB, File: tokenFile},
+			wantToken:  tokenB,
+			wantSource: "HCLOUD_TOKEN",
+		},
+		{
+			name:       "file",
+			sources:    TokenSources{File: tokenFile, Command: []string{"echo", tokenA}},
+			wantToken:  tokenC,
+			wantSource: "token_file",
+		},
+		{
+			name:       "command",
+			sources:    TokenSources{Command: []string{"echo", tokenA}},
+			wantToken:  tokenA,
+			wantSource: "token_command",
+		},
+		{
+			name:       "missing file",
+			sources:    TokenSources{File: filepath.Join(t.TempDir(), "missing")},
+			wantSource: "token_file",
+			wantErr:    "could not read the token file",
+		},
+		{
+			name:       "failing command",
+			sources:    TokenSources{Command: []string{"sh", "-c", "echo 'helper failed' >&2; exit 1"}},
+			wantSource: "token_command",
+			wantErr:    "token command failed: exit status 1: helper failed",
+		},
+		{
+			name:       "invalid env",
+			sources:    TokenSources{Env: "invalid"},
+			wantSource: "HCLOUD_TOKEN",
+			wantErr:    "token from HCLOUD_TOKEN: entered token is invalid (must be exactly 64 characters long)",
+		},
+		{
+			name:    "missing",
+			sources: TokenSources{},
+			wantErr: ErrMissingToken.Error(),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			token, source, err := ResolveToken(context.Background(), tt.sources)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantToken, token)
+			assert.Equal(t, tt.wantSource, source)
+		})
+	}
+}
+
+func TestResolveTokenCommandCache(t *testing.T) {
+	counter := filepath.Join(t.TempDir(), "counter")
+	command := []string{"sh", "-c", "echo run >> " + counter + "; printf '%064d' 0"}
+
+	for range 3 {
+		token, _, err := ResolveToken(context.Background(), TokenSources{Command: command})
+		require.NoError(t, err)
+		assert.Equal(t, strings.Repeat("0", TokenLength), token)
+	}
+
+	content, err := os.ReadFile(counter)
+	require.NoError(t, err)
+	assert.Equal(t, "run\n", string(content))
+}
+
+func TestConfiguredTokenSources(t *testing.T) {
+	assert.Equal(t, []string{}, ConfiguredTokenSources(TokenSources{}))
+	assert.Equal(t,
+		[]string{"HCLOUD_TOKEN", "token_command"},
+		ConfiguredTokenSources(TokenSources{Env: "env", Command: []string{"echo"}}),
+	)
+}
@@ -0,0 +1,41 @@
+package resourceutil
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
+)
+
+// ModifyPlanDefaultLocation plans the provider default location of a resource being
+// created, see [hcloudutil.DefaultsConfig.Placement].
+func ModifyPlanDefaultLocation(ctx context.Context, config hcloudutil.DefaultsConfig, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, location, datacenter string, exclusive ...string) {
+	// Defaults only apply to new resources, to never replace an existing resource.
+	if !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	attribute, value := config.Placement(location, datacenter, exclusive, func(name string) bool {
+		return IsConfigured(req.Config.Raw, name)
+	})
+	if attribute == "" {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attribute), types.StringValue(value))...)
+}
+
+// IsConfigured reports whether a root attribute is set in the configuration. Unknown
+// values are considered set.
+func IsConfigured(config tftypes.Value, name string) bool {
+	value, _, err := tftypes.WalkAttributePath(config, tftypes.NewAttributePath().WithAttributeName(name))
+	if err != nil {
+		return false
+	}
+	v, ok := value.(tftypes.Value)
+	return ok && !v.IsNull()
+}
@@ -5,12 +5,15 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
 )
 
 var _ validator.Map = (*labelsValidator)(nil)
@@ -39,6 +42,11 @@ func (l labelsValidator) ValidateMap(_ context.Context, req validator.MapRequest
 	}
 }
 
+// LabelsValidator returns a validator ensuring that labels conform to the labels format.
+func LabelsValidator() validator.Map {
+	return labelsValidator{}
+}
+
 // LabelsSchema returns a map attribute schema with validation for the labels field shared by multiple resources.
 func LabelsSchema() schema.MapAttribute {
 	return schema.MapAttribute{
@@ -50,12 +58,111 @@ func LabelsSchema() schema.MapAttribute {
 		// To avoid a data consistency issue, we set the default value to the empty object.
 		Default: mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
 		Validators: []validator.Map{
-			labelsValidator{},
+			LabelsValidator(),
 		},
 	}
 }
 
 // LabelsMapValueFrom prepare the labels from the API to be assigned into the resource model.
-func LabelsMapValueFrom(ctx context.Context, in map[string]string) (types.Map, diag.Diagnostics) {
+// The labels ignored by the provider are filtered out.
+func LabelsMapValueFrom(ctx context.Context, config hcloudutil.LabelsConfig, in map[string]string) (types.Map, diag.Diagnostics) {
+	if in != nil {
+		in = config.Filter(nil, in)
+	}
 	return types.MapValueFrom(ctx, types.StringType, in)
 }
+
+// LabelsAllSchema returns a computed map attribute schema for the labels_all field, holding the
+// labels of the resource merged with the provider default labels.
+func LabelsAllSchema() schema.MapAttribute {
+	return schema.MapAttribute{
+		MarkdownDescription: "All labels of the resource, including the labels inherited from the provider `default_labels`.",
+		Computed:            true,
+		ElementType:         types.StringType,
+	}
+}
+
+// ModifyPlanLabelsAll plans the labels_all attribute, by merging the provider default
+// labels with the planned labels of the resource.
+func ModifyPlanLabelsAll(ctx context.Context, config hcloudutil.LabelsConfig, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var labels types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("labels"), &labels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !isFullyKnown(labels) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("labels_all"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	var hcLabels map[string]string
+	resp.Diagnostics.Append(hcloudutil.TerraformLabelsToHCloud(ctx, labels, &hcLabels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	labelsAll, diags := types.MapValueFrom(ctx, types.StringType, config.All(hcLabels))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("labels_all"), labelsAll)...)
+}
+
+// LabelsAllFromAPI prepare the labels and labels_all values from the API to be assigned into
+// the resource model. The configured labels are used to decide whether a default or an
+// ignored label must be kept in the resource labels.
+func LabelsAllFromAPI(ctx context.Context, config hcloudutil.LabelsConfig, configured types.Map, in map[string]string, outputLabels, outputLabelsAll *types.Map) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var newDiags diag.Diagnostics
+
+	var configuredLabels map[string]string
+	diags.Append(hcloudutil.TerraformLabelsToHCloud(ctx, configured, &configuredLabels)...)
+
+	*outputLabels, newDiags = types.MapValueFrom(ctx, types.StringType, config.Resource(configuredLabels, in))
+	diags.Append(newDiags...)
+
+	*outputLabelsAll, newDiags = types.MapValueFrom(ctx, types.StringType, config.Filter(configuredLabels, in))
+	diags.Append(newDiags...)
+
+	return diags
+}
+
+// LabelsForUpdate prepare the labels_all value from the plan to be sent to the API in an
+// update request. The remote function must return the labels currently set on the
+// resource, it is only called when the provider ignores some labels, to preserve them.
+func LabelsForUpdate(ctx context.Context, config hcloudutil.LabelsConfig, labelsAll types.Map, remote func() (map[string]string, error)) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var labels map[string]string
+	diags.Append(hcloudutil.TerraformLabelsToHCloud(ctx, labelsAll, &labels)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	labels, err := config.Update(labels, remote)
+	if err != nil {
+		diags.Append(hcloudutil.APIErrorDiagnostics(err)...)
+	}
+
+	return labels, diags
+}
+
+func isFullyKnown(value types.Map) bool {
+	if value.IsUnknown() {
+		return false
+	}
+	for _, element := range value.Elements() {
+		if element.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
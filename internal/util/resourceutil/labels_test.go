@@ -12,6 +12,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
 )
 
 func TestLabelsValidator_ValidateMap(t *testing.T) {
@@ -82,10 +84,11 @@ func TestLabelsValidator_ValidateMap(t *testing.T) {
 
 func TestLabelsMapValueFrom(t *testing.T) {
 	tests := []struct {
-		name  string
-		in    map[string]string
-		want  types.Map
-		diags diag.Diagnostics
+		name   string
+		config hcloudutil.LabelsConfig
+		in     map[string]string
+		want   types.Map
+		diags  diag.Diagnostics
 	}{
 		{
 			name:  "Map with Labels",
@@ -93,6 +96,13 @@ func TestLabelsMapValueFrom(t *testing.T) {
 			want:  types.MapValueMust(types.StringType, map[string]attr.Value{"foo": types.StringValue("bar")}),
 			diags: nil,
 		},
+		{
+			name:   "Map with ignored Labels",
+			config: hcloudutil.LabelsConfig{IgnoreKeys: []string{"ignored"}, IgnoreKeyPrefixes: []string{"csi.hetzner.cloud/"}},
+			in:     map[string]string{"foo": "bar", "ignored": "value", "csi.hetzner.cloud/volume": "value"},
+			want:   types.MapValueMust(types.StringType, map[string]attr.Value{"foo": types.StringValue("bar")}),
+			diags:  nil,
+		},
 		{
 			name:  "Empty Map",
 			in:    map[string]string{},
@@ -102,7 +112,7 @@ func TestLabelsMapValueFrom(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			labels, diags := LabelsMapValueFrom(context.Background(), tt.in)
+			labels, diags := LabelsMapValueFrom(context.Background(), tt.config, tt.in)
 			if !reflect.DeepEqual(labels, tt.want) {
 				t.Errorf("LabelsMapValueFrom() got = %v, want %v", labels, tt.want)
 			}
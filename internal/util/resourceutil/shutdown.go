@@ -0,0 +1,69 @@
+package resourceutil
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/validateutil"
+)
+
+// ShutdownTimeoutSchema returns the schema of the shutdown_timeout field shared by
+// the resources stopping servers.
+func ShutdownTimeoutSchema() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Time given to the Server to shut down gracefully before `shutdown_fallback` is applied, e.g. `30s` or `5m`.",
+		Optional:            true,
+		Computed:            true,
+		Default:             stringdefault.StaticString(hcloudutil.DefaultShutdownTimeout.String()),
+		Validators: []validator.String{
+			validateutil.Duration(),
+		},
+	}
+}
+
+// ShutdownFallbackSchema returns the schema of the shutdown_fallback field shared by
+// the resources stopping servers.
+func ShutdownFallbackSchema() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Action taken when the Server did not shut down within `shutdown_timeout`: `poweroff` forcefully powers it off, `fail` aborts the operation, `delete` deletes the Server while it is still running. Operations other than deleting the Server fall back to `poweroff` instead of `delete`.",
+		Optional:            true,
+		Computed:            true,
+		Default:             stringdefault.StaticString(string(hcloudutil.ShutdownFallbackPoweroff)),
+		Validators: []validator.String{
+			stringvalidator.OneOf(hcloudutil.ShutdownFallbackValues...),
+		},
+	}
+}
+
+// ShutdownOptsFrom returns the [hcloudutil.ShutdownOpts] configured by the
+// shutdown_timeout and shutdown_fallback fields. Null values use the defaults.
+func ShutdownOptsFrom(timeout, fallback types.String) (hcloudutil.ShutdownOpts, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	opts := hcloudutil.ShutdownOpts{
+		Timeout:  hcloudutil.DefaultShutdownTimeout,
+		Fallback: hcloudutil.ShutdownFallbackPoweroff,
+	}
+
+	if timeout.ValueString() != "" {
+		value, err := time.ParseDuration(timeout.ValueString())
+		if err != nil {
+			diags.AddError("Invalid shutdown timeout", fmt.Sprintf("Value is not a valid duration: %s", err))
+			return opts, diags
+		}
+		opts.Timeout = value
+	}
+	if fallback.ValueString() != "" {
+		opts.Fallback = hcloudutil.ShutdownFallback(fallback.ValueString())
+	}
+
+	return opts, diags
+}
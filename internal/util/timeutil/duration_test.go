@@ -11,4 +11,5 @@ func TestDurationFromSeconds(t *testing.T) {
 	assert.Equal(t, time.Second, DurationFromSeconds(1))
 	assert.Equal(t, 2*time.Second, DurationFromSeconds(int32(2)))
 	assert.Equal(t, 2*time.Second, DurationFromSeconds(int64(2)))
+	assert.Equal(t, 1500*time.Millisecond, DurationFromSeconds(1.5))
 }
@@ -0,0 +1,14 @@
+package timeutil
+
+import "time"
+
+// ParseRFC3339 parses a timestamp in the RFC3339 format used by the API and the
+// Terraform attributes.
+func ParseRFC3339(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339, value)
+}
+
+// FormatRFC3339 formats the time in the RFC3339 format, in UTC.
+func FormatRFC3339(value time.Time) string {
+	return value.UTC().Format(time.RFC3339)
+}
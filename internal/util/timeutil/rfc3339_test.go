@@ -0,0 +1,21 @@
+package timeutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/timeutil"
+)
+
+func TestRFC3339(t *testing.T) {
+	value, err := timeutil.ParseRFC3339("2025-01-02T14:04:05+02:00")
+	require.NoError(t, err)
+	assert.True(t, value.Equal(time.Date(2025, 1, 2, 12, 4, 5, 0, time.UTC)))
+	assert.Equal(t, "2025-01-02T12:04:05Z", timeutil.FormatRFC3339(value))
+
+	_, err = timeutil.ParseRFC3339("2025-01-02 12:04:05")
+	assert.Error(t, err)
+}
@@ -0,0 +1,248 @@
+package traceutil
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Names of the traced Terraform RPCs.
+const (
+	RPCCreate = "Create"
+	RPCRead   = "Read"
+	RPCUpdate = "Update"
+	RPCDelete = "Delete"
+	RPCImport = "Import"
+	RPCInvoke = "Invoke"
+)
+
+// fullProviderServer is a [tfprotov6.ProviderServer] implementing every optional RPC.
+type fullProviderServer interface {
+	tfprotov6.ProviderServerWithActions
+	tfprotov6.ProviderServerWithListResource
+	tfprotov6.ProviderServerWithStateStores
+}
+
+// providerServer creates a span for every Terraform RPC operating on a resource, a data
+// source or an action. The other RPCs are passed to the wrapped server untouched.
+type providerServer struct {
+	fullProviderServer
+
+	schemasOnce sync.Once
+	schemas     map[string]tftypes.Type
+}
+
+// NewProviderServer wraps a [tfprotov6.ProviderServer], to trace the Terraform RPCs
+// creating, reading, updating, deleting or importing resources, reading data sources
+// and invoking actions.
+//
+// The spans are named after the resource type and the RPC, e.g. "hcloud_server Create",
+// and hold the ID of the resource when it is known. Servers not implementing every
+// optional RPC, e.g. actions, are returned untouched.
+func NewProviderServer(server tfprotov6.ProviderServer) tfprotov6.ProviderServer {
+	full, ok := server.(fullProviderServer)
+	if !ok {
+		return server
+	}
+	return &providerServer{fullProviderServer: full}
+}
+
+func (s *providerServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	rpc := RPCUpdate
+	if isNull(req.PriorState) {
+		rpc = RPCCreate
+	} else if isNull(req.PlannedState) {
+		rpc = RPCDelete
+	}
+
+	ctx, span := startRPC(ctx, rpc, req.TypeName)
+	if rpc != RPCCreate {
+		s.setResourceID(ctx, span, req.TypeName, req.PriorState)
+	}
+
+	resp, err := s.fullProviderServer.ApplyResourceChange(ctx, req)
+	if resp != nil {
+		if rpc == RPCCreate {
+			s.setResourceID(ctx, span, req.TypeName, resp.NewState)
+		}
+		endRPC(span, err, resp.Diagnostics)
+	} else {
+		endRPC(span, err, nil)
+	}
+	return resp, err
+}
+
+func (s *providerServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	ctx, span := startRPC(ctx, RPCRead, req.TypeName)
+	s.setResourceID(ctx, span, req.TypeName, req.CurrentState)
+
+	resp, err := s.fullProviderServer.ReadResource(ctx, req)
+	if resp != nil {
+		endRPC(span, err, resp.Diagnostics)
+	} else {
+		endRPC(span, err, nil)
+	}
+	return resp, err
+}
+
+func (s *providerServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	ctx, span := startRPC(ctx, RPCImport, req.TypeName)
+	if req.ID != "" {
+		span.SetAttributes(ResourceIDKey.String(req.ID))
+	}
+
+	resp, err := s.fullProviderServer.ImportResourceState(ctx, req)
+	if resp != nil {
+		endRPC(span, err, resp.Diagnostics)
+	} else {
+		endRPC(span, err, nil)
+	}
+	return resp, err
+}
+
+func (s *providerServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	ctx, span := startRPC(ctx, RPCRead, req.TypeName)
+
+	resp, err := s.fullProviderServer.ReadDataSource(ctx, req)
+	if resp != nil {
+		endRPC(span, err, resp.Diagnostics)
+	} else {
+		endRPC(span, err, nil)
+	}
+	return resp, err
+}
+
+func (s *providerServer) InvokeAction(ctx context.Context, req *tfprotov6.InvokeActionRequest) (*tfprotov6.InvokeActionServerStream, error) {
+	ctx, span := startRPC(ctx, RPCInvoke, req.ActionType)
+
+	resp, err := s.fullProviderServer.InvokeAction(ctx, req)
+	if err != nil || resp == nil || resp.Events == nil {
+		endRPC(span, err, nil)
+		return resp, err
+	}
+
+	// The action is invoked while its events are streamed, the span ends once the
+	// completed event was sent, or the stream was interrupted.
+	events := resp.Events
+	resp.Events = func(yield func(tfprotov6.InvokeActionEvent) bool) {
+		var diagnostics []*tfprotov6.Diagnostic
+		defer func() { endRPC(span, nil, diagnostics) }()
+
+		for event := range events {
+			if completed, ok := event.Type.(tfprotov6.CompletedInvokeActionEventType); ok {
+				diagnostics = completed.Diagnostics
+			}
+			if !yield(event) {
+				return
+			}
+		}
+	}
+	return resp, nil
+}
+
+func startRPC(ctx context.Context, rpc, typeName string) (context.Context, trace.Span) {
+	return Start(ctx, typeName+" "+rpc,
+		RPCKey.String(rpc),
+		ResourceTypeKey.String(typeName),
+	)
+}
+
+func endRPC(span trace.Span, err error, diagnostics []*tfprotov6.Diagnostic) {
+	if err == nil {
+		summaries := make([]string, 0, len(diagnostics))
+		for _, diagnostic := range diagnostics {
+			if diagnostic != nil && diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+				summaries = append(summaries, diagnostic.Summary)
+			}
+		}
+		if len(summaries) > 0 {
+			span.SetStatus(codes.Error, strings.Join(summaries, "; "))
+		}
+	}
+	End(span, err)
+}
+
+// setResourceID sets the ID found in the state of the resource on the span.
+func (s *providerServer) setResourceID(ctx context.Context, span trace.Span, typeName string, state *tfprotov6.DynamicValue) {
+	if state == nil || !span.IsRecording() {
+		return
+	}
+
+	typ, ok := s.schemaType(ctx, typeName)
+	if !ok {
+		return
+	}
+
+	value, err := state.Unmarshal(typ)
+	if err != nil {
+		return
+	}
+
+	if id := stateID(value); id != "" {
+		span.SetAttributes(ResourceIDKey.String(id))
+	}
+}
+
+// schemaType returns the type of the state of a resource. The schemas are only fetched
+// once, the first time a resource ID is needed.
+func (s *providerServer) schemaType(ctx context.Context, typeName string) (tftypes.Type, bool) {
+	s.schemasOnce.Do(func() {
+		s.schemas = make(map[string]tftypes.Type)
+
+		resp, err := s.fullProviderServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+		if err != nil || resp == nil {
+			return
+		}
+		for name, schema := range resp.ResourceSchemas {
+			s.schemas[name] = schema.ValueType()
+		}
+	})
+
+	typ, ok := s.schemas[typeName]
+	return typ, ok
+}
+
+// stateID returns the value of the "id" attribute of a state, formatted as a string.
+func stateID(value tftypes.Value) string {
+	if !value.IsKnown() || value.IsNull() || !value.Type().Is(tftypes.Object{}) {
+		return ""
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		return ""
+	}
+
+	id, ok := attributes["id"]
+	if !ok || !id.IsKnown() || id.IsNull() {
+		return ""
+	}
+
+	switch {
+	case id.Type().Is(tftypes.String):
+		var result string
+		if err := id.As(&result); err == nil {
+			return result
+		}
+	case id.Type().Is(tftypes.Number):
+		var result big.Float
+		if err := id.As(&result); err == nil {
+			return result.Text('f', -1)
+		}
+	}
+	return ""
+}
+
+func isNull(value *tfprotov6.DynamicValue) bool {
+	if value == nil {
+		return true
+	}
+	null, err := value.IsNull()
+	return err == nil && null
+}
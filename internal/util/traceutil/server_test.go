@@ -0,0 +1,235 @@
+package traceutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var testResourceType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"id":   tftypes.String,
+	"name": tftypes.String,
+}}
+
+func testResourceState(t *testing.T, id string) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	var value tftypes.Value
+	if id == "" {
+		value = tftypes.NewValue(testResourceType, nil)
+	} else {
+		value = tftypes.NewValue(testResourceType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, id),
+			"name": tftypes.NewValue(tftypes.String, "test"),
+		})
+	}
+
+	state, err := tfprotov6.NewDynamicValue(testResourceType, value)
+	require.NoError(t, err)
+	return &state
+}
+
+// mockProviderServer implements the RPCs used by the tests, and records whether the
+// context passed to them contains a span.
+type mockProviderServer struct {
+	fullProviderServer
+
+	t           *testing.T
+	diagnostics []*tfprotov6.Diagnostic
+	spanCtx     trace.SpanContext
+}
+
+func (s *mockProviderServer) GetProviderSchema(_ context.Context, _ *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	return &tfprotov6.GetProviderSchemaResponse{
+		ResourceSchemas: map[string]*tfprotov6.Schema{
+			"hcloud_test": {Block: &tfprotov6.SchemaBlock{Attributes: []*tfprotov6.SchemaAttribute{
+				{Name: "id", Type: tftypes.String, Computed: true},
+				{Name: "name", Type: tftypes.String, Required: true},
+			}}},
+		},
+	}, nil
+}
+
+func (s *mockProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	s.spanCtx = trace.SpanContextFromContext(ctx)
+
+	newState := req.PlannedState
+	if null, _ := req.PriorState.IsNull(); null {
+		newState = testResourceState(s.t, "42")
+	}
+	return &tfprotov6.ApplyResourceChangeResponse{NewState: newState, Diagnostics: s.diagnostics}, nil
+}
+
+func (s *mockProviderServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	s.spanCtx = trace.SpanContextFromContext(ctx)
+	return &tfprotov6.ReadResourceResponse{NewState: req.CurrentState, Diagnostics: s.diagnostics}, nil
+}
+
+func (s *mockProviderServer) InvokeAction(ctx context.Context, _ *tfprotov6.InvokeActionRequest) (*tfprotov6.InvokeActionServerStream, error) {
+	return &tfprotov6.InvokeActionServerStream{
+		Events: func(yield func(tfprotov6.InvokeActionEvent) bool) {
+			s.spanCtx = trace.SpanContextFromContext(ctx)
+			if !yield(tfprotov6.InvokeActionEvent{Type: tfprotov6.ProgressInvokeActionEventType{Message: "progress"}}) {
+				return
+			}
+			yield(tfprotov6.InvokeActionEvent{Type: tfprotov6.CompletedInvokeActionEventType{Diagnostics: s.diagnostics}})
+		},
+	}, nil
+}
+
+func spanAttribute(attributes []attribute.KeyValue, key attribute.Key) string {
+	for _, kv := range attributes {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestProviderServerApplyResourceChange(t *testing.T) {
+	testCases := []struct {
+		name         string
+		prior        string
+		planned      string
+		wantName     string
+		wantID       string
+		wantRPC      string
+		wantErrorMsg string
+		diagnostics  []*tfprotov6.Diagnostic
+	}{
+		{
+			name:     "create",
+			planned:  "unknown",
+			wantName: "hcloud_test Create",
+			wantID:   "42",
+			wantRPC:  RPCCreate,
+		},
+		{
+			name:     "update",
+			prior:    "42",
+			planned:  "42",
+			wantName: "hcloud_test Update",
+			wantID:   "42",
+			wantRPC:  RPCUpdate,
+		},
+		{
+			name:     "delete",
+			prior:    "42",
+			wantName: "hcloud_test Delete",
+			wantID:   "42",
+			wantRPC:  RPCDelete,
+		},
+		{
+			name:     "error",
+			prior:    "42",
+			planned:  "42",
+			wantName: "hcloud_test Update",
+			wantID:   "42",
+			wantRPC:  RPCUpdate,
+			diagnostics: []*tfprotov6.Diagnostic{
+				{Severity: tfprotov6.DiagnosticSeverityWarning, Summary: "Warning"},
+				{Severity: tfprotov6.DiagnosticSeverityError, Summary: "API error"},
+			},
+			wantErrorMsg: "API error",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := NewInMemoryExporter(t)
+
+			mock := &mockProviderServer{t: t, diagnostics: tt.diagnostics}
+			server := NewProviderServer(mock)
+
+			_, err := server.ApplyResourceChange(t.Context(), &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "hcloud_test",
+				PriorState:   testResourceState(t, tt.prior),
+				PlannedState: testResourceState(t, tt.planned),
+			})
+			require.NoError(t, err)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			span := spans[0]
+
+			assert.Equal(t, tt.wantName, span.Name)
+			assert.Equal(t, tt.wantRPC, spanAttribute(span.Attributes, RPCKey))
+			assert.Equal(t, "hcloud_test", spanAttribute(span.Attributes, ResourceTypeKey))
+			assert.Equal(t, tt.wantID, spanAttribute(span.Attributes, ResourceIDKey))
+			assert.Equal(t, span.SpanContext, mock.spanCtx)
+			if tt.wantErrorMsg != "" {
+				assert.Equal(t, codes.Error, span.Status.Code)
+				assert.Equal(t, tt.wantErrorMsg, span.Status.Description)
+			} else {
+				assert.Equal(t, codes.Unset, span.Status.Code)
+			}
+		})
+	}
+}
+
+func TestProviderServerReadResource(t *testing.T) {
+	exporter := NewInMemoryExporter(t)
+
+	mock := &mockProviderServer{t: t}
+	server := NewProviderServer(mock)
+
+	_, err := server.ReadResource(t.Context(), &tfprotov6.ReadResourceRequest{
+		TypeName:     "hcloud_test",
+		CurrentState: testResourceState(t, "42"),
+	})
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "hcloud_test Read", spans[0].Name)
+	assert.Equal(t, "42", spanAttribute(spans[0].Attributes, ResourceIDKey))
+	assert.Equal(t, spans[0].SpanContext, mock.spanCtx)
+}
+
+func TestProviderServerInvokeAction(t *testing.T) {
+	exporter := NewInMemoryExporter(t)
+
+	mock := &mockProviderServer{t: t, diagnostics: []*tfprotov6.Diagnostic{
+		{Severity: tfprotov6.DiagnosticSeverityError, Summary: "Action failed"},
+	}}
+	server := NewProviderServer(mock).(tfprotov6.ProviderServerWithActions)
+
+	stream, err := server.InvokeAction(t.Context(), &tfprotov6.InvokeActionRequest{ActionType: "hcloud_test"})
+	require.NoError(t, err)
+
+	// The span ends once the events were streamed.
+	assert.Empty(t, exporter.GetSpans())
+
+	events := 0
+	for range stream.Events {
+		events++
+	}
+	assert.Equal(t, 2, events)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "hcloud_test Invoke", spans[0].Name)
+	assert.Equal(t, RPCInvoke, spanAttribute(spans[0].Attributes, RPCKey))
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "Action failed", spans[0].Status.Description)
+	assert.Equal(t, spans[0].SpanContext, mock.spanCtx)
+}
+
+func TestStateID(t *testing.T) {
+	typ := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.Number}}
+
+	assert.Equal(t, "42", stateID(tftypes.NewValue(typ, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.Number, 42),
+	})))
+	assert.Empty(t, stateID(tftypes.NewValue(typ, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+	})))
+	assert.Empty(t, stateID(tftypes.NewValue(typ, nil)))
+}
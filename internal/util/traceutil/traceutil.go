@@ -0,0 +1,172 @@
+// Package traceutil exports the traces of the provider using OpenTelemetry.
+//
+// Tracing is disabled by default, and is configured using the standard OTEL_*
+// environment variables:
+//
+//   - OTEL_TRACES_EXPORTER selects the exporter: "otlp" sends the traces to an OTLP/HTTP
+//     endpoint configured with the OTEL_EXPORTER_OTLP_* environment variables, "file"
+//     appends the traces as JSON to the file configured with OTEL_EXPORTER_FILE_PATH.
+//   - OTEL_SDK_DISABLED disables the tracing, whatever the exporter.
+//   - OTEL_SERVICE_NAME, OTEL_RESOURCE_ATTRIBUTES, OTEL_TRACES_SAMPLER and OTEL_BSP_* are
+//     handled by the OpenTelemetry SDK.
+package traceutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer creating the spans of the provider.
+const TracerName = "github.com/hetznercloud/terraform-provider-hcloud"
+
+// ServiceName is the default service name of the exported traces.
+const ServiceName = "terraform-provider-hcloud"
+
+const (
+	// ExporterOTLP sends the traces to an OTLP/HTTP endpoint.
+	ExporterOTLP = "otlp"
+	// ExporterFile appends the traces as JSON to a file.
+	ExporterFile = "file"
+	// ExporterNone disables the tracing.
+	ExporterNone = "none"
+)
+
+const (
+	envSDKDisabled    = "OTEL_SDK_DISABLED"
+	envTracesExporter = "OTEL_TRACES_EXPORTER"
+	envFilePath       = "OTEL_EXPORTER_FILE_PATH"
+)
+
+// Attribute keys describing the Terraform resources and the API objects.
+const (
+	ResourceTypeKey    = attribute.Key("terraform.resource.type")
+	ResourceIDKey      = attribute.Key("terraform.resource.id")
+	RPCKey             = attribute.Key("terraform.rpc")
+	ActionIDsKey       = attribute.Key("hcloud.action.ids")
+	ActionCmdsKey      = attribute.Key("hcloud.action.commands")
+	ActionResourcesKey = attribute.Key("hcloud.action.resources")
+	ErrorCodeKey       = attribute.Key("hcloud.error.code")
+)
+
+// Tracer returns the tracer creating the spans of the provider, using the global
+// [trace.TracerProvider]. When tracing is disabled, the spans are not recorded.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Start creates a span and a context containing the span.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error on the span, if any, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Setup configures the global [trace.TracerProvider] from the OTEL_* environment variables.
+//
+// The returned function flushes the pending spans and shuts the tracer provider down, it
+// must be called before the process exits. When tracing is disabled, the global tracer
+// provider is left untouched and the returned function does nothing.
+func Setup(ctx context.Context, version string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	exporter, err := newExporter(ctx)
+	if err != nil || exporter == nil {
+		return noop, err
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(ServiceName),
+			semconv.ServiceVersion(version),
+		),
+	)
+	if err != nil {
+		return noop, fmt.Errorf("could not create the trace resource: %w", err)
+	}
+	// Attributes from OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES take precedence.
+	if fromEnv, err := resource.New(ctx, resource.WithFromEnv()); err == nil {
+		if merged, err := resource.Merge(res, fromEnv); err == nil {
+			res = merged
+		}
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// newExporter returns the span exporter configured with the OTEL_* environment
+// variables, or nil if tracing is disabled.
+func newExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	if disabled := strings.ToLower(strings.TrimSpace(os.Getenv(envSDKDisabled))); disabled == "true" {
+		return nil, nil
+	}
+
+	switch exporter := strings.ToLower(strings.TrimSpace(os.Getenv(envTracesExporter))); exporter {
+	case "", ExporterNone:
+		return nil, nil
+
+	case ExporterOTLP:
+		result, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not create the OTLP trace exporter: %w", err)
+		}
+		return result, nil
+
+	case ExporterFile:
+		path := os.Getenv(envFilePath)
+		if path == "" {
+			return nil, fmt.Errorf("%s must be set when %s is %q", envFilePath, envTracesExporter, ExporterFile)
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("could not open the trace file: %w", err)
+		}
+		result, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("could not create the file trace exporter: %w", err), file.Close())
+		}
+		return &fileExporter{SpanExporter: result, file: file}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported %s %q, must be one of %q, %q or %q",
+			envTracesExporter, exporter, ExporterOTLP, ExporterFile, ExporterNone)
+	}
+}
+
+// fileExporter closes the trace file when the exporter is shut down.
+type fileExporter struct {
+	sdktrace.SpanExporter
+	file *os.File
+}
+
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.SpanExporter.Shutdown(ctx), e.file.Close())
+}
@@ -0,0 +1,85 @@
+package traceutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		t.Setenv("OTEL_TRACES_EXPORTER", "")
+
+		shutdown, err := Setup(t.Context(), "1.0.0")
+		require.NoError(t, err)
+		assert.NoError(t, shutdown(t.Context()))
+	})
+
+	t.Run("sdk disabled", func(t *testing.T) {
+		t.Setenv("OTEL_TRACES_EXPORTER", "file")
+		t.Setenv("OTEL_SDK_DISABLED", "true")
+
+		shutdown, err := Setup(t.Context(), "1.0.0")
+		require.NoError(t, err)
+		assert.NoError(t, shutdown(t.Context()))
+	})
+
+	t.Run("unsupported exporter", func(t *testing.T) {
+		t.Setenv("OTEL_TRACES_EXPORTER", "zipkin")
+
+		_, err := Setup(t.Context(), "1.0.0")
+		assert.EqualError(t, err, `unsupported OTEL_TRACES_EXPORTER "zipkin", must be one of "otlp", "file" or "none"`)
+	})
+
+	t.Run("file without path", func(t *testing.T) {
+		t.Setenv("OTEL_TRACES_EXPORTER", "file")
+		t.Setenv("OTEL_EXPORTER_FILE_PATH", "")
+
+		_, err := Setup(t.Context(), "1.0.0")
+		assert.EqualError(t, err, `OTEL_EXPORTER_FILE_PATH must be set when OTEL_TRACES_EXPORTER is "file"`)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		// Restore the global tracer provider once the test is done.
+		NewInMemoryExporter(t)
+
+		path := filepath.Join(t.TempDir(), "traces.json")
+		t.Setenv("OTEL_TRACES_EXPORTER", "file")
+		t.Setenv("OTEL_EXPORTER_FILE_PATH", path)
+
+		shutdown, err := Setup(t.Context(), "1.0.0")
+		require.NoError(t, err)
+
+		_, span := Start(t.Context(), "test")
+		End(span, errors.New("failed"))
+
+		require.NoError(t, shutdown(t.Context()))
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `"Name":"test"`)
+		assert.Contains(t, string(content), `"Value":"terraform-provider-hcloud"`)
+		assert.Contains(t, string(content), `"Description":"failed"`)
+	})
+}
+
+func TestEnd(t *testing.T) {
+	exporter := NewInMemoryExporter(t)
+
+	_, span := Start(t.Context(), "success")
+	End(span, nil)
+	_, span = Start(t.Context(), "failure")
+	End(span, errors.New("failed"))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, "failed", spans[1].Status.Description)
+	assert.Len(t, spans[1].Events, 1)
+}
@@ -0,0 +1,65 @@
+package transportutil
+
+import (
+	"net/http"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// Config holds the settings of the HTTP client used to send requests to the API.
+type Config struct {
+	// MaxRequestsPerSecond limits the number of requests sent per second, zero means unlimited.
+	MaxRequestsPerSecond float64
+	// MaxConcurrentRequests limits the number of requests in flight, zero means unlimited.
+	MaxConcurrentRequests int
+	// Retry is the retry policy of the requests.
+	Retry RetryConfig
+	// ReadOnly rejects every request that could mutate a resource.
+	ReadOnly bool
+	// TLS holds the TLS settings of the connections to the API.
+	TLS TLSConfig
+	// HTTPProxy is the URL of the proxy the requests are sent through. When empty, the
+	// proxy is read from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+	HTTPProxy string
+}
+
+// NewHTTPClient creates the HTTP client used to send requests to the API.
+//
+// The requests of every client are throttled using the [SharedLimiter], and retried
+// according to the retry policy. In read-only mode, the requests that could mutate a
+// resource are rejected before being throttled. Every request is traced in its own span.
+//
+// An error is returned when the TLS or proxy settings are invalid.
+func NewHTTPClient(config Config) (*http.Client, error) {
+	base, err := newBaseTransport(config)
+	if err != nil {
+		return nil, err
+	}
+
+	limiter := SharedLimiter()
+	limiter.SetLimits(config.MaxRequestsPerSecond, config.MaxConcurrentRequests)
+
+	var transport http.RoundTripper = base
+	transport = NewLimiterTransport(limiter, transport)
+	transport = NewRetryTransport(config.Retry, transport)
+	if config.ReadOnly {
+		transport = NewReadOnlyTransport(transport)
+	}
+	transport = NewTracingTransport(transport)
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// ClientOptions returns the [hcloud.ClientOption] to send the requests using the HTTP
+// client created from the config. The retry handler of the hcloud client is disabled,
+// the requests are retried by the HTTP client instead.
+func ClientOptions(config Config) ([]hcloud.ClientOption, error) {
+	httpClient, err := NewHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return []hcloud.ClientOption{
+		hcloud.WithHTTPClient(httpClient),
+		hcloud.WithRetryOpts(hcloud.RetryOpts{MaxRetries: 0}),
+	}, nil
+}
@@ -0,0 +1,207 @@
+package transportutil
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// lowBudgetRatio is the ratio of the rate limit budget below which requests are slowed down.
+const lowBudgetRatio = 0.1
+
+var (
+	sharedLimiter     *Limiter
+	sharedLimiterOnce sync.Once
+)
+
+// SharedLimiter returns the process-wide [Limiter], shared by the HTTP clients of both
+// the framework and the SDK providers, so they consume a single request budget.
+func SharedLimiter() *Limiter {
+	sharedLimiterOnce.Do(func() {
+		sharedLimiter = NewLimiter()
+	})
+	return sharedLimiter
+}
+
+// Limiter throttles the requests sent to the API.
+//
+// The number of requests per second and of concurrent requests may be limited using
+// [Limiter.SetLimits]. In addition, the rate limit headers returned by the API are used
+// to slow down the requests before the rate limit budget is exhausted.
+type Limiter struct {
+	mu sync.Mutex
+
+	// interval is the minimum interval between two requests.
+	interval time.Duration
+	// slots holds one item per request in flight, nil means unlimited.
+	slots chan struct{}
+	// next is the earliest time at which the next request may be sent.
+	next time.Time
+
+	// Rate limit budget, as reported by the API.
+	limit     int
+	remaining int
+	reset     time.Time
+}
+
+// NewLimiter creates a new [Limiter] without any limits.
+func NewLimiter() *Limiter {
+	return &Limiter{}
+}
+
+// SetLimits configures the maximum number of requests per second and of concurrent
+// requests. A value lower or equal to zero disables the limit.
+func (l *Limiter) SetLimits(maxRequestsPerSecond float64, maxConcurrentRequests int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.interval = 0
+	if maxRequestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / maxRequestsPerSecond)
+	}
+
+	switch {
+	case maxConcurrentRequests <= 0:
+		l.slots = nil
+	case l.slots == nil || cap(l.slots) != maxConcurrentRequests:
+		// Requests in flight release the slot they acquired, the new slots are only
+		// used by the next requests.
+		l.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+}
+
+// Wait blocks until a request may be sent, or the context is done. The returned
+// function must be called once the request completed, to release its slot.
+func (l *Limiter) Wait(ctx context.Context) (func(), error) {
+	l.mu.Lock()
+	slots := l.slots
+	l.mu.Unlock()
+
+	release := func() {}
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+			release = func() { <-slots }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	wait := l.reserve(time.Now())
+	if wait <= 0 {
+		return release, nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return release, nil
+	case <-ctx.Done():
+		release()
+		return nil, ctx.Err()
+	}
+}
+
+// reserve reserves a time slot for a request, and returns the duration to wait
+// before sending it.
+func (l *Limiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	at := now
+	if l.next.After(at) {
+		at = l.next
+	}
+	l.next = at.Add(max(l.interval, l.budgetInterval(at)))
+
+	if l.remaining > 0 {
+		l.remaining--
+	}
+
+	return at.Sub(now)
+}
+
+// budgetInterval returns the interval between two requests needed to stay within the
+// rate limit budget. The requests are only slowed down once the remaining budget is low,
+// they are then sent at the pace the budget is refilled.
+func (l *Limiter) budgetInterval(now time.Time) time.Duration {
+	if l.limit <= 0 || l.reset.IsZero() {
+		return 0
+	}
+	if float64(l.remaining) > float64(l.limit)*lowBudgetRatio {
+		return 0
+	}
+
+	consumed := l.limit - l.remaining
+	untilReset := l.reset.Sub(now)
+	if consumed <= 0 || untilReset <= 0 {
+		return 0
+	}
+
+	return untilReset / time.Duration(consumed)
+}
+
+// Observe updates the rate limit budget from the headers of an API response.
+func (l *Limiter) Observe(header http.Header) {
+	limit, err := strconv.Atoi(header.Get("RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(header.Get("RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limit = limit
+	l.remaining = remaining
+	l.reset = time.Unix(reset, 0)
+}
+
+type limiterTransport struct {
+	limiter *Limiter
+	next    http.RoundTripper
+}
+
+// NewLimiterTransport returns a [http.RoundTripper] throttling the requests using the
+// limiter, before sending them with next.
+func NewLimiterTransport(limiter *Limiter, next http.RoundTripper) http.RoundTripper {
+	return &limiterTransport{limiter: limiter, next: next}
+}
+
+func (t *limiterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	release, err := t.limiter.Wait(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if waited := time.Since(start); waited >= time.Millisecond {
+		trace.SpanFromContext(req.Context()).AddEvent("throttled", trace.WithAttributes(
+			attribute.String("wait", waited.String()),
+		))
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	t.limiter.Observe(resp.Header)
+
+	return resp, nil
+}
@@ -0,0 +1,151 @@
+package transportutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sendRequests(t *testing.T, client *http.Client, url string, count int) {
+	t.Helper()
+
+	wg := sync.WaitGroup{}
+	for range count {
+		wg.Go(func() {
+			resp, err := client.Get(url)
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestLimiterTransportMaxRequestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	limiter := NewLimiter()
+	limiter.SetLimits(20, 0)
+	client := &http.Client{Transport: NewLimiterTransport(limiter, http.DefaultTransport)}
+
+	start := time.Now()
+	sendRequests(t, client, server.URL, 5)
+
+	// The first request is sent immediately, the 4 others are spaced by 50ms.
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestLimiterTransportMaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			previous := maxInFlight.Load()
+			if current <= previous || maxInFlight.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	limiter := NewLimiter()
+	limiter.SetLimits(0, 2)
+	client := &http.Client{Transport: NewLimiterTransport(limiter, http.DefaultTransport)}
+
+	sendRequests(t, client, server.URL, 10)
+
+	assert.Equal(t, int32(2), maxInFlight.Load())
+}
+
+func TestLimiterTransportRateLimitHeaders(t *testing.T) {
+	var remaining atomic.Int32
+	remaining.Store(20)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		current := remaining.Add(-1)
+
+		// The budget is fully replenished in ~10s, ~100ms per consumed request.
+		w.Header().Set("RateLimit-Limit", "100")
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(int(current)))
+		w.Header().Set("RateLimit-Reset", strconv.FormatInt(time.Now().Add(10*time.Second).Unix(), 10))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	limiter := NewLimiter()
+	client := &http.Client{Transport: NewLimiterTransport(limiter, http.DefaultTransport)}
+
+	// Remaining budget is high, requests are not slowed down.
+	start := time.Now()
+	for range 5 {
+		sendRequests(t, client, server.URL, 1)
+	}
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+
+	// Remaining budget is low, requests are sent at the pace the budget is refilled.
+	remaining.Store(5)
+	sendRequests(t, client, server.URL, 1)
+
+	start = time.Now()
+	for range 3 {
+		sendRequests(t, client, server.URL, 1)
+	}
+	assert.Greater(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestLimiterWaitContextCanceled(t *testing.T) {
+	limiter := NewLimiter()
+	limiter.SetLimits(0, 1)
+
+	release, err := limiter.Wait(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = limiter.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+
+	_, err = limiter.Wait(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestLimiterObserve(t *testing.T) {
+	limiter := NewLimiter()
+
+	// Incomplete headers are ignored
+	limiter.Observe(http.Header{"Ratelimit-Limit": []string{"3600"}})
+	assert.Equal(t, 0, limiter.limit)
+
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	limiter.Observe(http.Header{
+		"Ratelimit-Limit":     []string{"3600"},
+		"Ratelimit-Remaining": []string{"10"},
+		"Ratelimit-Reset":     []string{strconv.FormatInt(reset.Unix(), 10)},
+	})
+	assert.Equal(t, 3600, limiter.limit)
+	assert.Equal(t, 10, limiter.remaining)
+	assert.Equal(t, reset, limiter.reset)
+
+	// 3590 requests are refilled in 1 hour
+	assert.Equal(t, time.Hour/3590, limiter.budgetInterval(reset.Add(-time.Hour)))
+}
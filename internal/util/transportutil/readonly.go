@@ -0,0 +1,39 @@
+package transportutil
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ReadOnlyError is returned when a request that could mutate a resource is sent while
+// the provider is in read-only mode.
+type ReadOnlyError struct {
+	Method string
+	Path   string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("provider is in read-only mode, refusing to send request: %s %s", e.Method, e.Path)
+}
+
+type readOnlyTransport struct {
+	next http.RoundTripper
+}
+
+// NewReadOnlyTransport returns a [http.RoundTripper] rejecting every request that could
+// mutate a resource, only GET and HEAD requests are sent with next.
+func NewReadOnlyTransport(next http.RoundTripper) http.RoundTripper {
+	return &readOnlyTransport{next: next}
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return t.next.RoundTrip(req)
+	default:
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, &ReadOnlyError{Method: req.Method, Path: req.URL.Path}
+	}
+}
@@ -0,0 +1,42 @@
+package transportutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyTransport(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewReadOnlyTransport(http.DefaultTransport)}
+
+	resp, err := client.Get(server.URL + "/servers")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, requests)
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+		req, err := http.NewRequest(method, server.URL+"/servers/42", strings.NewReader(`{}`))
+		require.NoError(t, err)
+
+		_, err = client.Do(req)
+
+		var readOnlyErr *ReadOnlyError
+		require.ErrorAs(t, err, &readOnlyErr)
+		assert.Equal(t, method, readOnlyErr.Method)
+		assert.Equal(t, "/servers/42", readOnlyErr.Path)
+		assert.ErrorContains(t, err, "provider is in read-only mode, refusing to send request: "+method+" /servers/42")
+	}
+	assert.Equal(t, 1, requests)
+}
@@ -0,0 +1,266 @@
+package transportutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+const (
+	// DefaultRetryMaxAttempts is the default maximum number of attempts of a request.
+	DefaultRetryMaxAttempts = 5
+	// DefaultRetryMaxBackoff is the default maximum duration to wait between two attempts.
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+// DefaultRetryableErrorCodes returns the API error codes that are retried by default. In
+// addition, idempotent requests failing with a 5xx status code or a network timeout are
+// always retried.
+func DefaultRetryableErrorCodes() []string {
+	return []string{
+		string(hcloud.ErrorCodeConflict),
+		string(hcloud.ErrorCodeLocked),
+		string(hcloud.ErrorCodeRateLimitExceeded),
+		string(hcloud.ErrorCodeTimeout),
+	}
+}
+
+// RetryConfig holds the retry policy of the requests sent to the API.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of attempts of a request, zero means
+	// [DefaultRetryMaxAttempts].
+	MaxAttempts int
+	// MaxBackoff is the maximum duration to wait between two attempts, zero means
+	// [DefaultRetryMaxBackoff].
+	MaxBackoff time.Duration
+	// RetryableErrorCodes are the API error codes to retry, nil means
+	// [DefaultRetryableErrorCodes].
+	RetryableErrorCodes []string
+}
+
+func (c RetryConfig) withDefaults() RetryConfig {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = DefaultRetryMaxBackoff
+	}
+	if c.RetryableErrorCodes == nil {
+		c.RetryableErrorCodes = DefaultRetryableErrorCodes()
+	}
+	return c
+}
+
+type retryTransport struct {
+	config  RetryConfig
+	backoff hcloud.BackoffFunc
+	next    http.RoundTripper
+}
+
+// NewRetryTransport returns a [http.RoundTripper] retrying the requests sent with next,
+// when they fail with a transient error.
+func NewRetryTransport(config RetryConfig, next http.RoundTripper) http.RoundTripper {
+	config = config.withDefaults()
+	return &retryTransport{
+		config: config,
+		backoff: hcloud.ExponentialBackoffWithOpts(hcloud.ExponentialBackoffOpts{
+			Base:       time.Second,
+			Multiplier: 2,
+			Cap:        config.MaxBackoff,
+			Jitter:     true,
+		}),
+		next: next,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+
+		reason := t.retryReason(req, resp, err)
+		if reason == "" || !rewindable(req) {
+			return resp, err
+		}
+		if attempt >= t.config.MaxAttempts {
+			return markRetried(resp, err)
+		}
+
+		sleep := t.backoff(attempt - 1)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(sleep).After(deadline) {
+			// Retrying would exceed the context deadline, return the last attempt.
+			return markRetried(resp, err)
+		}
+
+		tflog.Warn(ctx, "retrying request", map[string]any{
+			"method":   req.Method,
+			"path":     req.URL.Path,
+			"attempt":  attempt,
+			"attempts": t.config.MaxAttempts,
+			"backoff":  sleep.String(),
+			"reason":   reason,
+		})
+		trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+			attribute.Int("attempt", attempt),
+			attribute.String("backoff", sleep.String()),
+			attribute.String("reason", reason),
+		))
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(sleep)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryReason returns why the request should be retried, or an empty string if it must
+// not be retried.
+//
+// A network timeout or a 5xx status code does not tell whether the request was
+// processed by the API, so only idempotent requests are retried in this case. Other
+// requests, e.g. creating a server, are only retried when the API rejected them with a
+// 4xx status code and a retryable error code.
+func (t *retryTransport) retryReason(req *http.Request, resp *http.Response, err error) string {
+	if err != nil {
+		var netErr net.Error
+		if idempotent(req) && errors.As(err, &netErr) && netErr.Timeout() && !errors.Is(err, context.DeadlineExceeded) {
+			return err.Error()
+		}
+		return ""
+	}
+
+	if resp.StatusCode < 400 {
+		return ""
+	}
+	if resp.StatusCode >= 500 && !idempotent(req) {
+		return ""
+	}
+
+	if code := readErrorCode(resp); code != "" && slices.Contains(t.config.RetryableErrorCodes, code) {
+		return code
+	}
+	if resp.StatusCode >= 500 {
+		return resp.Status
+	}
+	return ""
+}
+
+// idempotent reports whether sending the request several times has the same effect
+// as sending it once.
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// retriedHeader marks the responses of the requests the retry transport gave up on.
+const retriedHeader = "X-Hcloud-Provider-Retried"
+
+type retriedError struct {
+	err error
+}
+
+func (e *retriedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retriedError) Unwrap() error {
+	return e.err
+}
+
+// markRetried marks the result of a request the retry transport gave up on, so it is
+// not retried again by the caller, see [IsRetried].
+func markRetried(resp *http.Response, err error) (*http.Response, error) {
+	if err != nil {
+		return resp, &retriedError{err: err}
+	}
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+	resp.Header.Set(retriedHeader, "true")
+	return resp, nil
+}
+
+// IsRetried reports whether err was returned by a request that was already retried
+// according to the retry policy, and must not be retried again.
+func IsRetried(err error) bool {
+	var retriedErr *retriedError
+	if errors.As(err, &retriedErr) {
+		return true
+	}
+
+	var apiErr hcloud.Error
+	if errors.As(err, &apiErr) && apiErr.Response() != nil && apiErr.Response().Response != nil {
+		return apiErr.Response().Header.Get(retriedHeader) != ""
+	}
+	return false
+}
+
+// readErrorCode returns the error code of an API error response. The response body is
+// buffered, so it can still be read by the caller.
+func readErrorCode(resp *http.Response) string {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return payload.Error.Code
+}
+
+// rewindable reports whether the request can be sent again.
+func rewindable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewind returns a copy of the request with a fresh body, ready to be sent again.
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	cloned := req.Clone(req.Context())
+	cloned.Body = body
+	return cloned, nil
+}
@@ -0,0 +1,193 @@
+package transportutil
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func newRetryTestServer(t *testing.T, responses ...string) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	bodies := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+
+		response := responses[min(len(bodies), len(responses))-1]
+		switch response {
+		case "ok":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{}`))
+		case "unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":{"code":"` + response + `","message":"error"}}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &bodies
+}
+
+func newRetryTestClient(config RetryConfig) *http.Client {
+	transport := NewRetryTransport(config, http.DefaultTransport).(*retryTransport)
+	transport.backoff = func(_ int) time.Duration { return time.Millisecond }
+	return &http.Client{Transport: transport}
+}
+
+func TestRetryTransport(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		config         RetryConfig
+		responses      []string
+		wantAttempts   int
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "success",
+			responses:      []string{"ok"},
+			wantAttempts:   1,
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{}`,
+		},
+		{
+			name:           "retry locked",
+			responses:      []string{"locked", "conflict", "ok"},
+			wantAttempts:   3,
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{}`,
+		},
+		{
+			name:           "retry 5xx",
+			method:         http.MethodPut,
+			responses:      []string{"unavailable", "ok"},
+			wantAttempts:   2,
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{}`,
+		},
+		{
+			name:           "non idempotent 5xx",
+			responses:      []string{"unavailable", "ok"},
+			wantAttempts:   1,
+			wantStatusCode: http.StatusServiceUnavailable,
+			wantBody:       ``,
+		},
+		{
+			name:           "non retryable error",
+			responses:      []string{"uniqueness_error", "ok"},
+			wantAttempts:   1,
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"error":{"code":"uniqueness_error","message":"error"}}`,
+		},
+		{
+			name:           "max attempts",
+			config:         RetryConfig{MaxAttempts: 2},
+			responses:      []string{"locked"},
+			wantAttempts:   2,
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"error":{"code":"locked","message":"error"}}`,
+		},
+		{
+			name:           "custom retryable error codes",
+			config:         RetryConfig{RetryableErrorCodes: []string{"uniqueness_error"}},
+			responses:      []string{"uniqueness_error", "locked", "ok"},
+			wantAttempts:   2,
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"error":{"code":"locked","message":"error"}}`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			server, bodies := newRetryTestServer(t, tt.responses...)
+			client := newRetryTestClient(tt.config)
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req, err := http.NewRequestWithContext(t.Context(), method, server.URL, strings.NewReader(`{"name":"test"}`))
+			require.NoError(t, err)
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.Equal(t, tt.wantBody, string(body))
+			assert.Len(t, *bodies, tt.wantAttempts)
+			for _, body := range *bodies {
+				assert.Equal(t, `{"name":"test"}`, body)
+			}
+		})
+	}
+}
+
+func TestRetryTransportContextDeadline(t *testing.T) {
+	server, bodies := newRetryTestServer(t, "locked", "ok")
+
+	transport := NewRetryTransport(RetryConfig{}, http.DefaultTransport).(*retryTransport)
+	transport.backoff = func(_ int) time.Duration { return time.Minute }
+	client := &http.Client{Transport: transport}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// Waiting for the backoff would exceed the deadline, the last response is returned.
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Len(t, *bodies, 1)
+}
+
+func TestIsRetried(t *testing.T) {
+	server, bodies := newRetryTestServer(t, "locked", "locked", "uniqueness_error")
+
+	transport := NewRetryTransport(RetryConfig{MaxAttempts: 2}, http.DefaultTransport).(*retryTransport)
+	transport.backoff = func(_ int) time.Duration { return time.Millisecond }
+	client := hcloud.NewClient(
+		hcloud.WithEndpoint(server.URL),
+		hcloud.WithHTTPClient(&http.Client{Transport: transport}),
+		hcloud.WithRetryOpts(hcloud.RetryOpts{MaxRetries: 0}),
+	)
+
+	// The retry transport gave up after 2 attempts.
+	_, _, err := client.Server.GetByID(t.Context(), 1)
+	require.True(t, hcloud.IsError(err, hcloud.ErrorCodeLocked))
+	assert.True(t, IsRetried(err))
+	assert.Len(t, *bodies, 2)
+
+	// The error is not retryable.
+	_, _, err = client.Server.GetByID(t.Context(), 1)
+	require.True(t, hcloud.IsError(err, hcloud.ErrorCodeUniquenessError))
+	assert.False(t, IsRetried(err))
+	assert.Len(t, *bodies, 3)
+
+	assert.False(t, IsRetried(errors.New("test")))
+}
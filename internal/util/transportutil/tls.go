@@ -0,0 +1,101 @@
+package transportutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TLSConfig holds the TLS settings of the connections to the API.
+type TLSConfig struct {
+	// CABundle holds PEM encoded certificates, trusted in addition to the system
+	// certificate pool.
+	CABundle string
+	// ClientCertificate is the PEM encoded certificate presented to the API.
+	ClientCertificate string
+	// ClientKey is the PEM encoded private key of the client certificate.
+	ClientKey string
+	// InsecureSkipVerify disables the verification of the certificate presented by the API.
+	InsecureSkipVerify bool
+}
+
+// IsZero reports whether the default TLS settings are used.
+func (c TLSConfig) IsZero() bool {
+	return c == TLSConfig{}
+}
+
+// Build returns the [tls.Config] of the connections to the API.
+func (c TLSConfig) Build() (*tls.Config, error) {
+	result := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify, // nolint:gosec
+	}
+
+	if c.CABundle != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(c.CABundle)) {
+			return nil, errors.New("the CA bundle does not contain any PEM encoded certificate")
+		}
+		result.RootCAs = pool
+	}
+
+	if c.ClientCertificate != "" || c.ClientKey != "" {
+		if c.ClientCertificate == "" || c.ClientKey == "" {
+			return nil, errors.New("the client certificate and the client key must be configured together")
+		}
+		certificate, err := tls.X509KeyPair([]byte(c.ClientCertificate), []byte(c.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("could not load the client certificate: %w", err)
+		}
+		result.Certificates = []tls.Certificate{certificate}
+	}
+
+	return result, nil
+}
+
+// ParseProxyURL parses the URL of a proxy. Supported schemes are http, https and socks5.
+func ParseProxyURL(value string) (*url.URL, error) {
+	proxyURL, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the proxy URL: %w", err)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported proxy URL scheme %q, must be one of http, https or socks5", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, errors.New("the proxy URL must contain a host")
+	}
+	return proxyURL, nil
+}
+
+// newBaseTransport returns the transport opening the connections to the API, using the
+// TLS and proxy settings of the config.
+func newBaseTransport(config Config) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if !config.TLS.IsZero() {
+		tlsConfig, err := config.TLS.Build()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	if config.HTTPProxy != "" {
+		proxyURL, err := ParseProxyURL(config.HTTPProxy)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return transport, nil
+}
@@ -0,0 +1,142 @@
+package transportutil
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/testsupport"
+)
+
+func newTLSTestServer(t *testing.T, clientAuth tls.ClientAuthType) (*httptest.Server, string) {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			w.Write([]byte(r.TLS.PeerCertificates[0].Subject.Organization[0]))
+		}
+	}))
+	server.TLS = &tls.Config{ClientAuth: clientAuth, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return server, string(caBundle)
+}
+
+func sendTLSTestRequest(t *testing.T, config Config, url string) (*http.Response, error) {
+	t.Helper()
+
+	client, err := NewHTTPClient(config)
+	require.NoError(t, err)
+
+	resp, err := client.Get(url)
+	if err == nil {
+		t.Cleanup(func() { resp.Body.Close() })
+	}
+	return resp, err
+}
+
+func TestNewHTTPClientTLS(t *testing.T) {
+	server, caBundle := newTLSTestServer(t, tls.RequestClientCert)
+
+	t.Run("unknown authority", func(t *testing.T) {
+		_, err := sendTLSTestRequest(t, Config{}, server.URL)
+		assert.ErrorContains(t, err, "certificate signed by unknown authority")
+	})
+
+	t.Run("ca bundle", func(t *testing.T) {
+		resp, err := sendTLSTestRequest(t, Config{TLS: TLSConfig{CABundle: caBundle}}, server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("insecure skip verify", func(t *testing.T) {
+		resp, err := sendTLSTestRequest(t, Config{TLS: TLSConfig{InsecureSkipVerify: true}}, server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestNewHTTPClientClientCertificate(t *testing.T) {
+	server, caBundle := newTLSTestServer(t, tls.RequireAnyClientCert)
+
+	certificate, key, err := testsupport.RandTLSCert("client.example.com")
+	require.NoError(t, err)
+
+	_, err = sendTLSTestRequest(t, Config{TLS: TLSConfig{CABundle: caBundle}}, server.URL)
+	assert.Error(t, err)
+
+	resp, err := sendTLSTestRequest(t, Config{TLS: TLSConfig{
+		CABundle:          caBundle,
+		ClientCertificate: certificate,
+		ClientKey:         key,
+	}}, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	var proxied *url.URL
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	resp, err := sendTLSTestRequest(t, Config{HTTPProxy: proxy.URL}, "http://api.example.com/v1/servers")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotNil(t, proxied)
+	assert.Equal(t, "http://api.example.com/v1/servers", proxied.String())
+}
+
+func TestNewHTTPClientInvalidConfig(t *testing.T) {
+	certificate, _, err := testsupport.RandTLSCert("client.example.com")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{
+			name:    "invalid ca bundle",
+			config:  Config{TLS: TLSConfig{CABundle: "invalid"}},
+			wantErr: "the CA bundle does not contain any PEM encoded certificate",
+		},
+		{
+			name:    "missing client key",
+			config:  Config{TLS: TLSConfig{ClientCertificate: certificate}},
+			wantErr: "the client certificate and the client key must be configured together",
+		},
+		{
+			name:    "invalid client key",
+			config:  Config{TLS: TLSConfig{ClientCertificate: certificate, ClientKey: "invalid"}},
+			wantErr: "could not load the client certificate: tls: failed to find any PEM data in key input",
+		},
+		{
+			name:    "invalid proxy scheme",
+			config:  Config{HTTPProxy: "ftp://proxy.example.com"},
+			wantErr: `unsupported proxy URL scheme "ftp", must be one of http, https or socks5`,
+		},
+		{
+			name:    "missing proxy host",
+			config:  Config{HTTPProxy: "http://"},
+			wantErr: "the proxy URL must contain a host",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHTTPClient(tt.config)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
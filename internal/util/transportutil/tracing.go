@@ -0,0 +1,65 @@
+package transportutil
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/traceutil"
+)
+
+type tracingTransport struct {
+	next http.RoundTripper
+}
+
+// NewTracingTransport returns a [http.RoundTripper] creating a span for every request
+// sent with next. The span is a child of the span found in the request context, and
+// covers the time spent throttling and retrying the request.
+func NewTracingTransport(next http.RoundTripper) http.RoundTripper {
+	return &tracingTransport{next: next}
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := traceutil.Tracer().Start(req.Context(), req.Method+" "+routePath(req.URL.Path),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLPath(req.URL.Path),
+			semconv.ServerAddress(req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		if code := readErrorCode(resp); code != "" {
+			span.SetAttributes(traceutil.ErrorCodeKey.String(code))
+		}
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
+
+// routePath replaces the IDs in a path with a placeholder, to keep the span names
+// low-cardinality, e.g. "/servers/42/actions/poweroff" becomes
+// "/servers/{id}/actions/poweroff".
+func routePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment != "" && strings.Trim(segment, "0123456789") == "" {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
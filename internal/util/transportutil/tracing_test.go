@@ -0,0 +1,58 @@
+package transportutil
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/traceutil"
+)
+
+func TestTracingTransport(t *testing.T) {
+	exporter := traceutil.NewInMemoryExporter(t)
+
+	server, _ := newRetryTestServer(t, "locked", "uniqueness_error")
+	client := newRetryTestClient(RetryConfig{})
+	client.Transport = NewTracingTransport(client.Transport)
+
+	ctx, parent := traceutil.Start(t.Context(), "parent")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/servers/42/actions/poweroff", nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	span := spans[0]
+
+	assert.Equal(t, "POST /servers/{id}/actions/poweroff", span.Name)
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), span.Parent.SpanID())
+	assert.Contains(t, span.Attributes, semconv.HTTPRequestMethodKey.String("POST"))
+	assert.Contains(t, span.Attributes, semconv.URLPath("/servers/42/actions/poweroff"))
+	assert.Contains(t, span.Attributes, semconv.HTTPResponseStatusCode(http.StatusConflict))
+	assert.Contains(t, span.Attributes, traceutil.ErrorCodeKey.String("uniqueness_error"))
+	assert.Equal(t, codes.Error, span.Status.Code)
+
+	// The retried attempt is recorded as an event of the request span.
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "retry", span.Events[0].Name)
+	assert.Contains(t, span.Events[0].Attributes, attribute.String("reason", "locked"))
+}
+
+func TestRoutePath(t *testing.T) {
+	assert.Equal(t, "/servers", routePath("/servers"))
+	assert.Equal(t, "/servers/{id}", routePath("/servers/42"))
+	assert.Equal(t, "/v1/servers/{id}/actions/{id}", routePath("/v1/servers/42/actions/1337"))
+	assert.Equal(t, "/zones/example.com/rrsets/www/A", routePath("/zones/example.com/rrsets/www/A"))
+}
@@ -0,0 +1,63 @@
+package validateutil
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/timeutil"
+)
+
+var _ validator.String = DurationValidator{}
+
+type DurationValidator struct{}
+
+func Duration() DurationValidator {
+	return DurationValidator{}
+}
+
+func (v DurationValidator) Description(_ context.Context) string {
+	return "must be a valid duration, e.g. 30s or 5m"
+}
+
+func (v DurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v DurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	raw := req.ConfigValue.ValueString()
+	if _, err := time.ParseDuration(raw); err != nil {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(req.Path, v.Description(ctx), raw))
+	}
+}
+
+var _ validator.String = RFC3339Validator{}
+
+type RFC3339Validator struct{}
+
+func RFC3339() RFC3339Validator {
+	return RFC3339Validator{}
+}
+
+func (v RFC3339Validator) Description(_ context.Context) string {
+	return "must be a valid RFC3339 timestamp, e.g. 2025-01-02T15:04:05Z"
+}
+
+func (v RFC3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v RFC3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	raw := req.ConfigValue.ValueString()
+	if _, err := timeutil.ParseRFC3339(raw); err != nil {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(req.Path, v.Description(ctx), raw))
+	}
+}
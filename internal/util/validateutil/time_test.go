@@ -0,0 +1,103 @@
+package validateutil
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationValidator(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		given types.String
+		want  diag.Diagnostics
+	}{
+		"unknown": {
+			given: types.StringUnknown(),
+		},
+		"null": {
+			given: types.StringNull(),
+		},
+		"valid": {
+			given: types.StringValue("1h30m"),
+		},
+		"invalid": {
+			given: types.StringValue("30"),
+			want: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.Root("test"),
+					"Invalid Attribute Value",
+					"Attribute test must be a valid duration, e.g. 30s or 5m, got: 30",
+				),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:           path.Root("test"),
+				PathExpression: path.MatchRoot("test"),
+				ConfigValue:    testCase.given,
+			}
+			resp := validator.StringResponse{}
+
+			DurationValidator{}.ValidateString(t.Context(), req, &resp)
+
+			assert.Equal(t, testCase.want, resp.Diagnostics)
+		})
+	}
+}
+
+func TestRFC3339Validator(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		given types.String
+		want  diag.Diagnostics
+	}{
+		"unknown": {
+			given: types.StringUnknown(),
+		},
+		"null": {
+			given: types.StringNull(),
+		},
+		"valid": {
+			given: types.StringValue("2025-01-02T15:04:05+01:00"),
+		},
+		"invalid": {
+			given: types.StringValue("2025-01-02 15:04:05"),
+			want: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(
+					path.Root("test"),
+					"Invalid Attribute Value",
+					"Attribute test must be a valid RFC3339 timestamp, e.g. 2025-01-02T15:04:05Z, got: 2025-01-02 15:04:05",
+				),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:           path.Root("test"),
+				PathExpression: path.MatchRoot("test"),
+				ConfigValue:    testCase.given,
+			}
+			resp := validator.StringResponse{}
+
+			RFC3339Validator{}.ValidateString(t.Context(), req, &resp)
+
+			assert.Equal(t, testCase.want, resp.Diagnostics)
+		})
+	}
+}
@@ -119,7 +119,7 @@ func DataSourceList() *schema.Resource {
 }
 
 func dataSourceHcloudVolumeRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	if id, ok := d.GetOk("id"); ok {
 		v, _, err := client.Volume.GetByID(ctx, util.CastInt64(id))
@@ -129,7 +129,7 @@ func dataSourceHcloudVolumeRead(ctx context.Context, d *schema.ResourceData, m a
 		if v == nil {
 			return diag.Errorf("no volume found with id %d", id)
 		}
-		setVolumeSchema(d, v)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getVolumeAttributes(v)))
 		return nil
 	}
 	if name, ok := d.GetOk("name"); ok {
@@ -140,7 +140,7 @@ func dataSourceHcloudVolumeRead(ctx context.Context, d *schema.ResourceData, m a
 		if v == nil {
 			return diag.Errorf("no volume found with name %v", name)
 		}
-		setVolumeSchema(d, v)
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getVolumeAttributes(v)))
 		return nil
 	}
 
@@ -174,14 +174,14 @@ func dataSourceHcloudVolumeRead(ctx context.Context, d *schema.ResourceData, m a
 		if len(allVolumes) > 1 {
 			return diag.Errorf("more than one volume found for selector %q", selector)
 		}
-		setVolumeSchema(d, allVolumes[0])
+		util.SetSchemaFromAttributes(d, hcloudutil.DataSourceAttributes(m, getVolumeAttributes(allVolumes[0])))
 		return nil
 	}
 	return diag.Errorf("please specify an id, a name or a selector to lookup the volume")
 }
 
 func dataSourceHcloudVolumeListRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	selector := d.Get("with_selector").(string)
 
@@ -205,7 +205,7 @@ func dataSourceHcloudVolumeListRead(ctx context.Context, d *schema.ResourceData,
 	tfVolume := make([]map[string]any, len(allVolumes))
 	for i, volume := range allVolumes {
 		ids[i] = util.FormatID(volume.ID)
-		tfVolume[i] = getVolumeAttributes(volume)
+		tfVolume[i] = hcloudutil.DataSourceAttributes(m, getVolumeAttributes(volume))
 	}
 	d.Set("volumes", tfVolume)
 	d.SetId(datasourceutil.ListID(ids))
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
@@ -29,6 +30,10 @@ func Resource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			hcloudutil.CustomizeDiffLabelsAll,
+			hcloudutil.CustomizeDiffDefaultLocation("location", "", "server_id"),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -60,6 +65,7 @@ func Resource() *schema.Resource {
 					return nil
 				},
 			},
+			"labels_all": hcloudutil.LabelsAllSchema(),
 			"linux_device": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -82,7 +88,7 @@ func Resource() *schema.Resource {
 }
 
 func resourceVolumeCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
 	opts := hcloud.VolumeCreateOpts{
 		Name: d.Get("name").(string),
@@ -95,13 +101,7 @@ func resourceVolumeCreate(ctx context.Context, d *schema.ResourceData, m any) di
 	if location, ok := d.GetOk("location"); ok {
 		opts.Location = &hcloud.Location{Name: location.(string)}
 	}
-	if labels, ok := d.GetOk("labels"); ok {
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
-		}
-		opts.Labels = tmpLabels
-	}
+	opts.Labels = hcloudutil.LabelsFromResourceData(d, m)
 	if automount, ok := d.GetOk("automount"); ok {
 		opts.Automount = new(automount.(bool))
 	}
@@ -118,11 +118,11 @@ func resourceVolumeCreate(ctx context.Context, d *schema.ResourceData, m any) di
 	}
 	d.SetId(util.FormatID(result.Volume.ID))
 
-	if err = c.Action.WaitFor(ctx, result.Action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, result.Action); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 	for _, nextAction := range result.NextActions {
-		if err = c.Action.WaitFor(ctx, nextAction); err != nil {
+		if err = hcloudutil.WaitForActions(ctx, &c.Action, nextAction); err != nil {
 			var aerr hcloud.ActionError
 
 			if nextAction.Command != "attach_volume" {
@@ -144,7 +144,7 @@ func resourceVolumeCreate(ctx context.Context, d *schema.ResourceData, m any) di
 			// call and it should work.
 			for _, resource := range nextAction.Resources {
 				if resource.Type == hcloud.ActionResourceTypeServer {
-					err := control.Retry(control.DefaultRetries, func() error {
+					err := control.Retry(ctx, control.DefaultRetries, func() error {
 						o := hcloud.VolumeAttachOpts{Server: opts.Server}
 						if automount, ok := d.GetOk("automount"); ok {
 							opts.Automount = new(automount.(bool))
@@ -153,7 +153,7 @@ func resourceVolumeCreate(ctx context.Context, d *schema.ResourceData, m any) di
 						if err != nil {
 							return err
 						}
-						return c.Action.WaitFor(ctx, action)
+						return hcloudutil.WaitForActions(ctx, &c.Action, action)
 					})
 					if err != nil {
 						return hcloudutil.ErrorToDiag(err)
@@ -174,7 +174,7 @@ func resourceVolumeCreate(ctx context.Context, d *schema.ResourceData, m any) di
 }
 
 func resourceVolumeRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	id, err := util.ParseID(d.Id())
 	if err != nil {
@@ -193,12 +193,16 @@ func resourceVolumeRead(ctx context.Context, d *schema.ResourceData, m any) diag
 		return nil
 	}
 
+	labels := d.Get("labels")
 	setVolumeSchema(d, volume)
+	if err := hcloudutil.SetResourceDataLabels(d, m, labels, volume.Labels); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
 func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
 	id, err := util.ParseID(d.Id())
 	if err != nil {
@@ -232,7 +236,7 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m any) di
 	if d.HasChange("server_id") {
 		serverID := util.CastInt64(d.Get("server_id"))
 		if serverID == 0 {
-			err := control.Retry(control.DefaultRetries, func() error {
+			err := control.Retry(ctx, control.DefaultRetries, func() error {
 				action, _, err := c.Volume.Detach(ctx, volume)
 				if err != nil {
 					if resourceVolumeIsNotFound(err, d) {
@@ -241,14 +245,14 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m any) di
 					return err
 				}
 
-				return c.Action.WaitFor(ctx, action)
+				return hcloudutil.WaitForActions(ctx, &c.Action, action)
 			})
 			if err != nil {
 				return hcloudutil.ErrorToDiag(err)
 			}
 		} else {
 			if volume.Server != nil {
-				err := control.Retry(control.DefaultRetries, func() error {
+				err := control.Retry(ctx, control.DefaultRetries, func() error {
 					action, _, err := c.Volume.Detach(ctx, volume)
 					if err != nil {
 						if resourceVolumeIsNotFound(err, d) {
@@ -257,13 +261,13 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m any) di
 						return err
 					}
 
-					return c.Action.WaitFor(ctx, action)
+					return hcloudutil.WaitForActions(ctx, &c.Action, action)
 				})
 				if err != nil {
 					return hcloudutil.ErrorToDiag(err)
 				}
 			}
-			err := control.Retry(control.DefaultRetries, func() error {
+			err := control.Retry(ctx, control.DefaultRetries, func() error {
 				opts := hcloud.VolumeAttachOpts{Server: &hcloud.Server{ID: serverID}}
 				if automount, ok := d.GetOk("automount"); ok {
 					opts.Automount = new(automount.(bool))
@@ -277,7 +281,7 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m any) di
 					return err
 				}
 
-				return c.Action.WaitFor(ctx, action)
+				return hcloudutil.WaitForActions(ctx, &c.Action, action)
 			})
 			if err != nil {
 				return hcloudutil.ErrorToDiag(err)
@@ -295,19 +299,24 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m any) di
 			return hcloudutil.ErrorToDiag(err)
 		}
 
-		if err = c.Action.WaitFor(ctx, action); err != nil {
+		if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 			return hcloudutil.ErrorToDiag(err)
 		}
 	}
 
-	if d.HasChange("labels") {
-		labels := d.Get("labels")
-		tmpLabels := make(map[string]string)
-		for k, v := range labels.(map[string]any) {
-			tmpLabels[k] = v.(string)
+	if d.HasChanges("labels", "labels_all") {
+		labels, err := hcloudutil.UpdateLabelsFromResourceData(d, m, func() (map[string]string, error) {
+			in, _, err := c.Volume.GetByID(ctx, volume.ID)
+			if err != nil || in == nil {
+				return nil, err
+			}
+			return in.Labels, nil
+		})
+		if err != nil {
+			return hcloudutil.ErrorToDiag(err)
 		}
-		_, _, err := c.Volume.Update(ctx, volume, hcloud.VolumeUpdateOpts{
-			Labels: tmpLabels,
+		_, _, err = c.Volume.Update(ctx, volume, hcloud.VolumeUpdateOpts{
+			Labels: labels,
 		})
 		if err != nil {
 			if resourceVolumeIsNotFound(err, d) {
@@ -329,7 +338,7 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m any) di
 }
 
 func resourceVolumeDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
 	volumeID, err := util.ParseID(d.Id())
 	if err != nil {
@@ -348,7 +357,7 @@ func resourceVolumeDelete(ctx context.Context, d *schema.ResourceData, m any) di
 	}
 
 	if volume.Server != nil {
-		err := control.Retry(control.DefaultRetries, func() error {
+		err := control.Retry(ctx, control.DefaultRetries, func() error {
 			action, _, err := c.Volume.Detach(ctx, volume)
 			if err != nil {
 				if resourceVolumeIsNotFound(err, d) {
@@ -357,13 +366,13 @@ func resourceVolumeDelete(ctx context.Context, d *schema.ResourceData, m any) di
 				return err
 			}
 
-			return c.Action.WaitFor(ctx, action)
+			return hcloudutil.WaitForActions(ctx, &c.Action, action)
 		})
 		if err != nil {
 			return hcloudutil.ErrorToDiag(err)
 		}
 	}
-	err = control.Retry(control.DefaultRetries, func() error {
+	err = control.Retry(ctx, control.DefaultRetries, func() error {
 		if _, err := c.Volume.Delete(ctx, volume); err != nil {
 			if resourceVolumeIsNotFound(err, d) {
 				return nil
@@ -420,5 +429,5 @@ func setProtection(ctx context.Context, c *hcloud.Client, v *hcloud.Volume, dele
 		return err
 	}
 
-	return c.Action.WaitFor(ctx, action)
+	return hcloudutil.WaitForActions(ctx, &c.Action, action)
 }
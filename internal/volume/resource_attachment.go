@@ -50,7 +50,7 @@ func AttachmentResource() *schema.Resource {
 func resourceVolumeAttachmentCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	var action *hcloud.Action
 
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
 	volumeID := d.Get("volume_id")
 	volume := &hcloud.Volume{ID: util.CastInt64(volumeID)}
@@ -66,7 +66,7 @@ func resourceVolumeAttachmentCreate(ctx context.Context, d *schema.ResourceData,
 		opts.Automount = new(automount.(bool))
 	}
 
-	err := control.Retry(control.DefaultRetries, func() error {
+	err := control.Retry(ctx, control.DefaultRetries, func() error {
 		var err error
 
 		action, _, err = c.Volume.AttachWithOpts(ctx, volume, opts)
@@ -82,7 +82,7 @@ func resourceVolumeAttachmentCreate(ctx context.Context, d *schema.ResourceData,
 	// we can use the volume id as volume attachment id.
 	d.SetId(util.FormatID(volume.ID))
 
-	if err = c.Action.WaitFor(ctx, action); err != nil {
+	if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 		return hcloudutil.ErrorToDiag(err)
 	}
 
@@ -90,7 +90,7 @@ func resourceVolumeAttachmentCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceVolumeAttachmentRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*hcloud.Client)
+	client := m.(*hcloudutil.ProviderData).Client
 
 	volumeID, err := util.ParseID(d.Id())
 	if err != nil {
@@ -142,7 +142,7 @@ func resourceVolumeAttachmentRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceVolumeAttachmentDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	c := m.(*hcloud.Client)
+	c := m.(*hcloudutil.ProviderData).Client
 
 	volumeID, err := util.ParseID(d.Id())
 	if err != nil {
@@ -165,7 +165,7 @@ func resourceVolumeAttachmentDelete(ctx context.Context, d *schema.ResourceData,
 	if volume.Server != nil {
 		var action *hcloud.Action
 
-		err := control.Retry(control.DefaultRetries, func() error {
+		err := control.Retry(ctx, control.DefaultRetries, func() error {
 			var err error
 
 			action, _, err = c.Volume.Detach(ctx, volume)
@@ -178,7 +178,7 @@ func resourceVolumeAttachmentDelete(ctx context.Context, d *schema.ResourceData,
 			return hcloudutil.ErrorToDiag(err)
 		}
 
-		if err = c.Action.WaitFor(ctx, action); err != nil {
+		if err = hcloudutil.WaitForActions(ctx, &c.Action, action); err != nil {
 			return hcloudutil.ErrorToDiag(err)
 		}
 	}
@@ -23,6 +23,7 @@ var _ datasource.DataSourceWithConfigure = (*DataSourceList)(nil)
 
 type DataSourceList struct {
 	client *hcloud.Client
+	labels hcloudutil.LabelsConfig
 }
 
 func NewDataSourceList() datasource.DataSource {
@@ -38,13 +39,14 @@ func (d *DataSourceList) Metadata(_ context.Context, _ datasource.MetadataReques
 // provider-defined DataSource type. It is separately executed for each
 // ReadDataSource RPC.
 func (d *DataSourceList) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
-	var newDiags diag.Diagnostics
-
-	d.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
 	resp.Diagnostics.Append(newDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	d.client = providerData.Client
+	d.labels = providerData.Labels
 }
 
 // Schema should return the schema for this data source.
@@ -77,6 +79,8 @@ type dataSourceListModel struct {
 	Zones types.List   `tfsdk:"zones"`
 
 	WithSelector types.String `tfsdk:"with_selector"`
+
+	labelsConfig hcloudutil.LabelsConfig
 }
 
 func populateDataSourceListModel(ctx context.Context, data *dataSourceListModel, in []*hcloud.Zone) diag.Diagnostics {
@@ -90,6 +94,7 @@ func populateDataSourceListModel(ctx context.Context, data *dataSourceListModel,
 		tfIDs = append(tfIDs, util.FormatID(item.ID))
 
 		var value model
+		value.labelsConfig = data.labelsConfig
 		diags.Append(value.FromAPI(ctx, item)...)
 
 		tfItem, newDiags := value.ToTerraform(ctx)
@@ -130,6 +135,7 @@ func (d *DataSourceList) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
+	data.labelsConfig = d.labels
 	resp.Diagnostics.Append(populateDataSourceListModel(ctx, &data, result)...)
 	if resp.Diagnostics.HasError() {
 		return
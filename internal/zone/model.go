@@ -10,6 +10,7 @@ import (
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/resourceutil"
 )
 
@@ -24,6 +25,9 @@ type model struct {
 
 	AuthoritativeNameservers types.Object `tfsdk:"authoritative_nameservers"`
 	Registrar                types.String `tfsdk:"registrar"`
+
+	// labelsConfig filters the labels ignored by the provider.
+	labelsConfig hcloudutil.LabelsConfig
 }
 
 func (m *model) tfAttributesTypes() map[string]attr.Type {
@@ -56,7 +60,7 @@ func (m *model) FromAPI(ctx context.Context, hc *hcloud.Zone) diag.Diagnostics {
 	m.Mode = types.StringValue(string(hc.Mode))
 	m.TTL = types.Int32Value(int32(hc.TTL)) // nolint: gosec
 
-	m.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, hc.Labels)
+	m.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, m.labelsConfig, hc.Labels)
 	diags.Append(newDiags...)
 
 	m.DeleteProtection = types.BoolValue(hc.Protection.Delete)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/hcloudutil"
 	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/resourceutil"
 )
 
@@ -22,6 +23,9 @@ type model struct {
 	Labels           types.Map    `tfsdk:"labels"`
 	ChangeProtection types.Bool   `tfsdk:"change_protection"`
 	Records          types.Set    `tfsdk:"records"`
+
+	// labelsConfig filters the labels ignored by the provider.
+	labelsConfig hcloudutil.LabelsConfig
 }
 
 func (m *model) tfAttributesTypes() map[string]attr.Type {
@@ -58,7 +62,7 @@ func (m *model) FromAPI(ctx context.Context, hc *hcloud.ZoneRRSet) diag.Diagnost
 		m.TTL = types.Int32Null()
 	}
 
-	m.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, hc.Labels)
+	m.Labels, newDiags = resourceutil.LabelsMapValueFrom(ctx, m.labelsConfig, hc.Labels)
 	diags.Append(newDiags...)
 
 	m.ChangeProtection = types.BoolValue(hc.Protection.Change)
@@ -26,10 +26,12 @@ const ResourceType = "hcloud_zone_rrset"
 
 var _ resource.Resource = (*Resource)(nil)
 var _ resource.ResourceWithConfigure = (*Resource)(nil)
+var _ resource.ResourceWithModifyPlan = (*Resource)(nil)
 var _ resource.ResourceWithImportState = (*Resource)(nil)
 
 type Resource struct {
 	client *hcloud.Client
+	labels hcloudutil.LabelsConfig
 }
 
 func NewResource() resource.Resource {
@@ -45,13 +47,14 @@ func (r *Resource) Metadata(_ context.Context, _ resource.MetadataRequest, resp
 // provider-defined DataSource type. It is separately executed for each
 // ReadDataSource RPC.
 func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	var newDiags diag.Diagnostics
-
-	r.client, newDiags = hcloudutil.ConfigureClient(req.ProviderData)
+	providerData, newDiags := hcloudutil.ConfigureProviderData(req.ProviderData)
 	resp.Diagnostics.Append(newDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	r.client = providerData.Client
+	r.labels = providerData.Labels
 }
 
 func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -113,7 +116,8 @@ the parent Zone, therefor this Terraform resource will:
 			MarkdownDescription: "Time To Live (TTL) of the Zone RRSet.",
 			Optional:            true,
 		},
-		"labels": resourceutil.LabelsSchema(),
+		"labels":     resourceutil.LabelsSchema(),
+		"labels_all": resourceutil.LabelsAllSchema(),
 		"change_protection": schema.BoolAttribute{
 			MarkdownDescription: "Whether change protection is enabled.",
 			Optional:            true,
@@ -141,8 +145,18 @@ the parent Zone, therefor this Terraform resource will:
 	}
 }
 
+type resourceModel struct {
+	model
+
+	LabelsAll types.Map `tfsdk:"labels_all"`
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resourceutil.ModifyPlanLabelsAll(ctx, r.labels, req, resp)
+}
+
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data model
+	var data resourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -158,8 +172,8 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	if !data.TTL.IsUnknown() && !data.TTL.IsNull() {
 		opts.TTL = new(int(data.TTL.ValueInt32()))
 	}
-	if !data.Labels.IsUnknown() && !data.Labels.IsNull() {
-		hcloudutil.TerraformLabelsToHCloud(ctx, data.Labels, &opts.Labels)
+	if !data.LabelsAll.IsUnknown() && !data.LabelsAll.IsNull() {
+		hcloudutil.TerraformLabelsToHCloud(ctx, data.LabelsAll, &opts.Labels)
 	}
 	if !data.Records.IsUnknown() && !data.Records.IsNull() {
 		values := modelRecords{}
@@ -237,16 +251,22 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	OverrideRecordsSOASerial(in)
 
+	configuredLabels := data.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data model
+	var data resourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -268,16 +288,22 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 
 	OverrideRecordsSOASerial(in)
 
+	configuredLabels := data.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data, plan model
+	var data, plan resourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -356,8 +382,19 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	// the update.
 	opts := hcloud.ZoneRRSetUpdateOpts{}
 
-	if !plan.Labels.IsUnknown() && !plan.Labels.Equal(data.Labels) {
-		hcloudutil.TerraformLabelsToHCloud(ctx, plan.Labels, &opts.Labels)
+	var newDiags diag.Diagnostics
+	if !plan.LabelsAll.IsUnknown() && !plan.LabelsAll.Equal(data.LabelsAll) {
+		opts.Labels, newDiags = resourceutil.LabelsForUpdate(ctx, r.labels, plan.LabelsAll, func() (map[string]string, error) {
+			in, _, err := r.client.Zone.GetRRSetByID(ctx, rrset.Zone, rrset.ID)
+			if err != nil || in == nil {
+				return nil, err
+			}
+			return in.Labels, nil
+		})
+		resp.Diagnostics.Append(newDiags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	in, _, err := r.client.Zone.UpdateRRSet(ctx, rrset, opts)
@@ -376,7 +413,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 			return
 		}
 
-		if err := r.client.Action.WaitFor(ctx, action); err != nil {
+		if err := hcloudutil.WaitForActions(ctx, &r.client.Action, action); err != nil {
 			resp.Diagnostics.Append(hcloudutil.APIErrorDiagnostics(err)...)
 			return
 		}
@@ -387,16 +424,22 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	OverrideRecordsSOASerial(in)
 
+	configuredLabels := plan.Labels
 	resp.Diagnostics.Append(data.FromAPI(ctx, in)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(resourceutil.LabelsAllFromAPI(ctx, r.labels, configuredLabels, in.Labels, &data.Labels, &data.LabelsAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data model
+	var data resourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 
 	"github.com/hetznercloud/terraform-provider-hcloud/hcloud"
+	"github.com/hetznercloud/terraform-provider-hcloud/internal/util/traceutil"
 )
 
 func main() {
@@ -18,6 +19,11 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
+	shutdownTracing, err := traceutil.Setup(ctx, hcloud.Version)
+	if err != nil {
+		log.Printf("[WARN] tracing is disabled: %s", err)
+	}
+
 	providerFactory, err := hcloud.GetMuxedProvider(ctx)
 	if err != nil {
 		log.Fatal(err)
@@ -35,6 +41,10 @@ func main() {
 		serveOpts...,
 	)
 
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("[WARN] could not export the traces: %s", err)
+	}
+
 	if err != nil {
 		log.Fatal(err)
 	}